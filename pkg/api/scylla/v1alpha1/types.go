@@ -7,4 +7,109 @@ const (
 	ProgressingCondition = "Progressing"
 	DegradedCondition    = "Degraded"
 	PrewarmedCondition   = "Prewarmed"
+
+	// DowngradeDetectedCondition indicates that the desired ScyllaDB version is lower than the version in use.
+	DowngradeDetectedCondition = "DowngradeDetected"
+
+	// NoRacksDefinedCondition indicates that the datacenter spec doesn't define any racks.
+	NoRacksDefinedCondition = "NoRacksDefined"
+
+	// ImagePullFailingCondition indicates that images of some member Pods can't be pulled.
+	ImagePullFailingCondition = "ImagePullFailing"
+
+	// SchemaDisagreementCondition indicates that nodes of the datacenter report different schema versions.
+	SchemaDisagreementCondition = "SchemaDisagreement"
+
+	// MemberServicesReadyCondition indicates whether all member Services expected by the spec exist.
+	MemberServicesReadyCondition = "MemberServicesReady"
+
+	// ServiceIPAllocationFailedCondition indicates that some member Services didn't get a cluster IP assigned,
+	// e.g. due to exhaustion of the service IP range, which prevents their nodes from joining the cluster.
+	ServiceIPAllocationFailedCondition = "ServiceIPAllocationFailed"
+
+	// StuckTerminatingCondition indicates that some member Pods are still terminating after their grace period has passed.
+	StuckTerminatingCondition = "StuckTerminating"
+
+	// ShardCountMismatchCondition indicates that nodes in the same rack have differing shard counts.
+	ShardCountMismatchCondition = "ShardCountMismatch"
+
+	// StorageClassMismatchCondition indicates that the storage class of some racks' StatefulSets
+	// differs from the desired one. It can't be reconciled without recreating the PersistentVolumeClaims.
+	StorageClassMismatchCondition = "StorageClassMismatch"
+
+	// EditionMismatchCondition indicates that the racks' images resolve to different ScyllaDB editions,
+	// mixing Enterprise and open source nodes in a single datacenter.
+	EditionMismatchCondition = "EditionMismatch"
+
+	// DiskPressureCondition indicates that some nodes use most of their data volume capacity
+	// and their storage should be expanded before they stop accepting writes.
+	DiskPressureCondition = "DiskPressure"
+
+	// IntraRackVersionSkewCondition indicates that nodes within some racks have been running different ScyllaDB
+	// versions for longer than an upgrade is expected to take, e.g. because the upgrade got stuck mid-rack.
+	IntraRackVersionSkewCondition = "IntraRackVersionSkew"
+
+	// RacksAvailableCondition indicates whether all nodes of every rack are available and the statuses of racks
+	// reflect their latest StatefulSets. It contributes to the aggregated Available condition.
+	RacksAvailableCondition = "RacksAvailable"
+
+	// PlacementDriftedCondition indicates that the affinity of some racks' StatefulSet Pod templates
+	// differs from the desired rack placement, i.e. the latest placement change hasn't been rolled out yet.
+	PlacementDriftedCondition = "PlacementDrifted"
+
+	// CleanupRecommendedCondition is an advisory condition indicating that the number of nodes changed
+	// since the datacenter was last fully available and a cleanup should be run on its nodes.
+	CleanupRecommendedCondition = "CleanupRecommended"
+
+	// QuarantinedCondition indicates that the datacenter is quarantined and the operator doesn't take any orchestration
+	// actions on it, while its status keeps being reported.
+	QuarantinedCondition = "Quarantined"
+
+	// DuplicateHostIDCondition indicates that several members report the same host ID, which is usually caused
+	// by copied data volumes and corrupts the cluster topology.
+	DuplicateHostIDCondition = "DuplicateHostID"
+
+	// AntiAffinityViolatedCondition indicates that several members of the same rack are scheduled to the same
+	// Kubernetes node, so a failure of that node takes all of them down.
+	AntiAffinityViolatedCondition = "AntiAffinityViolated"
+
+	// TokenRangeOverlapCondition indicates that several nodes claim ownership of the same tokens,
+	// which happens e.g. after a botched replace and leads to overlapping token ranges.
+	TokenRangeOverlapCondition = "TokenRangeOverlap"
+
+	// RackUpdateStalledCondition indicates that the StatefulSet of some racks has an update pending
+	// that doesn't progress, either because it is paused by a partition or because it waits for nodes to become ready.
+	RackUpdateStalledCondition = "RackUpdateStalled"
+
+	// SeedUnreachableCondition indicates that none of the nodes of some racks see a seed of the datacenter as up
+	// in their gossip view, which is usually caused by network policies blocking traffic between racks.
+	SeedUnreachableCondition = "SeedUnreachable"
+
+	// DataUnderReplicatedCondition indicates that some keyspaces have replicas on nodes that are down,
+	// so their data has fewer live copies than their replication factor until the nodes are repaired or replaced.
+	DataUnderReplicatedCondition = "DataUnderReplicated"
+
+	// TopologyMismatchCondition indicates that some nodes report a datacenter or rack that differs from the one
+	// they belong to in the spec, which is usually caused by a misconfigured snitch.
+	TopologyMismatchCondition = "TopologyMismatch"
+
+	// WorkloadPrioritizationMisconfiguredCondition indicates that some nodes don't have the scheduling groups
+	// of the service levels expected to be used for workload prioritization.
+	WorkloadPrioritizationMisconfiguredCondition = "WorkloadPrioritizationMisconfigured"
+
+	// ConsistentTopologyEnabledCondition indicates whether the cluster uses raft-based consistent topology changes,
+	// i.e. whether all nodes report that the upgrade to raft-based topology is done.
+	ConsistentTopologyEnabledCondition = "ConsistentTopologyEnabled"
+
+	// LargePartitionDetectedCondition indicates that some nodes have compacted partitions larger than
+	// the configured threshold, which are known to cause latency spikes and instability.
+	LargePartitionDetectedCondition = "LargePartitionDetected"
+
+	// ClockSkewDetectedCondition indicates that the clocks of some nodes drift from the clocks of the other nodes
+	// beyond the configured threshold, which breaks the ordering of writes and indicates a time synchronization problem.
+	ClockSkewDetectedCondition = "ClockSkewDetected"
+
+	// StaleSnapshotsDetectedCondition indicates that snapshots take more space than the configured threshold
+	// on some nodes, which usually means old snapshots weren't cleared and may eventually exhaust the disk.
+	StaleSnapshotsDetectedCondition = "StaleSnapshotsDetected"
 )
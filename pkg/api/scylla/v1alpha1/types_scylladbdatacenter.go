@@ -461,6 +461,44 @@ type Placement struct {
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
+// KeyspaceStatus describes a keyspace in the schema overview.
+type KeyspaceStatus struct {
+	// name is the name of the keyspace.
+	Name string `json:"name"`
+
+	// tables is the number of tables in the keyspace.
+	Tables int32 `json:"tables"`
+}
+
+// SchemaStatus is an overview of the schema of the cluster.
+type SchemaStatus struct {
+	// keyspaces lists keyspaces sorted by name. Only a limited number of keyspaces is listed.
+	// +optional
+	Keyspaces []KeyspaceStatus `json:"keyspaces,omitempty"`
+
+	// totalKeyspaces is the total number of keyspaces, including the ones that aren't listed.
+	TotalKeyspaces int32 `json:"totalKeyspaces"`
+
+	// lastRefreshTime is the time when the schema overview was last queried from the nodes.
+	LastRefreshTime metav1.Time `json:"lastRefreshTime"`
+}
+
+// LatencyStatus is a coarse overview of the latencies of requests coordinated by the nodes.
+type LatencyStatus struct {
+	// readP99Microseconds is the highest 99th percentile of read latencies, in microseconds, of nodes that reported it.
+	// Percentiles are estimated from latency histograms accumulated since the nodes started.
+	// +optional
+	ReadP99Microseconds *int64 `json:"readP99Microseconds,omitempty"`
+
+	// writeP99Microseconds is the highest 99th percentile of write latencies, in microseconds, of nodes that reported it.
+	// Percentiles are estimated from latency histograms accumulated since the nodes started.
+	// +optional
+	WriteP99Microseconds *int64 `json:"writeP99Microseconds,omitempty"`
+
+	// lastRefreshTime is the time when the latencies were last queried from the nodes.
+	LastRefreshTime metav1.Time `json:"lastRefreshTime"`
+}
+
 // RackStatus is the status of a ScyllaDB Rack
 type RackStatus struct {
 	// name specifies the name of datacenter this status describes.
@@ -483,6 +521,12 @@ type RackStatus struct {
 	// +optional
 	UpdatedNodes *int32 `json:"updatedNodes,omitempty"`
 
+	// scalingTargetNodes is the number of nodes that the rack is currently being scaled to.
+	// Racks are scaled down gradually, one node at a time, so while scaling down it is an intermediate step
+	// rather than the number of nodes requested in spec. It is unset when the rack isn't being scaled.
+	// +optional
+	ScalingTargetNodes *int32 `json:"scalingTargetNodes,omitempty"`
+
 	// readyNodes specify the total number of ready nodes in rack.
 	// +optional
 	ReadyNodes *int32 `json:"readyNodes,omitempty"`
@@ -495,6 +539,166 @@ type RackStatus struct {
 	// stale should eventually become false when the appropriate controller writes a fresh status.
 	// +optional
 	Stale *bool `json:"stale,omitempty"`
+
+	// staleSince is the time since when the rack status has been continuously stale.
+	// It is unset when the rack status isn't stale.
+	// +optional
+	StaleSince *metav1.Time `json:"staleSince,omitempty"`
+
+	// appliedSpecHash is the hash of the desired rack spec that produced the current StatefulSet.
+	// Comparing it between reconciles helps to determine whether the latest spec change has been applied.
+	// +optional
+	AppliedSpecHash string `json:"appliedSpecHash,omitempty"`
+
+	// restartedNodes is the number of nodes in rack whose ScyllaDB container started after the operator first observed
+	// the current generation of the datacenter. It lets the progress of rolling restarts be tracked.
+	// +optional
+	RestartedNodes *int32 `json:"restartedNodes,omitempty"`
+
+	// placementUpToDate indicates whether the affinity of the rack's StatefulSet Pod template matches
+	// the desired rack placement.
+	// It is left unset when the rack's StatefulSet doesn't exist.
+	// +optional
+	PlacementUpToDate *bool `json:"placementUpToDate,omitempty"`
+
+	// alternatorReadyNodes specify the total number of nodes in rack that accept connections on the Alternator port.
+	// It is only reported when Alternator readiness checks are enabled in the operator and Alternator is configured,
+	// and is left unset when it can't be determined.
+	// +optional
+	AlternatorReadyNodes *int32 `json:"alternatorReadyNodes,omitempty"`
+
+	// schemaVersion is the schema version reported by the nodes in rack.
+	// It is only reported when schema version reporting is enabled in the operator,
+	// and is left empty when it can't be determined or when the nodes in rack don't agree on it.
+	// +optional
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+
+	// schemaMigratingNodes is the number of nodes in rack that are still applying schema changes,
+	// i.e. whose schema version differs from the one held by most nodes in the cluster.
+	// It is only reported when schema migration reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	SchemaMigratingNodes *int32 `json:"schemaMigratingNodes,omitempty"`
+
+	// compactedNodes is the number of nodes in rack that have completed their first compaction of user data,
+	// i.e. whose compaction history has an entry for a keyspace that isn't a system keyspace.
+	// It is only reported when compaction reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	CompactedNodes *int32 `json:"compactedNodes,omitempty"`
+
+	// clockSkewedNodes is the number of nodes in rack whose clock drifts from the median clock of the reporting nodes
+	// in the datacenter beyond the threshold configured in the operator.
+	// It is only reported when clock skew reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	ClockSkewedNodes *int32 `json:"clockSkewedNodes,omitempty"`
+
+	// shardCount is the number of shards per node in rack.
+	// It is only reported when shard count reporting is enabled in the operator,
+	// and is left unset when it can't be determined or when the nodes in rack have differing shard counts.
+	// +optional
+	ShardCount *int32 `json:"shardCount,omitempty"`
+
+	// cqlConnections is the total number of client connections to the CQL port of nodes in rack that reported it.
+	// It is only reported when CQL connection reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	CQLConnections *int32 `json:"cqlConnections,omitempty"`
+
+	// usedDataBytes is the total number of bytes of data stored on nodes in rack that reported it.
+	// It is only reported when data usage reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	UsedDataBytes *int64 `json:"usedDataBytes,omitempty"`
+
+	// maxPartitionBytes is the size of the largest partition compacted on nodes in rack that reported it.
+	// It is only reported when large partition reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	MaxPartitionBytes *int64 `json:"maxPartitionBytes,omitempty"`
+
+	// snapshotBytes is the total number of bytes taken by snapshots on nodes in rack that reported it.
+	// It is only reported when snapshot reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	SnapshotBytes *int64 `json:"snapshotBytes,omitempty"`
+
+	// diskPressureNodes is the number of nodes in rack whose data volume usage exceeds the disk pressure watermark.
+	// It is only reported when disk pressure reporting is enabled in the operator,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	DiskPressureNodes *int32 `json:"diskPressureNodes,omitempty"`
+
+	// versionSkewSince is the time since which nodes in rack have been observed running different ScyllaDB versions.
+	// It is only reported when intra-rack version skew reporting is enabled in the operator,
+	// and is left unset while all nodes in rack that reported their version run the same one.
+	// +optional
+	VersionSkewSince *metav1.Time `json:"versionSkewSince,omitempty"`
+
+	// cpuUtilizationPercent is the average CPU usage of ScyllaDB containers in rack, relative to their CPU requests.
+	// It is only reported when resource utilization reporting is enabled in the operator and the metrics API is available,
+	// and is left unset when it can't be determined for any node in rack.
+	// +optional
+	CPUUtilizationPercent *int32 `json:"cpuUtilizationPercent,omitempty"`
+
+	// memoryUtilizationPercent is the average memory usage of ScyllaDB containers in rack, relative to their memory requests.
+	// It is reported the same way as cpuUtilizationPercent.
+	// +optional
+	MemoryUtilizationPercent *int32 `json:"memoryUtilizationPercent,omitempty"`
+
+	// earliestNodeStartTime is the time when the longest running ScyllaDB node in rack was last (re)started,
+	// which determines the maximum node uptime in rack.
+	// It is derived from the start time of ScyllaDB containers, unless node uptime reporting is enabled in the operator,
+	// in which case it is derived from the uptime reported by the nodes.
+	// +optional
+	EarliestNodeStartTime *metav1.Time `json:"earliestNodeStartTime,omitempty"`
+
+	// latestNodeStartTime is the time when the most recently (re)started ScyllaDB node in rack was started,
+	// which determines the minimum node uptime in rack.
+	// It is derived the same way as earliestNodeStartTime.
+	// +optional
+	LatestNodeStartTime *metav1.Time `json:"latestNodeStartTime,omitempty"`
+
+	// ignitionPendingNodes specify the number of nodes in rack of which the ignition container is not ready yet.
+	// +optional
+	IgnitionPendingNodes *int32 `json:"ignitionPendingNodes,omitempty"`
+
+	// stuckTerminatingNodes specify the number of nodes in rack of which the Pod is still terminating after its grace period has passed.
+	// +optional
+	StuckTerminatingNodes *int32 `json:"stuckTerminatingNodes,omitempty"`
+
+	// instanceTypes maps the instance types of Kubernetes nodes hosting rack members to the number of members.
+	// Members hosted on nodes without the "node.kubernetes.io/instance-type" label are not counted.
+	// +optional
+	InstanceTypes map[string]int32 `json:"instanceTypes,omitempty"`
+
+	// members are the observed states of the individual members of rack, ordered by their ordinal.
+	// They are only reported when detailed member reporting is enabled in the operator.
+	// +optional
+	Members []MemberStatus `json:"members,omitempty"`
+}
+
+// MemberStatus is the observed state of a single member of a rack.
+type MemberStatus struct {
+	// name is the name of the member, which is shared by its Service and Pod.
+	Name string `json:"name"`
+
+	// hostID is the host ID of the ScyllaDB node, as last observed by the operator.
+	// It is left empty until the node reports it.
+	// +optional
+	HostID string `json:"hostID,omitempty"`
+
+	// podConditions are the Ready and ContainersReady conditions of the Pod of the member.
+	// They are only reported when member Pod details reporting is enabled in the operator.
+	// +optional
+	PodConditions []corev1.PodCondition `json:"podConditions,omitempty"`
+
+	// restartCount is the number of times the ScyllaDB container of the member has been restarted.
+	// It is only reported when member Pod details reporting is enabled in the operator,
+	// and is left unset when the Pod or its ScyllaDB container status doesn't exist.
+	// +optional
+	RestartCount *int32 `json:"restartCount,omitempty"`
 }
 
 // ScyllaDBDatacenterStatus defines the observed state of ScyllaDBDatacenter.
@@ -535,6 +739,58 @@ type ScyllaDBDatacenterStatus struct {
 	// +optional
 	AvailableNodes *int32 `json:"availableNodes,omitempty"`
 
+	// ignitionPendingNodes specify the total number of nodes in datacenter of which the ignition container is not ready yet.
+	// +optional
+	IgnitionPendingNodes *int32 `json:"ignitionPendingNodes,omitempty"`
+
+	// updatedPercent specifies the percentage of requested nodes in datacenter that match the current spec, rounded down.
+	// It is 100 when datacenter has no nodes requested.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	UpdatedPercent *int32 `json:"updatedPercent,omitempty"`
+
+	// ready mirrors the Available condition, i.e. it is true when all nodes of every rack are available
+	// and the datacenter is otherwise available.
+	// +optional
+	Ready *bool `json:"ready,omitempty"`
+
+	// lastFullyAvailableTime is the time of the latest transition into or out of full availability,
+	// which is when all requested nodes in datacenter are ready.
+	// While the datacenter is fully available, it is the time since when it has been; otherwise, it is the time
+	// when it last was, or unset if it never was.
+	// +optional
+	LastFullyAvailableTime *metav1.Time `json:"lastFullyAvailableTime,omitempty"`
+
+	// generationStartTime is the time when the operator first observed the current generation of the datacenter.
+	// +optional
+	GenerationStartTime *metav1.Time `json:"generationStartTime,omitempty"`
+
+	// lastReconcileTime is the time when the operator last reconciled the datacenter.
+	// To avoid perpetual status updates, it is only refreshed together with other status changes,
+	// or once it is a few minutes old.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// managedByOperatorVersion is the version of the operator that last reconciled the datacenter.
+	// +optional
+	ManagedByOperatorVersion string `json:"managedByOperatorVersion,omitempty"`
+
+	// cleanupBaselineNodes is the number of nodes the datacenter had when it was first observed fully available,
+	// or when a cleanup last completed on all of its nodes. It is only reported when cleanup recommendations are enabled.
+	// +optional
+	CleanupBaselineNodes *int32 `json:"cleanupBaselineNodes,omitempty"`
+
+	// schema is an overview of keyspaces and their table counts. It is only reported when schema overview
+	// reporting is enabled in the operator. It is refreshed periodically and kept when it can't be refreshed.
+	// +optional
+	Schema *SchemaStatus `json:"schema,omitempty"`
+
+	// latency is a coarse overview of read and write latency percentiles. It is only reported when latency
+	// reporting is enabled in the operator. It is refreshed periodically and left unset when it can't be determined.
+	// +optional
+	Latency *LatencyStatus `json:"latency,omitempty"`
+
 	// racks reflect the status of datacenter racks.
 	Racks []RackStatus `json:"racks"`
 }
@@ -341,6 +341,49 @@ func (in *IngressOptions) DeepCopy() *IngressOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyspaceStatus) DeepCopyInto(out *KeyspaceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyspaceStatus.
+func (in *KeyspaceStatus) DeepCopy() *KeyspaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyspaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyStatus) DeepCopyInto(out *LatencyStatus) {
+	*out = *in
+	if in.ReadP99Microseconds != nil {
+		in, out := &in.ReadP99Microseconds, &out.ReadP99Microseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WriteP99Microseconds != nil {
+		in, out := &in.WriteP99Microseconds, &out.WriteP99Microseconds
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastRefreshTime.DeepCopyInto(&out.LastRefreshTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyStatus.
+func (in *LatencyStatus) DeepCopy() *LatencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalDiskSetup) DeepCopyInto(out *LocalDiskSetup) {
 	*out = *in
@@ -400,6 +443,34 @@ func (in *LoopDeviceConfiguration) DeepCopy() *LoopDeviceConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberStatus) DeepCopyInto(out *MemberStatus) {
+	*out = *in
+	if in.PodConditions != nil {
+		in, out := &in.PodConditions, &out.PodConditions
+		*out = make([]v1.PodCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestartCount != nil {
+		in, out := &in.RestartCount, &out.RestartCount
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberStatus.
+func (in *MemberStatus) DeepCopy() *MemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MountConfiguration) DeepCopyInto(out *MountConfiguration) {
 	*out = *in
@@ -986,6 +1057,11 @@ func (in *RackStatus) DeepCopyInto(out *RackStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ScalingTargetNodes != nil {
+		in, out := &in.ScalingTargetNodes, &out.ScalingTargetNodes
+		*out = new(int32)
+		**out = **in
+	}
 	if in.ReadyNodes != nil {
 		in, out := &in.ReadyNodes, &out.ReadyNodes
 		*out = new(int32)
@@ -1001,6 +1077,116 @@ func (in *RackStatus) DeepCopyInto(out *RackStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.StaleSince != nil {
+		in, out := &in.StaleSince, &out.StaleSince
+		*out = (*in).DeepCopy()
+	}
+	if in.RestartedNodes != nil {
+		in, out := &in.RestartedNodes, &out.RestartedNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PlacementUpToDate != nil {
+		in, out := &in.PlacementUpToDate, &out.PlacementUpToDate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlternatorReadyNodes != nil {
+		in, out := &in.AlternatorReadyNodes, &out.AlternatorReadyNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SchemaMigratingNodes != nil {
+		in, out := &in.SchemaMigratingNodes, &out.SchemaMigratingNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CompactedNodes != nil {
+		in, out := &in.CompactedNodes, &out.CompactedNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClockSkewedNodes != nil {
+		in, out := &in.ClockSkewedNodes, &out.ClockSkewedNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ShardCount != nil {
+		in, out := &in.ShardCount, &out.ShardCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CQLConnections != nil {
+		in, out := &in.CQLConnections, &out.CQLConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UsedDataBytes != nil {
+		in, out := &in.UsedDataBytes, &out.UsedDataBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPartitionBytes != nil {
+		in, out := &in.MaxPartitionBytes, &out.MaxPartitionBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SnapshotBytes != nil {
+		in, out := &in.SnapshotBytes, &out.SnapshotBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskPressureNodes != nil {
+		in, out := &in.DiskPressureNodes, &out.DiskPressureNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VersionSkewSince != nil {
+		in, out := &in.VersionSkewSince, &out.VersionSkewSince
+		*out = (*in).DeepCopy()
+	}
+	if in.CPUUtilizationPercent != nil {
+		in, out := &in.CPUUtilizationPercent, &out.CPUUtilizationPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryUtilizationPercent != nil {
+		in, out := &in.MemoryUtilizationPercent, &out.MemoryUtilizationPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EarliestNodeStartTime != nil {
+		in, out := &in.EarliestNodeStartTime, &out.EarliestNodeStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LatestNodeStartTime != nil {
+		in, out := &in.LatestNodeStartTime, &out.LatestNodeStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.IgnitionPendingNodes != nil {
+		in, out := &in.IgnitionPendingNodes, &out.IgnitionPendingNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StuckTerminatingNodes != nil {
+		in, out := &in.StuckTerminatingNodes, &out.StuckTerminatingNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]MemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1227,6 +1413,28 @@ func (in *RemoteOwnerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaStatus) DeepCopyInto(out *SchemaStatus) {
+	*out = *in
+	if in.Keyspaces != nil {
+		in, out := &in.Keyspaces, &out.Keyspaces
+		*out = make([]KeyspaceStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.LastRefreshTime.DeepCopyInto(&out.LastRefreshTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaStatus.
+func (in *SchemaStatus) DeepCopy() *SchemaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScyllaDB) DeepCopyInto(out *ScyllaDB) {
 	*out = *in
@@ -1903,6 +2111,48 @@ func (in *ScyllaDBDatacenterStatus) DeepCopyInto(out *ScyllaDBDatacenterStatus)
 		*out = new(int32)
 		**out = **in
 	}
+	if in.IgnitionPendingNodes != nil {
+		in, out := &in.IgnitionPendingNodes, &out.IgnitionPendingNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdatedPercent != nil {
+		in, out := &in.UpdatedPercent, &out.UpdatedPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Ready != nil {
+		in, out := &in.Ready, &out.Ready
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastFullyAvailableTime != nil {
+		in, out := &in.LastFullyAvailableTime, &out.LastFullyAvailableTime
+		*out = (*in).DeepCopy()
+	}
+	if in.GenerationStartTime != nil {
+		in, out := &in.GenerationStartTime, &out.GenerationStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CleanupBaselineNodes != nil {
+		in, out := &in.CleanupBaselineNodes, &out.CleanupBaselineNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(SchemaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(LatencyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Racks != nil {
 		in, out := &in.Racks, &out.Racks
 		*out = make([]RackStatus, len(*in))
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"sync"
 	"time"
 
@@ -73,6 +74,44 @@ type OperatorOptions struct {
 	CryptoKeyBufferSizeMin int
 	CryptoKeyBufferSizeMax int
 	CryptoKeyBufferDelay   time.Duration
+
+	StatusAlternatorReadiness     bool
+	StatusSchemaVersion           bool
+	StatusSchemaMigrations        bool
+	StatusDetailedMembers         bool
+	StatusMemberPodDetails        bool
+	StatusCompactions             bool
+	StatusShardCount              bool
+	StatusCQLConnections          bool
+	StatusDataUsage               bool
+	StatusNodeUptime              bool
+	StatusCleanupRecommendation   bool
+	StatusTokenRangeOverlap       bool
+	StatusSeedReachability        bool
+	StatusDataReplication         bool
+	StatusResourceUtilization     bool
+	StatusTopology                bool
+	StatusWorkloadPrioritization  bool
+	StatusConsistentTopology      bool
+	StatusLargePartitions         bool
+	StatusClockSkew               bool
+	StatusSnapshots               bool
+	StatusLatency                 bool
+	StatusSchemaOverview          bool
+	StatusSchemaOverviewRefresh   time.Duration
+	StatusLatencyRefresh          time.Duration
+	StatusRackQueryTimeout        time.Duration
+	StatusLiveQueryTimeout        time.Duration
+	StatusLargePartitionThreshold int64
+	StatusClockSkewThreshold      time.Duration
+	StatusSnapshotThreshold       int64
+	StatusDiskPressure            bool
+	StatusDiskPressureWatermark   int
+	StatusIntraRackVersionSkew    bool
+	StatusIntraRackVersionGrace   time.Duration
+	StatusHistorySize             int
+
+	HTTPAddress string
 }
 
 func NewOperatorOptions(streams genericclioptions.IOStreams) *OperatorOptions {
@@ -88,6 +127,44 @@ func NewOperatorOptions(streams genericclioptions.IOStreams) *OperatorOptions {
 		CryptoKeyBufferSizeMin: 10,
 		CryptoKeyBufferSizeMax: 30,
 		CryptoKeyBufferDelay:   200 * time.Millisecond,
+
+		StatusAlternatorReadiness:     false,
+		StatusSchemaVersion:           false,
+		StatusSchemaMigrations:        false,
+		StatusDetailedMembers:         false,
+		StatusMemberPodDetails:        false,
+		StatusCompactions:             false,
+		StatusShardCount:              false,
+		StatusCQLConnections:          false,
+		StatusDataUsage:               false,
+		StatusNodeUptime:              false,
+		StatusCleanupRecommendation:   false,
+		StatusTokenRangeOverlap:       false,
+		StatusSeedReachability:        false,
+		StatusDataReplication:         false,
+		StatusResourceUtilization:     false,
+		StatusTopology:                false,
+		StatusWorkloadPrioritization:  false,
+		StatusConsistentTopology:      false,
+		StatusLargePartitions:         false,
+		StatusClockSkew:               false,
+		StatusSnapshots:               false,
+		StatusLatency:                 false,
+		StatusSchemaOverview:          false,
+		StatusSchemaOverviewRefresh:   10 * time.Minute,
+		StatusLatencyRefresh:          10 * time.Minute,
+		StatusRackQueryTimeout:        10 * time.Second,
+		StatusLiveQueryTimeout:        30 * time.Second,
+		StatusLargePartitionThreshold: scylladbdatacenter.DefaultLargePartitionThresholdBytes,
+		StatusClockSkewThreshold:      scylladbdatacenter.DefaultClockSkewThreshold,
+		StatusSnapshotThreshold:       scylladbdatacenter.DefaultSnapshotThresholdBytes,
+		StatusDiskPressure:            false,
+		StatusDiskPressureWatermark:   scylladbdatacenter.DefaultDiskPressureWatermarkPercent,
+		StatusIntraRackVersionSkew:    false,
+		StatusIntraRackVersionGrace:   scylladbdatacenter.DefaultIntraRackVersionSkewGracePeriod,
+		StatusHistorySize:             0,
+
+		HTTPAddress: "",
 	}
 }
 
@@ -137,6 +214,42 @@ func (o *OperatorOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().IntVarP(&o.CryptoKeyBufferSizeMin, "crypto-key-buffer-size-min", "", o.CryptoKeyBufferSizeMin, "Minimal number of pre-generated crypto keys that are used for quick certificate issuance. The minimum size is 1.")
 	cmd.Flags().IntVarP(&o.CryptoKeyBufferSizeMax, cryptoKeyBufferSizeMaxFlagKey, "", o.CryptoKeyBufferSizeMax, "Maximum number of pre-generated crypto keys that are used for quick certificate issuance. The minimum size is 1. If not set, it will adjust to be at least the size of crypto-key-buffer-size-min.")
 	cmd.Flags().DurationVarP(&o.CryptoKeyBufferDelay, "crypto-key-buffer-delay", "", o.CryptoKeyBufferDelay, "Delay is the time to wait when generating next certificate in the (min, max) range. Certificate generation bellow the min threshold is not affected.")
+	cmd.Flags().BoolVarP(&o.StatusAlternatorReadiness, "status-alternator-readiness", "", o.StatusAlternatorReadiness, "Report the number of nodes accepting connections on the Alternator port in ScyllaDBDatacenter rack status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusSchemaVersion, "status-schema-version", "", o.StatusSchemaVersion, "Report schema versions of racks and schema disagreement between them in ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusSchemaMigrations, "status-schema-migrations", "", o.StatusSchemaMigrations, "Report the number of nodes of each rack that are still applying schema changes in ScyllaDBDatacenter rack status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusDetailedMembers, "status-detailed-members", "", o.StatusDetailedMembers, "Report the states of the individual members of each rack in ScyllaDBDatacenter rack status.")
+	cmd.Flags().BoolVarP(&o.StatusMemberPodDetails, "status-member-pod-details", "", o.StatusMemberPodDetails, "Include the Ready and ContainersReady conditions and the ScyllaDB container restart count of member Pods in the reported member states. Requires status-detailed-members.")
+	cmd.Flags().BoolVarP(&o.StatusCompactions, "status-compactions", "", o.StatusCompactions, "Report the number of nodes of each rack that have completed their first compaction of user data in ScyllaDBDatacenter rack status, based on their compaction history. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusShardCount, "status-shard-count", "", o.StatusShardCount, "Report shard counts of rack nodes and shard count mismatches within racks in ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusCQLConnections, "status-cql-connections", "", o.StatusCQLConnections, "Report the number of CQL client connections of each rack in ScyllaDBDatacenter rack status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusDataUsage, "status-data-usage", "", o.StatusDataUsage, "Report the number of bytes of data stored on nodes of each rack in ScyllaDBDatacenter rack status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusNodeUptime, "status-node-uptime", "", o.StatusNodeUptime, "Derive node start times in ScyllaDBDatacenter rack status from the uptime reported by ScyllaDB nodes instead of the start time of their containers. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusCleanupRecommendation, "status-cleanup-recommendation", "", o.StatusCleanupRecommendation, "Report an advisory CleanupRecommended condition in ScyllaDBDatacenter status when the number of nodes changes, until a cleanup completes on all nodes.")
+	cmd.Flags().BoolVarP(&o.StatusTokenRangeOverlap, "status-token-range-overlap", "", o.StatusTokenRangeOverlap, "Collect the tokens owned by ScyllaDB nodes and report tokens claimed by more than one node in a TokenRangeOverlap condition of ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusSeedReachability, "status-seed-reachability", "", o.StatusSeedReachability, "Check that the seeds of the datacenter are up in the gossip view of each rack and report unreachable ones in a SeedUnreachable condition of ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusDataReplication, "status-data-replication", "", o.StatusDataReplication, "Report keyspaces with replicas on down nodes in a DataUnderReplicated condition of ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusResourceUtilization, "status-resource-utilization", "", o.StatusResourceUtilization, "Report the average CPU and memory utilization of ScyllaDB containers in each rack of ScyllaDBDatacenter status. Requires the metrics API (metrics.k8s.io) to be available, otherwise the utilization is left unset.")
+	cmd.Flags().BoolVarP(&o.StatusTopology, "status-topology", "", o.StatusTopology, "Check that ScyllaDB nodes report the datacenter and rack they belong to and report discrepancies in a TopologyMismatch condition of ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusWorkloadPrioritization, "status-workload-prioritization", "", o.StatusWorkloadPrioritization, "Check that ScyllaDB nodes have scheduling groups of the service levels listed in the \"ignore.internal.scylla-operator.scylladb.com/expected-service-levels\" annotation of ScyllaDBDatacenter and report discrepancies in a WorkloadPrioritizationMisconfigured condition of its status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusConsistentTopology, "status-consistent-topology", "", o.StatusConsistentTopology, "Report whether the cluster uses raft-based consistent topology changes in a ConsistentTopologyEnabled condition of ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusLargePartitions, "status-large-partitions", "", o.StatusLargePartitions, "Report the size of the largest partition compacted on nodes of each rack in ScyllaDBDatacenter rack status, and partitions larger than status-large-partition-threshold-bytes in a LargePartitionDetected condition. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusClockSkew, "status-clock-skew", "", o.StatusClockSkew, "Report the number of nodes of each rack whose clocks drift from the median clock of the datacenter nodes beyond status-clock-skew-threshold in ScyllaDBDatacenter rack status, and list them in a ClockSkewDetected condition. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusSnapshots, "status-snapshots", "", o.StatusSnapshots, "Report the space taken by snapshots on nodes of each rack in ScyllaDBDatacenter rack status, and nodes whose snapshots take more than status-snapshot-threshold-bytes in a StaleSnapshotsDetected condition. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusLatency, "status-latency", "", o.StatusLatency, "Report the highest 99th percentiles of read and write latencies of ScyllaDB nodes in ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusDiskPressure, "status-disk-pressure", "", o.StatusDiskPressure, "Report the number of nodes of each rack whose data volume usage exceeds status-disk-pressure-watermark-percent of its capacity in ScyllaDBDatacenter rack status, and the nodes in a DiskPressure condition. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusIntraRackVersionSkew, "status-intra-rack-version-skew", "", o.StatusIntraRackVersionSkew, "Report racks whose nodes run different ScyllaDB versions for longer than status-intra-rack-version-skew-grace-period in an IntraRackVersionSkew condition of ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().BoolVarP(&o.StatusSchemaOverview, "status-schema-overview", "", o.StatusSchemaOverview, "Report keyspaces and their table counts in ScyllaDBDatacenter status. Requires the operator to be able to connect to ScyllaDB nodes.")
+	cmd.Flags().DurationVarP(&o.StatusSchemaOverviewRefresh, "status-schema-overview-refresh-interval", "", o.StatusSchemaOverviewRefresh, "Minimum interval between refreshes of the schema overview in ScyllaDBDatacenter status.")
+	cmd.Flags().DurationVarP(&o.StatusLatencyRefresh, "status-latency-refresh-interval", "", o.StatusLatencyRefresh, "Minimum interval between refreshes of the latencies in ScyllaDBDatacenter status.")
+	cmd.Flags().DurationVarP(&o.StatusRackQueryTimeout, "status-rack-query-timeout", "", o.StatusRackQueryTimeout, "Timeout for querying the ScyllaDB nodes of a single rack for the values reported by the opt-in ScyllaDBDatacenter status features. Racks that time out are reported as if none of their nodes could be queried.")
+	cmd.Flags().DurationVarP(&o.StatusLiveQueryTimeout, "status-live-query-timeout", "", o.StatusLiveQueryTimeout, "Timeout for querying the ScyllaDB nodes of a datacenter for all values reported by the opt-in ScyllaDBDatacenter status features in a single sync. Racks that aren't queried in time are reported as if none of their nodes could be queried.")
+	cmd.Flags().Int64VarP(&o.StatusLargePartitionThreshold, "status-large-partition-threshold-bytes", "", o.StatusLargePartitionThreshold, "Partition size in bytes above which partitions are reported as large in ScyllaDBDatacenter status.")
+	cmd.Flags().DurationVarP(&o.StatusClockSkewThreshold, "status-clock-skew-threshold", "", o.StatusClockSkewThreshold, "Clock drift above which nodes are reported as skewed in ScyllaDBDatacenter status. Node clocks are estimated with a precision of about a second.")
+	cmd.Flags().Int64VarP(&o.StatusSnapshotThreshold, "status-snapshot-threshold-bytes", "", o.StatusSnapshotThreshold, "Snapshot space usage of a node in bytes above which its snapshots are reported as stale in ScyllaDBDatacenter status.")
+	cmd.Flags().IntVarP(&o.StatusDiskPressureWatermark, "status-disk-pressure-watermark-percent", "", o.StatusDiskPressureWatermark, "Data volume usage of a node in percent of its capacity above which it is reported under disk pressure in ScyllaDBDatacenter status.")
+	cmd.Flags().DurationVarP(&o.StatusIntraRackVersionGrace, "status-intra-rack-version-skew-grace-period", "", o.StatusIntraRackVersionGrace, "Time for which nodes of a rack can run different ScyllaDB versions before the rack is reported in ScyllaDBDatacenter status.")
+	cmd.Flags().IntVarP(&o.StatusHistorySize, "status-history-size", "", o.StatusHistorySize, "Number of the latest ScyllaDBDatacenter statuses kept in memory for each datacenter and served by the status history endpoint of the HTTP server. Zero disables the history.")
+	cmd.Flags().StringVarP(&o.HTTPAddress, "http-address", "", o.HTTPAddress, "Listen address (host:port) of the HTTP server exposing the ScyllaDBDatacenter readiness summary and status history endpoints. The server is disabled when empty.")
 }
 
 func (o *OperatorOptions) Validate() error {
@@ -170,11 +283,62 @@ func (o *OperatorOptions) Validate() error {
 		))
 	}
 
+	if o.StatusMemberPodDetails && !o.StatusDetailedMembers {
+		errs = append(errs, errors.New("status-member-pod-details requires status-detailed-members"))
+	}
+
 	msg := validation.IsInRange(o.CQLSIngressPort, 0, 65535)
 	if len(msg) != 0 {
 		errs = append(errs, fmt.Errorf("invalid secure cql ingress port %d: %s", o.CQLSIngressPort, msg))
 	}
 
+	if o.StatusSchemaOverviewRefresh <= 0 {
+		errs = append(errs, fmt.Errorf("status-schema-overview-refresh-interval must be positive, got %v", o.StatusSchemaOverviewRefresh))
+	}
+
+	if o.StatusLatencyRefresh <= 0 {
+		errs = append(errs, fmt.Errorf("status-latency-refresh-interval must be positive, got %v", o.StatusLatencyRefresh))
+	}
+
+	if o.StatusRackQueryTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("status-rack-query-timeout must be positive, got %v", o.StatusRackQueryTimeout))
+	}
+
+	if o.StatusLiveQueryTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("status-live-query-timeout must be positive, got %v", o.StatusLiveQueryTimeout))
+	}
+
+	if o.StatusLargePartitionThreshold <= 0 {
+		errs = append(errs, fmt.Errorf("status-large-partition-threshold-bytes must be positive, got %d", o.StatusLargePartitionThreshold))
+	}
+
+	if o.StatusClockSkewThreshold <= 0 {
+		errs = append(errs, fmt.Errorf("status-clock-skew-threshold must be positive, got %v", o.StatusClockSkewThreshold))
+	}
+
+	if o.StatusSnapshotThreshold <= 0 {
+		errs = append(errs, fmt.Errorf("status-snapshot-threshold-bytes must be positive, got %d", o.StatusSnapshotThreshold))
+	}
+
+	if o.StatusDiskPressureWatermark <= 0 || o.StatusDiskPressureWatermark > 100 {
+		errs = append(errs, fmt.Errorf("status-disk-pressure-watermark-percent must be between 1 and 100, got %d", o.StatusDiskPressureWatermark))
+	}
+
+	if o.StatusIntraRackVersionGrace <= 0 {
+		errs = append(errs, fmt.Errorf("status-intra-rack-version-skew-grace-period must be positive, got %v", o.StatusIntraRackVersionGrace))
+	}
+
+	if o.StatusHistorySize < 0 {
+		errs = append(errs, fmt.Errorf("status-history-size (%d) can't be negative", o.StatusHistorySize))
+	}
+
+	if len(o.HTTPAddress) != 0 {
+		_, _, err := net.SplitHostPort(o.HTTPAddress)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid http address %q: %w", o.HTTPAddress, err))
+		}
+	}
+
 	return apierrors.NewAggregate(errs)
 }
 
@@ -330,9 +494,48 @@ func (o *OperatorOptions) run(ctx context.Context, streams genericclioptions.IOS
 		kubeInformers.Networking().V1().Ingresses(),
 		kubeInformers.Batch().V1().Jobs(),
 		scyllaInformers.Scylla().V1alpha1().ScyllaDBDatacenters(),
+		kubeInformers.Core().V1().Nodes(),
+		kubeInformers.Core().V1().PersistentVolumeClaims(),
 		o.OperatorImage,
 		o.CQLSIngressPort,
 		rsaKeyGenerator,
+		scylladbdatacenter.StatusOptions{
+			AlternatorReadiness:             o.StatusAlternatorReadiness,
+			SchemaVersion:                   o.StatusSchemaVersion,
+			SchemaMigrations:                o.StatusSchemaMigrations,
+			DetailedMembers:                 o.StatusDetailedMembers,
+			MemberPodDetails:                o.StatusMemberPodDetails,
+			Compactions:                     o.StatusCompactions,
+			ShardCount:                      o.StatusShardCount,
+			CQLConnections:                  o.StatusCQLConnections,
+			DataUsage:                       o.StatusDataUsage,
+			NodeUptime:                      o.StatusNodeUptime,
+			CleanupRecommendation:           o.StatusCleanupRecommendation,
+			TokenRangeOverlap:               o.StatusTokenRangeOverlap,
+			SeedReachability:                o.StatusSeedReachability,
+			DataReplication:                 o.StatusDataReplication,
+			ResourceUtilization:             o.StatusResourceUtilization,
+			Topology:                        o.StatusTopology,
+			WorkloadPrioritization:          o.StatusWorkloadPrioritization,
+			ConsistentTopology:              o.StatusConsistentTopology,
+			LargePartitions:                 o.StatusLargePartitions,
+			LargePartitionThresholdBytes:    o.StatusLargePartitionThreshold,
+			ClockSkew:                       o.StatusClockSkew,
+			ClockSkewThreshold:              o.StatusClockSkewThreshold,
+			Snapshots:                       o.StatusSnapshots,
+			SnapshotThresholdBytes:          o.StatusSnapshotThreshold,
+			DiskPressure:                    o.StatusDiskPressure,
+			DiskPressureWatermarkPercent:    o.StatusDiskPressureWatermark,
+			IntraRackVersionSkew:            o.StatusIntraRackVersionSkew,
+			IntraRackVersionSkewGracePeriod: o.StatusIntraRackVersionGrace,
+			SchemaOverview:                  o.StatusSchemaOverview,
+			RackQueryTimeout:                o.StatusRackQueryTimeout,
+			LiveQueryTimeout:                o.StatusLiveQueryTimeout,
+			SchemaOverviewRefreshInterval:   o.StatusSchemaOverviewRefresh,
+			Latency:                         o.StatusLatency,
+			LatencyRefreshInterval:          o.StatusLatencyRefresh,
+			HistorySize:                     o.StatusHistorySize,
+		},
 	)
 	if err != nil {
 		return fmt.Errorf("can't create scylladbdatacenter controller: %w", err)
@@ -708,7 +911,55 @@ func (o *OperatorOptions) run(ctx context.Context, streams genericclioptions.IOS
 		sdbcc.Run(ctx, o.ConcurrentSyncs)
 	}()
 
+	if len(o.HTTPAddress) != 0 {
+		mux := http.NewServeMux()
+		mux.HandleFunc(naming.ScyllaDBDatacenterReadinessSummaryPath, sdcc.ServeReadinessSummary)
+		mux.HandleFunc(naming.ScyllaDBDatacenterStatusHistoryPath, sdcc.ServeStatusHistory)
+
+		listener, err := net.Listen("tcp", o.HTTPAddress)
+		if err != nil {
+			return fmt.Errorf("can't create tcp listener on address %q: %w", o.HTTPAddress, err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serveHTTP(ctx, listener, mux)
+		}()
+	}
+
 	<-ctx.Done()
 
 	return nil
 }
+
+func serveHTTP(ctx context.Context, listener net.Listener, handler http.Handler) {
+	server := &http.Server{
+		Handler: handler,
+	}
+
+	klog.InfoS("Starting HTTP server", "Address", listener.Addr().String())
+	defer klog.InfoS("HTTP server shut down")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		<-ctx.Done()
+		klog.Infof("Shutting down HTTP server.")
+		shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer shutdownCtxCancel()
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			klog.ErrorS(err, "can't shut down the HTTP server")
+		}
+	}()
+
+	err := server.Serve(listener)
+	if !errors.Is(err, http.ErrServerClosed) {
+		klog.ErrorS(err, "HTTP server failed")
+	}
+}
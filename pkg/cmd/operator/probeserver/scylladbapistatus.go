@@ -5,12 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/scylladb/scylla-operator/pkg/genericclioptions"
 	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
 	"github.com/scylladb/scylla-operator/pkg/probeserver/scylladbapistatus"
 	"github.com/scylladb/scylla-operator/pkg/signals"
 	"github.com/scylladb/scylla-operator/pkg/version"
@@ -19,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -32,19 +39,99 @@ type ScyllaDBAPIStatusOptions struct {
 	genericclioptions.ClientConfig
 	genericclioptions.InClusterReflection
 	ServiceName string
+	PodName     string
 	AwaitPaths  []string
 
-	mux        *http.ServeMux
-	kubeClient kubernetes.Interface
+	AwaitPathsMinSize map[string]int64
+
+	AwaitPathsManifestDir string
+
+	MaxBootstrapDuration time.Duration
+
+	ReadyzTimeout  time.Duration
+	HealthzTimeout time.Duration
+
+	ReadyzCacheRefreshInterval time.Duration
+	ReadyzCacheMaxStaleness    time.Duration
+	ReadyzFallbackFreshness    time.Duration
+
+	SkipNativeTransportCheck bool
+	AlternatorPort           int
+	RequireTokens            bool
+	WarmupHold               time.Duration
+	GossipSettleWindow       time.Duration
+	ResumeWarmupHold         time.Duration
+	ResumeGossipSettleWindow time.Duration
+
+	CommitlogReplayCheck bool
+
+	MinUNNodes int
+
+	ListenAddressCheck bool
+	PodIP              string
+
+	PlacementCheck bool
+
+	CQLAuthCheck       bool
+	CQLQueryCheck      bool
+	CQLCredentialsPath string
+
+	RequiredKeyspace string
+
+	ExpectedVersion  string
+	RequiredFeatures []string
+
+	ConfigReloadStatusURL string
+
+	MetricsCheck bool
+	MetricsPort  int
+
+	ExtraMaintenanceLabels     []string
+	MaintenanceDrainProbeCount int
+
+	SuccessLogLevel int32
+	FailureLogLevel int32
+
+	DrainAuthTokenPath string
+
+	ProbeMethods []string
+
+	ReportPodCondition         bool
+	PodConditionReportInterval time.Duration
+
+	PrimeClient bool
+
+	mux            *http.ServeMux
+	diagnosticMux  *http.ServeMux
+	kubeClient     kubernetes.Interface
+	drainAuthToken string
 }
 
 func NewScyllaDBAPIStatusOptions(streams genericclioptions.IOStreams) *ScyllaDBAPIStatusOptions {
 	mux := http.NewServeMux()
+	diagnosticMux := http.NewServeMux()
+
+	serveProbesOptions := NewServeProbesOptions(streams, naming.ScyllaDBAPIStatusProbePort, mux)
+	serveProbesOptions.diagnosticHandler = diagnosticMux
 
 	return &ScyllaDBAPIStatusOptions{
-		ServeProbesOptions: *NewServeProbesOptions(streams, naming.ScyllaDBAPIStatusProbePort, mux),
+		ServeProbesOptions: *serveProbesOptions,
 		ClientConfig:       genericclioptions.NewClientConfig("scylla-operator-scylladb-api-status-probe"),
-		mux:                mux,
+		ReadyzTimeout:      scylladbapistatus.DefaultProbeTimeout,
+		HealthzTimeout:     scylladbapistatus.DefaultProbeTimeout,
+
+		ResumeWarmupHold:         scylladbapistatus.DefaultResumeWarmupHold,
+		ResumeGossipSettleWindow: scylladbapistatus.DefaultResumeGossipSettleWindow,
+
+		ReadyzCacheRefreshInterval: 0,
+		ReadyzCacheMaxStaleness:    30 * time.Second,
+		SuccessLogLevel:            int32(scylladbapistatus.DefaultSuccessLogLevel),
+		FailureLogLevel:            int32(scylladbapistatus.DefaultFailureLogLevel),
+		ProbeMethods:               slices.Clone(scylladbapistatus.DefaultProbeMethods),
+		MetricsPort:                scylladbapistatus.DefaultMetricsPort,
+		PodConditionReportInterval: scylladbapistatus.DefaultPodConditionReportInterval,
+		mux:                        mux,
+		diagnosticMux:              diagnosticMux,
 	}
 }
 
@@ -54,7 +141,46 @@ func (o *ScyllaDBAPIStatusOptions) AddFlags(cmd *cobra.Command) {
 	o.InClusterReflection.AddFlags(cmd)
 
 	cmd.Flags().StringVarP(&o.ServiceName, "service-name", "", o.ServiceName, "Name of the service corresponding to the managed node.")
+	cmd.Flags().StringVarP(&o.PodName, "pod-name", "", o.PodName, "Name of the local Pod, usually provided by the downward API. Defaults to service-name, as member Pods share the name with their Services.")
 	cmd.Flags().StringSliceVarP(&o.AwaitPaths, "await-paths", "", o.AwaitPaths, "Paths to await existence of. Until all exist, service will be considered healthy and unready.")
+	cmd.Flags().StringToInt64VarP(&o.AwaitPathsMinSize, "await-paths-min-size", "", o.AwaitPathsMinSize, "Minimum size in bytes of await paths, keyed by the path. Until a path reaches it, it is considered not to exist yet. Useful for markers that are created empty and populated later.")
+	cmd.Flags().StringVarP(&o.AwaitPathsManifestDir, "await-paths-manifest-dir", "", o.AwaitPathsManifestDir, "Directory of manifest files listing additional paths to await existence of, one per line. It is re-read on every probe.")
+	cmd.Flags().DurationVarP(&o.MaxBootstrapDuration, "max-bootstrap-duration", "", o.MaxBootstrapDuration, "Maximum duration since the start of the probe server for which the service is considered healthy while await paths don't exist. Zero means no limit.")
+	cmd.Flags().DurationVarP(&o.ReadyzTimeout, "readyz-timeout", "", o.ReadyzTimeout, "Timeout for evaluating readiness probes.")
+	cmd.Flags().DurationVarP(&o.HealthzTimeout, "healthz-timeout", "", o.HealthzTimeout, "Timeout for evaluating liveness probes.")
+	cmd.Flags().DurationVarP(&o.ReadyzCacheRefreshInterval, "readyz-cache-refresh-interval", "", o.ReadyzCacheRefreshInterval, "Interval of refreshing cached ScyllaDB API readiness checks in the background. Zero disables the cache and every readiness probe contacts ScyllaDB API.")
+	cmd.Flags().DurationVarP(&o.ReadyzCacheMaxStaleness, "readyz-cache-max-staleness", "", o.ReadyzCacheMaxStaleness, "Maximum age of cached ScyllaDB API readiness checks that readiness probes use before falling back to a live call.")
+	cmd.Flags().DurationVarP(&o.ReadyzFallbackFreshness, "readyz-fallback-freshness", "", o.ReadyzFallbackFreshness, "Maximum age of the last definitive outcome of ScyllaDB API readiness checks that readiness probes return when a call to ScyllaDB API fails, to ride out brief API outages. Zero disables the fallback.")
+	cmd.Flags().BoolVarP(&o.SkipNativeTransportCheck, "skip-native-transport-check", "", o.SkipNativeTransportCheck, "Consider a UN node ready regardless of its native transport state. Useful for Alternator-only deployments.")
+	cmd.Flags().BoolVarP(&o.RequireTokens, "require-tokens", "", o.RequireTokens, "Consider a UN node ready only if it owns at least one token.")
+	cmd.Flags().BoolVarP(&o.CommitlogReplayCheck, "commitlog-replay-check", "", o.CommitlogReplayCheck, "Consider a node ready only once it finished starting up, which includes replaying its commitlog after a restart.")
+	cmd.Flags().IntVarP(&o.MinUNNodes, "min-un-nodes", "", o.MinUNNodes, "Consider a node ready only once at least this many nodes are UN in its view of the cluster. The first node of the first rack is exempt, as it bootstraps alone. Zero disables the check.")
+	cmd.Flags().BoolVarP(&o.ListenAddressCheck, "listen-address-check", "", o.ListenAddressCheck, "Consider a node ready only if its listen address matches the Pod IP. Requires pod-ip.")
+	cmd.Flags().BoolVarP(&o.PlacementCheck, "placement-check", "", o.PlacementCheck, "Consider a node ready only if its datacenter and rack in ScyllaDB topology match the ones of its Pod.")
+	cmd.Flags().StringVarP(&o.PodIP, "pod-ip", "", o.PodIP, "IP of the local Pod, usually provided by the downward API, used by the listen address check.")
+	cmd.Flags().DurationVarP(&o.WarmupHold, "warmup-hold", "", o.WarmupHold, "Duration for which a node is kept unready after it becomes UN with native transport enabled, to let it warm its caches before receiving traffic. The hold applies again whenever the node stops being UN or serving clients. Zero disables the hold.")
+	cmd.Flags().DurationVarP(&o.GossipSettleWindow, "gossip-settle-window", "", o.GossipSettleWindow, "Duration for which the endpoint states in the view of the cluster of a node have to stay unchanged before it is ready, so a rejoining node doesn't receive traffic while its view is converging. Zero disables the check.")
+	cmd.Flags().DurationVarP(&o.ResumeWarmupHold, "resume-warmup-hold", "", o.ResumeWarmupHold, "Duration for which a node of a cluster that is being resumed from pause is kept unready after it is first observed UN during the resume.")
+	cmd.Flags().DurationVarP(&o.ResumeGossipSettleWindow, "resume-gossip-settle-window", "", o.ResumeGossipSettleWindow, "Duration for which the endpoint states in the view of the cluster of a node that is being resumed from pause have to stay unchanged before it is ready.")
+	cmd.Flags().BoolVarP(&o.CQLAuthCheck, "cql-auth-check", "", o.CQLAuthCheck, "Consider a node ready only if it accepts an authenticated CQL session. Requires cql-credentials-path.")
+	cmd.Flags().BoolVarP(&o.CQLQueryCheck, "cql-query-check", "", o.CQLQueryCheck, "Consider a node ready only if it executes a trivial query over CQL. The session is authenticated with the credentials in cql-credentials-path, if it's set.")
+	cmd.Flags().StringVarP(&o.CQLCredentialsPath, "cql-credentials-path", "", o.CQLCredentialsPath, "Directory with a mounted basic-auth Secret holding credentials used by the CQL authentication and query checks.")
+	cmd.Flags().StringVarP(&o.RequiredKeyspace, "required-keyspace", "", o.RequiredKeyspace, "Consider a node ready only once the keyspace with this name exists. Empty disables the check.")
+	cmd.Flags().StringVarP(&o.ExpectedVersion, "expected-version", "", o.ExpectedVersion, "Consider a node ready only if it runs this ScyllaDB version. Empty disables the check.")
+	cmd.Flags().StringSliceVarP(&o.RequiredFeatures, "required-features", "", o.RequiredFeatures, "Consider a node ready only once all of these ScyllaDB features are enabled in the cluster. Empty disables the check.")
+	cmd.Flags().StringVarP(&o.ConfigReloadStatusURL, "config-reload-status-url", "", o.ConfigReloadStatusURL, "URL of the sidecar endpoint reporting whether a config reload is in progress. While it is, the node is considered unready. Empty disables the check.")
+	cmd.Flags().BoolVarP(&o.MetricsCheck, "metrics-check", "", o.MetricsCheck, "Verify that the ScyllaDB Prometheus metrics endpoint responds. It being down is reported as a warning and doesn't fail probes.")
+	cmd.Flags().IntVarP(&o.MetricsPort, "metrics-port", "", o.MetricsPort, "Port of the ScyllaDB Prometheus metrics endpoint verified by the metrics check and read by the drain complete probe.")
+	cmd.Flags().StringSliceVarP(&o.ExtraMaintenanceLabels, "extra-maintenance-labels", "", o.ExtraMaintenanceLabels, "Keys of additional labels that mark the node as under maintenance when present on its service. The built-in maintenance label is always checked.")
+	cmd.Flags().IntVarP(&o.MaintenanceDrainProbeCount, "maintenance-drain-probe-count", "", o.MaintenanceDrainProbeCount, "Number of consecutive unready readiness probe responses served during maintenance after which the drain endpoint reports the node as drained.")
+	cmd.Flags().Int32VarP(&o.SuccessLogLevel, "success-log-level", "", o.SuccessLogLevel, "Log verbosity at which successful probe outcomes are logged. A level above the configured verbosity silences them.")
+	cmd.Flags().Int32VarP(&o.FailureLogLevel, "failure-log-level", "", o.FailureLogLevel, "Log verbosity at which failed probe outcomes are logged. Unexpected errors are always logged.")
+	cmd.Flags().StringVarP(&o.DrainAuthTokenPath, "drain-auth-token-path", "", o.DrainAuthTokenPath, "Path to a file with a bearer token authorizing non-local callers of the drain and nodes endpoints. If empty, only local callers can drain the node or query nodes.")
+	cmd.Flags().StringSliceVarP(&o.ProbeMethods, "probe-methods", "", o.ProbeMethods, "HTTP methods accepted by probe endpoints. Requests using other methods are rejected with 405.")
+	cmd.Flags().BoolVarP(&o.ReportPodCondition, "report-pod-condition", "", o.ReportPodCondition, fmt.Sprintf("Reflect the latest readiness probe outcome in the %s condition of the local Pod. Requires permission to patch pods/status.", naming.NodeReadyPodCondition))
+	cmd.Flags().DurationVarP(&o.PodConditionReportInterval, "pod-condition-report-interval", "", o.PodConditionReportInterval, "Interval of reflecting the latest readiness probe outcome in the condition of the local Pod.")
+	cmd.Flags().BoolVarP(&o.PrimeClient, "prime-client", "", o.PrimeClient, "Create and warm up a ScyllaDB API client at startup, so the first probe doesn't pay for creating it.")
+	cmd.Flags().IntVarP(&o.AlternatorPort, "alternator-port", "", o.AlternatorPort, "Alternator port to check instead of native transport when native transport check is skipped. Zero disables the check.")
 }
 
 func NewScyllaDBAPIStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
@@ -108,6 +234,121 @@ func (o *ScyllaDBAPIStatusOptions) Validate(args []string) error {
 		}
 	}
 
+	if len(o.PodName) != 0 {
+		podNameValidationErrs := apimachineryvalidation.NameIsDNSSubdomain(o.PodName, false)
+		if len(podNameValidationErrs) != 0 {
+			errs = append(errs, fmt.Errorf("invalid pod name %q: %v", o.PodName, podNameValidationErrs))
+		}
+	}
+
+	if o.MaxBootstrapDuration < 0 {
+		errs = append(errs, fmt.Errorf("max-bootstrap-duration can't be negative, got %v", o.MaxBootstrapDuration))
+	}
+
+	if o.ReadyzTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("readyz-timeout must be positive, got %v", o.ReadyzTimeout))
+	}
+
+	if o.HealthzTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("healthz-timeout must be positive, got %v", o.HealthzTimeout))
+	}
+
+	if o.ReadyzCacheRefreshInterval < 0 {
+		errs = append(errs, fmt.Errorf("readyz-cache-refresh-interval can't be negative, got %v", o.ReadyzCacheRefreshInterval))
+	}
+
+	if o.ReadyzCacheRefreshInterval > 0 && o.ReadyzCacheMaxStaleness < o.ReadyzCacheRefreshInterval {
+		errs = append(errs, fmt.Errorf("readyz-cache-max-staleness (%v) can't be lower than readyz-cache-refresh-interval (%v)", o.ReadyzCacheMaxStaleness, o.ReadyzCacheRefreshInterval))
+	}
+
+	if o.ReportPodCondition && o.PodConditionReportInterval <= 0 {
+		errs = append(errs, fmt.Errorf("pod-condition-report-interval must be positive when report-pod-condition is enabled, got %v", o.PodConditionReportInterval))
+	}
+
+	if o.ReadyzFallbackFreshness < 0 {
+		errs = append(errs, fmt.Errorf("readyz-fallback-freshness can't be negative, got %v", o.ReadyzFallbackFreshness))
+	}
+
+	if o.MinUNNodes < 0 {
+		errs = append(errs, fmt.Errorf("min-un-nodes (%d) can't be negative", o.MinUNNodes))
+	}
+
+	if o.ListenAddressCheck && net.ParseIP(o.PodIP) == nil {
+		errs = append(errs, fmt.Errorf("pod-ip must be a valid IP when listen-address-check is enabled, got %q", o.PodIP))
+	}
+
+	if o.WarmupHold < 0 {
+		errs = append(errs, fmt.Errorf("warmup-hold can't be negative, got %v", o.WarmupHold))
+	}
+
+	if o.GossipSettleWindow < 0 {
+		errs = append(errs, fmt.Errorf("gossip-settle-window can't be negative, got %v", o.GossipSettleWindow))
+	}
+
+	if o.ResumeWarmupHold <= 0 {
+		errs = append(errs, fmt.Errorf("resume-warmup-hold must be positive, got %v", o.ResumeWarmupHold))
+	}
+
+	if o.ResumeGossipSettleWindow <= 0 {
+		errs = append(errs, fmt.Errorf("resume-gossip-settle-window must be positive, got %v", o.ResumeGossipSettleWindow))
+	}
+
+	if o.CQLAuthCheck && len(o.CQLCredentialsPath) == 0 {
+		errs = append(errs, fmt.Errorf("cql-credentials-path can't be empty when cql-auth-check is enabled"))
+	}
+
+	if len(o.ConfigReloadStatusURL) != 0 {
+		u, err := url.Parse(o.ConfigReloadStatusURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid config-reload-status-url %q: %w", o.ConfigReloadStatusURL, err))
+		} else if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			errs = append(errs, fmt.Errorf("config-reload-status-url %q has to be an absolute http or https URL", o.ConfigReloadStatusURL))
+		}
+	}
+
+	for _, f := range o.RequiredFeatures {
+		if len(f) == 0 {
+			errs = append(errs, fmt.Errorf("required features can't contain an empty feature name"))
+			break
+		}
+	}
+
+	for _, label := range o.ExtraMaintenanceLabels {
+		for _, msg := range validation.IsQualifiedName(label) {
+			errs = append(errs, fmt.Errorf("invalid extra maintenance label %q: %s", label, msg))
+		}
+	}
+
+	if o.MaintenanceDrainProbeCount < 0 {
+		errs = append(errs, fmt.Errorf("maintenance-drain-probe-count (%d) can't be negative", o.MaintenanceDrainProbeCount))
+	}
+
+	if o.SuccessLogLevel < 0 {
+		errs = append(errs, fmt.Errorf("success-log-level (%d) can't be negative", o.SuccessLogLevel))
+	}
+
+	if o.FailureLogLevel < 0 {
+		errs = append(errs, fmt.Errorf("failure-log-level (%d) can't be negative", o.FailureLogLevel))
+	}
+
+	if len(o.ProbeMethods) == 0 {
+		errs = append(errs, fmt.Errorf("probe-methods can't be empty"))
+	}
+
+	for _, method := range o.ProbeMethods {
+		if len(method) == 0 || strings.ToUpper(method) != method {
+			errs = append(errs, fmt.Errorf("invalid probe method %q: it has to be a non-empty upper case HTTP method", method))
+		}
+	}
+
+	if o.AlternatorPort < 0 || o.AlternatorPort > 65535 {
+		errs = append(errs, fmt.Errorf("invalid alternator port %d", o.AlternatorPort))
+	}
+
+	if o.MetricsPort < 0 || o.MetricsPort > 65535 {
+		errs = append(errs, fmt.Errorf("invalid metrics port %d", o.MetricsPort))
+	}
+
 	for _, path := range o.AwaitPaths {
 		_, err = os.Stat(path)
 		if err != nil && !errors.Is(err, fs.ErrNotExist) {
@@ -115,6 +356,16 @@ func (o *ScyllaDBAPIStatusOptions) Validate(args []string) error {
 		}
 	}
 
+	for path, minSize := range o.AwaitPathsMinSize {
+		if !slices.Contains(o.AwaitPaths, path) {
+			errs = append(errs, fmt.Errorf("await-paths-min-size refers to path %q that isn't awaited", path))
+		}
+
+		if minSize < 0 {
+			errs = append(errs, fmt.Errorf("await-paths-min-size of path %q (%d) can't be negative", path, minSize))
+		}
+	}
+
 	return apierrors.NewAggregate(errs)
 }
 
@@ -139,6 +390,18 @@ func (o *ScyllaDBAPIStatusOptions) Complete(args []string) error {
 		return fmt.Errorf("can't build kubernetes clientset: %w", err)
 	}
 
+	if len(o.DrainAuthTokenPath) != 0 {
+		data, err := os.ReadFile(o.DrainAuthTokenPath)
+		if err != nil {
+			return fmt.Errorf("can't read drain auth token: %w", err)
+		}
+
+		o.drainAuthToken = strings.TrimSpace(string(data))
+		if len(o.drainAuthToken) == 0 {
+			return fmt.Errorf("drain auth token in %q can't be empty", o.DrainAuthTokenPath)
+		}
+	}
+
 	return nil
 }
 
@@ -170,24 +433,127 @@ func (o *ScyllaDBAPIStatusOptions) Execute(ctx context.Context, originalStreams
 	)
 	singleServiceInformer := singleServiceKubeInformers.Core().V1().Services()
 
+	// Member Pods share the name with their Services.
+	podName := o.ServiceName
+	if len(o.PodName) != 0 {
+		podName = o.PodName
+	}
+	singlePodKubeInformers := informers.NewSharedInformerFactoryWithOptions(
+		o.kubeClient,
+		12*time.Hour,
+		informers.WithNamespace(o.Namespace),
+		informers.WithTweakListOptions(
+			func(options *metav1.ListOptions) {
+				options.FieldSelector = fields.OneTermEqualSelector("metadata.name", podName).String()
+			},
+		),
+	)
+	singlePodInformer := singlePodKubeInformers.Core().V1().Pods()
+
+	awaitPaths := make([]scylladbapistatus.AwaitPath, 0, len(o.AwaitPaths))
+	for _, path := range o.AwaitPaths {
+		awaitPaths = append(awaitPaths, scylladbapistatus.AwaitPath{
+			Path:    path,
+			MinSize: o.AwaitPathsMinSize[path],
+		})
+	}
+
 	prober := scylladbapistatus.NewProber(
 		o.Namespace,
 		o.ServiceName,
 		singleServiceInformer.Lister(),
-		o.AwaitPaths,
+		podName,
+		singlePodInformer.Lister(),
+		awaitPaths,
+		scylladbapistatus.ProberOptions{
+			SkipNativeTransportCheck:   o.SkipNativeTransportCheck,
+			AlternatorPort:             o.AlternatorPort,
+			RequireTokens:              o.RequireTokens,
+			CommitlogReplayCheck:       o.CommitlogReplayCheck,
+			MinUNNodes:                 o.MinUNNodes,
+			ListenAddressCheck:         o.ListenAddressCheck,
+			PlacementCheck:             o.PlacementCheck,
+			PodIP:                      o.PodIP,
+			WarmupHold:                 o.WarmupHold,
+			GossipSettleWindow:         o.GossipSettleWindow,
+			ResumeWarmupHold:           o.ResumeWarmupHold,
+			ResumeGossipSettleWindow:   o.ResumeGossipSettleWindow,
+			CQLAuthCheck:               o.CQLAuthCheck,
+			CQLQueryCheck:              o.CQLQueryCheck,
+			CQLCredentialsPath:         o.CQLCredentialsPath,
+			RequiredKeyspace:           o.RequiredKeyspace,
+			ExpectedVersion:            o.ExpectedVersion,
+			RequiredFeatures:           o.RequiredFeatures,
+			ConfigReloadStatusURL:      o.ConfigReloadStatusURL,
+			MetricsCheck:               o.MetricsCheck,
+			MetricsPort:                o.MetricsPort,
+			AwaitPathsManifestDir:      o.AwaitPathsManifestDir,
+			MaxBootstrapDuration:       o.MaxBootstrapDuration,
+			ReadyzTimeout:              o.ReadyzTimeout,
+			HealthzTimeout:             o.HealthzTimeout,
+			ReadyzCacheRefreshInterval: o.ReadyzCacheRefreshInterval,
+			ReadyzCacheMaxStaleness:    o.ReadyzCacheMaxStaleness,
+			ReadyzFallbackFreshness:    o.ReadyzFallbackFreshness,
+			ExtraMaintenanceLabels:     o.ExtraMaintenanceLabels,
+			MaintenanceDrainProbeCount: o.MaintenanceDrainProbeCount,
+			SuccessLogLevel:            pointer.Ptr(klog.Level(o.SuccessLogLevel)),
+			FailureLogLevel:            pointer.Ptr(klog.Level(o.FailureLogLevel)),
+			DrainAuthToken:             o.drainAuthToken,
+			ProbeMethods:               o.ProbeMethods,
+			ReportPodCondition:         o.ReportPodCondition,
+			PodConditionReportInterval: o.PodConditionReportInterval,
+			PrimeClient:                o.PrimeClient,
+		},
 	)
 
 	o.mux.HandleFunc(naming.LivenessProbePath, prober.Healthz)
+	o.mux.HandleFunc(naming.LivezProbePath, prober.Livez)
 	o.mux.HandleFunc(naming.ReadinessProbePath, prober.Readyz)
+	o.mux.HandleFunc(naming.PodReadinessProbePath, prober.PodReadyz)
+
+	diagnosticMux := o.mux
+	if o.separateDiagnostics() {
+		diagnosticMux = o.diagnosticMux
+	}
+	diagnosticMux.HandleFunc(naming.DrainProbePath, prober.Drainz)
+	diagnosticMux.HandleFunc(naming.DrainCompletePath, prober.DrainComplete)
+	diagnosticMux.HandleFunc(naming.DrainPath, prober.Drain)
+	diagnosticMux.HandleFunc(naming.ConfigzPath, prober.Configz)
+	diagnosticMux.HandleFunc(naming.NodesPath, prober.Nodes)
+	diagnosticMux.HandleFunc(naming.PathzPath, prober.Pathz)
 
 	// Start informers.
 	singleServiceKubeInformers.Start(ctx.Done())
 	defer singleServiceKubeInformers.Shutdown()
 
-	ok := cache.WaitForNamedCacheSync("Prober", ctx.Done(), singleServiceInformer.Informer().HasSynced)
+	singlePodKubeInformers.Start(ctx.Done())
+	defer singlePodKubeInformers.Shutdown()
+
+	ok := cache.WaitForNamedCacheSync("Prober", ctx.Done(), singleServiceInformer.Informer().HasSynced, singlePodInformer.Informer().HasSynced)
 	if !ok {
-		return fmt.Errorf("error waiting for service informer cache to sync")
+		return fmt.Errorf("error waiting for service and pod informer caches to sync")
 	}
 
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		prober.Run(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		prober.RunClientPrimer(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		prober.RunPodConditionReporter(ctx, o.kubeClient.CoreV1().Pods(o.Namespace))
+	}()
+
 	return o.ServeProbesOptions.Execute(ctx, originalStreams, cmd)
 }
@@ -23,7 +23,14 @@ type ServeProbesOptions struct {
 	Address string
 	Port    uint16
 
+	DiagnosticAddress string
+	DiagnosticPort    uint16
+
 	handler http.Handler
+
+	// diagnosticHandler serves diagnostic endpoints on a separate listener when DiagnosticPort is set.
+	// Nil means there are no diagnostic endpoints to separate.
+	diagnosticHandler http.Handler
 }
 
 func NewServeProbesOptions(streams genericclioptions.IOStreams, port uint16, handler http.Handler) *ServeProbesOptions {
@@ -36,14 +43,28 @@ func NewServeProbesOptions(streams genericclioptions.IOStreams, port uint16, han
 func (o *ServeProbesOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.Address, "address", "", o.Address, "Listen address for the server.")
 	cmd.Flags().Uint16VarP(&o.Port, "port", "", o.Port, "Port to use for the server.")
+
+	if o.diagnosticHandler != nil {
+		cmd.Flags().StringVarP(&o.DiagnosticAddress, "diagnostic-address", "", o.DiagnosticAddress, "Listen address for the diagnostic endpoints. It only takes effect with diagnostic-port.")
+		cmd.Flags().Uint16VarP(&o.DiagnosticPort, "diagnostic-port", "", o.DiagnosticPort, "Port to serve the diagnostic endpoints on, separately from the probes. Zero serves them together with the probes.")
+	}
 }
 
 func (o *ServeProbesOptions) Validate(args []string) error {
 	var errs []error
 
+	if o.DiagnosticPort != 0 && o.DiagnosticPort == o.Port {
+		errs = append(errs, fmt.Errorf("diagnostic-port must differ from port %d", o.Port))
+	}
+
 	return apierrors.NewAggregate(errs)
 }
 
+// separateDiagnostics returns true if the diagnostic endpoints are served on their own listener.
+func (o *ServeProbesOptions) separateDiagnostics() bool {
+	return o.diagnosticHandler != nil && o.DiagnosticPort != 0
+}
+
 func (o *ServeProbesOptions) Complete(args []string) error {
 	return nil
 }
@@ -64,9 +85,45 @@ func (o *ServeProbesOptions) Run(originalStreams genericclioptions.IOStreams, cm
 }
 
 func (o *ServeProbesOptions) Execute(ctx context.Context, originalStreams genericclioptions.IOStreams, cmd *cobra.Command) error {
+	probeAddr := net.JoinHostPort(o.Address, strconv.Itoa(int(o.Port)))
+	if !o.separateDiagnostics() {
+		return serve(ctx, "probe", probeAddr, o.handler)
+	}
+
+	// Shut down both servers when either of them fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	servers := []struct {
+		name    string
+		addr    string
+		handler http.Handler
+	}{
+		{name: "probe", addr: probeAddr, handler: o.handler},
+		{name: "diagnostic", addr: net.JoinHostPort(o.DiagnosticAddress, strconv.Itoa(int(o.DiagnosticPort))), handler: o.diagnosticHandler},
+	}
+
+	errs := make([]error, len(servers))
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer cancel()
+
+			errs[i] = serve(ctx, s.name, s.addr, s.handler)
+		}()
+	}
+	wg.Wait()
+
+	return apierrors.NewAggregate(errs)
+}
+
+// serve serves the handler on the address until the context is cancelled.
+func serve(ctx context.Context, name string, addr string, handler http.Handler) error {
 	server := &http.Server{
-		Addr:    net.JoinHostPort(o.Address, strconv.Itoa(int(o.Port))),
-		Handler: o.handler,
+		Addr:    addr,
+		Handler: handler,
 	}
 
 	listener, err := net.Listen("tcp", server.Addr)
@@ -75,8 +132,8 @@ func (o *ServeProbesOptions) Execute(ctx context.Context, originalStreams generi
 	}
 
 	resolvedListenAddr := listener.Addr().String()
-	klog.InfoS("Starting probe server", "Address", resolvedListenAddr)
-	defer klog.InfoS("Probe server shut down")
+	klog.InfoS("Starting server", "Server", name, "Address", resolvedListenAddr)
+	defer klog.InfoS("Server shut down", "Server", name)
 
 	var wg sync.WaitGroup
 	defer wg.Wait()
@@ -86,7 +143,7 @@ func (o *ServeProbesOptions) Execute(ctx context.Context, originalStreams generi
 		defer wg.Done()
 
 		<-ctx.Done()
-		klog.Infof("Shutting down probe server.")
+		klog.InfoS("Shutting down server", "Server", name)
 		shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer shutdownCtxCancel()
 		err := server.Shutdown(shutdownCtx)
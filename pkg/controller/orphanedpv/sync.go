@@ -97,6 +97,11 @@ func (opc *Controller) sync(ctx context.Context, key string) error {
 		return nil
 	}
 
+	if controllerhelpers.IsScyllaDBDatacenterQuarantined(sdc) {
+		klog.V(2).InfoS("ScyllaDBDatacenter is quarantined, skipping orphaned node replacement", "ScyllaDBDatacenter", klog.KObj(sdc))
+		return nil
+	}
+
 	if sdc.Spec.DisableAutomaticOrphanedNodeReplacement == nil || *sdc.Spec.DisableAutomaticOrphanedNodeReplacement {
 		klog.V(4).InfoS("ScyllaDBDatacenter has AutomaticOrphanedNodeReplacement disabled", "ScyllaDBDatacenter", klog.KObj(sdc))
 		return nil
@@ -58,6 +58,129 @@ var (
 	statefulSetControllerGVK        = appsv1.SchemeGroupVersion.WithKind("StatefulSet")
 )
 
+// StatusOptions configures optional status fields that require contacting the ScyllaDB nodes directly.
+type StatusOptions struct {
+	// AlternatorReadiness enables reporting the number of nodes accepting connections on the Alternator port.
+	AlternatorReadiness bool
+
+	// SchemaVersion enables reporting schema versions of racks and schema disagreement between them.
+	SchemaVersion bool
+
+	// SchemaMigrations enables reporting the number of nodes of each rack that are still applying schema changes.
+	SchemaMigrations bool
+
+	// DetailedMembers enables reporting the states of the individual members of each rack.
+	DetailedMembers bool
+
+	// MemberPodDetails extends the reported member states with the conditions and restart count of their Pods.
+	// It only takes effect with DetailedMembers.
+	MemberPodDetails bool
+
+	// Compactions enables reporting the number of nodes of each rack that have completed their first compaction.
+	Compactions bool
+
+	// ShardCount enables reporting shard counts of rack nodes and shard count mismatches within racks.
+	ShardCount bool
+
+	// CQLConnections enables reporting the number of CQL client connections of each rack.
+	CQLConnections bool
+
+	// DataUsage enables reporting the number of bytes of data stored on nodes of each rack.
+	DataUsage bool
+
+	// NodeUptime enables deriving node start times in rack status from the uptime reported by the nodes,
+	// instead of the start time of their ScyllaDB containers.
+	NodeUptime bool
+
+	// CleanupRecommendation enables the advisory CleanupRecommended condition reported after node count changes.
+	CleanupRecommendation bool
+
+	// TokenRangeOverlap enables collecting the tokens owned by nodes and reporting tokens claimed by several nodes.
+	TokenRangeOverlap bool
+
+	// SeedReachability enables checking that the seeds of the datacenter are up in the gossip view of each rack.
+	SeedReachability bool
+
+	// DataReplication enables reporting keyspaces with replicas on down nodes.
+	DataReplication bool
+
+	// ResourceUtilization enables reporting the average CPU and memory utilization of racks, read from the metrics API.
+	ResourceUtilization bool
+
+	// Topology enables checking that nodes report the datacenter and rack they belong to in the spec.
+	Topology bool
+
+	// WorkloadPrioritization enables checking that nodes have scheduling groups of the expected service levels.
+	WorkloadPrioritization bool
+
+	// ConsistentTopology enables reporting whether the cluster uses raft-based consistent topology changes.
+	ConsistentTopology bool
+
+	// LargePartitions enables reporting the largest partition size of each rack and partitions
+	// above LargePartitionThresholdBytes.
+	LargePartitions bool
+
+	// LargePartitionThresholdBytes is the partition size above which LargePartitions reports a partition as large.
+	// Zero means DefaultLargePartitionThresholdBytes.
+	LargePartitionThresholdBytes int64
+
+	// ClockSkew enables reporting nodes whose clocks drift from the clocks of the other nodes beyond ClockSkewThreshold.
+	ClockSkew bool
+
+	// ClockSkewThreshold is the clock drift above which ClockSkew reports a node. Zero means DefaultClockSkewThreshold.
+	ClockSkewThreshold time.Duration
+
+	// Snapshots enables reporting the space taken by snapshots in each rack and nodes
+	// with snapshots above SnapshotThresholdBytes.
+	Snapshots bool
+
+	// SnapshotThresholdBytes is the snapshot space usage of a node above which Snapshots reports it.
+	// Zero means DefaultSnapshotThresholdBytes.
+	SnapshotThresholdBytes int64
+
+	// DiskPressure enables reporting nodes in each rack whose data volume usage exceeds DiskPressureWatermarkPercent
+	// of its capacity.
+	DiskPressure bool
+
+	// DiskPressureWatermarkPercent is the data volume usage, in percent of its capacity, above which DiskPressure
+	// reports a node. Zero means DefaultDiskPressureWatermarkPercent.
+	DiskPressureWatermarkPercent int
+
+	// IntraRackVersionSkew enables reporting racks whose nodes run different ScyllaDB versions
+	// for longer than IntraRackVersionSkewGracePeriod.
+	IntraRackVersionSkew bool
+
+	// IntraRackVersionSkewGracePeriod is how long nodes in a rack can run different ScyllaDB versions,
+	// e.g. during an upgrade, before IntraRackVersionSkew reports the rack.
+	// Zero means DefaultIntraRackVersionSkewGracePeriod.
+	IntraRackVersionSkewGracePeriod time.Duration
+
+	// Latency enables reporting read and write latency percentiles in datacenter status.
+	Latency bool
+
+	// LatencyRefreshInterval is the minimum time between refreshes of the reported latencies.
+	LatencyRefreshInterval time.Duration
+
+	// SchemaOverview enables reporting keyspaces and their table counts in datacenter status.
+	SchemaOverview bool
+
+	// RackQueryTimeout bounds querying the nodes of a single rack for the reported values.
+	// Racks that time out are reported as if none of their nodes could be queried. Zero means a default timeout.
+	RackQueryTimeout time.Duration
+
+	// LiveQueryTimeout bounds querying ScyllaDB nodes for all the reported values of a datacenter in a single sync.
+	// Racks that aren't queried in time are reported as if none of their nodes could be queried.
+	// Zero means a default timeout.
+	LiveQueryTimeout time.Duration
+
+	// SchemaOverviewRefreshInterval is the minimum time between refreshes of the schema overview.
+	SchemaOverviewRefreshInterval time.Duration
+
+	// HistorySize is the number of the latest written statuses kept in memory for each datacenter
+	// and served by the status history endpoint. Zero disables the history.
+	HistorySize int
+}
+
 type Controller struct {
 	operatorImage   string
 	cqlsIngressPort int
@@ -66,6 +189,8 @@ type Controller struct {
 	scyllaClient scyllav1alpha1client.ScyllaV1alpha1Interface
 
 	podLister                corev1listers.PodLister
+	nodeLister               corev1listers.NodeLister
+	pvcLister                corev1listers.PersistentVolumeClaimLister
 	serviceLister            corev1listers.ServiceLister
 	secretLister             corev1listers.SecretLister
 	configMapLister          corev1listers.ConfigMapLister
@@ -85,6 +210,9 @@ type Controller struct {
 	handlers *controllerhelpers.Handlers[*scyllav1alpha1.ScyllaDBDatacenter]
 
 	keyGetter crypto.RSAKeyGetter
+
+	statusOptions StatusOptions
+	statusHistory *statusHistory
 }
 
 func NewController(
@@ -101,9 +229,12 @@ func NewController(
 	ingressInformer networkingv1informers.IngressInformer,
 	jobInformer batchv1informers.JobInformer,
 	scyllaDBDatacenterInformer scyllav1alpha1informers.ScyllaDBDatacenterInformer,
+	nodeInformer corev1informers.NodeInformer,
+	pvcInformer corev1informers.PersistentVolumeClaimInformer,
 	operatorImage string,
 	cqlsIngressPort int,
 	keyGetter crypto.RSAKeyGetter,
+	statusOptions StatusOptions,
 ) (*Controller, error) {
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartStructuredLogging(0)
@@ -117,6 +248,8 @@ func NewController(
 		scyllaClient: scyllaClient,
 
 		podLister:                podInformer.Lister(),
+		nodeLister:               nodeInformer.Lister(),
+		pvcLister:                pvcInformer.Lister(),
 		serviceLister:            serviceInformer.Lister(),
 		secretLister:             secretInformer.Lister(),
 		configMapLister:          configMapInformer.Lister(),
@@ -140,6 +273,8 @@ func NewController(
 			ingressInformer.Informer().HasSynced,
 			scyllaDBDatacenterInformer.Informer().HasSynced,
 			jobInformer.Informer().HasSynced,
+			nodeInformer.Informer().HasSynced,
+			pvcInformer.Informer().HasSynced,
 		},
 
 		eventRecorder: eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "scylladbdatacenter-controller"}),
@@ -147,6 +282,9 @@ func NewController(
 		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "scylladbdatacenter"),
 
 		keyGetter: keyGetter,
+
+		statusOptions: statusOptions,
+		statusHistory: newStatusHistory(statusOptions.HistorySize),
 	}
 
 	var err error
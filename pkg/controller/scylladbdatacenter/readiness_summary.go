@@ -0,0 +1,103 @@
+package scylladbdatacenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+)
+
+// RackReadinessSummary describes the readiness of a single rack.
+type RackReadinessSummary struct {
+	Name       string `json:"name"`
+	Ready      bool   `json:"ready"`
+	Nodes      int32  `json:"nodes"`
+	ReadyNodes int32  `json:"readyNodes"`
+}
+
+// ReadinessSummary describes the readiness of all members of a ScyllaDBDatacenter.
+type ReadinessSummary struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Ready     bool   `json:"ready"`
+	// Stale is true when the status doesn't reflect the latest generation of the ScyllaDBDatacenter yet.
+	Stale      bool                   `json:"stale"`
+	Nodes      int32                  `json:"nodes"`
+	ReadyNodes int32                  `json:"readyNodes"`
+	Racks      []RackReadinessSummary `json:"racks"`
+}
+
+func derefInt32(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
+
+// makeReadinessSummary summarizes the readiness of the ScyllaDBDatacenter from its already computed status.
+func makeReadinessSummary(sdc *scyllav1alpha1.ScyllaDBDatacenter) *ReadinessSummary {
+	summary := &ReadinessSummary{
+		Namespace:  sdc.Namespace,
+		Name:       sdc.Name,
+		Stale:      sdc.Status.ObservedGeneration == nil || *sdc.Status.ObservedGeneration < sdc.Generation,
+		Nodes:      derefInt32(sdc.Status.Nodes),
+		ReadyNodes: derefInt32(sdc.Status.ReadyNodes),
+		Racks:      make([]RackReadinessSummary, 0, len(sdc.Status.Racks)),
+	}
+
+	for _, rs := range sdc.Status.Racks {
+		nodes, readyNodes := derefInt32(rs.Nodes), derefInt32(rs.ReadyNodes)
+		summary.Racks = append(summary.Racks, RackReadinessSummary{
+			Name:       rs.Name,
+			Ready:      readyNodes == nodes,
+			Nodes:      nodes,
+			ReadyNodes: readyNodes,
+		})
+	}
+
+	summary.Ready = !summary.Stale && isFullyAvailable(&sdc.Status)
+
+	return summary
+}
+
+// ServeReadinessSummary responds with a JSON summary of the readiness of all members of the ScyllaDBDatacenter
+// identified by the "namespace" and "name" query parameters. The status code is 200 when all members are ready
+// and 503 otherwise.
+func (sdcc *Controller) ServeReadinessSummary(w http.ResponseWriter, req *http.Request) {
+	namespace, name := req.URL.Query().Get("namespace"), req.URL.Query().Get("name")
+	if len(namespace) == 0 || len(name) == 0 {
+		http.Error(w, "namespace and name query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	sdc, err := sdcc.scyllaDBDatacenterLister.ScyllaDBDatacenters(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("ScyllaDBDatacenter %q not found", naming.ManualRef(namespace, name)), http.StatusNotFound)
+			return
+		}
+
+		klog.ErrorS(err, "can't get ScyllaDBDatacenter", "ScyllaDBDatacenter", naming.ManualRef(namespace, name))
+		http.Error(w, fmt.Sprintf("can't get ScyllaDBDatacenter: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	summary := makeReadinessSummary(sdc)
+
+	statusCode := http.StatusOK
+	if !summary.Ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	err = json.NewEncoder(w).Encode(summary)
+	if err != nil {
+		klog.ErrorS(err, "can't write readiness summary", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+	}
+}
@@ -0,0 +1,172 @@
+package scylladbdatacenter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	scyllav1alpha1listers "github.com/scylladb/scylla-operator/pkg/client/scylla/listers/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestController_ServeReadinessSummary(t *testing.T) {
+	t.Parallel()
+
+	newScyllaDBDatacenter := func() *scyllav1alpha1.ScyllaDBDatacenter {
+		return &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "basic",
+				Namespace:  "default",
+				Generation: 2,
+			},
+			Status: scyllav1alpha1.ScyllaDBDatacenterStatus{
+				ObservedGeneration: pointer.Ptr(int64(2)),
+				Nodes:              pointer.Ptr(int32(3)),
+				ReadyNodes:         pointer.Ptr(int32(3)),
+				Racks: []scyllav1alpha1.RackStatus{
+					{
+						Name:       "a",
+						Nodes:      pointer.Ptr(int32(2)),
+						ReadyNodes: pointer.Ptr(int32(2)),
+					},
+					{
+						Name:       "b",
+						Nodes:      pointer.Ptr(int32(1)),
+						ReadyNodes: pointer.Ptr(int32(1)),
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name               string
+		sdc                *scyllav1alpha1.ScyllaDBDatacenter
+		query              string
+		expectedStatusCode int
+		expectedSummary    *ReadinessSummary
+	}{
+		{
+			name:               "missing query parameters",
+			sdc:                newScyllaDBDatacenter(),
+			query:              "namespace=default",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedSummary:    nil,
+		},
+		{
+			name:               "missing ScyllaDBDatacenter",
+			sdc:                newScyllaDBDatacenter(),
+			query:              "namespace=default&name=other",
+			expectedStatusCode: http.StatusNotFound,
+			expectedSummary:    nil,
+		},
+		{
+			name:               "all members are ready",
+			sdc:                newScyllaDBDatacenter(),
+			query:              "namespace=default&name=basic",
+			expectedStatusCode: http.StatusOK,
+			expectedSummary: &ReadinessSummary{
+				Namespace:  "default",
+				Name:       "basic",
+				Ready:      true,
+				Stale:      false,
+				Nodes:      3,
+				ReadyNodes: 3,
+				Racks: []RackReadinessSummary{
+					{Name: "a", Ready: true, Nodes: 2, ReadyNodes: 2},
+					{Name: "b", Ready: true, Nodes: 1, ReadyNodes: 1},
+				},
+			},
+		},
+		{
+			name: "rack member isn't ready",
+			sdc: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newScyllaDBDatacenter()
+				sdc.Status.ReadyNodes = pointer.Ptr(int32(2))
+				sdc.Status.Racks[1].ReadyNodes = pointer.Ptr(int32(0))
+				return sdc
+			}(),
+			query:              "namespace=default&name=basic",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedSummary: &ReadinessSummary{
+				Namespace:  "default",
+				Name:       "basic",
+				Ready:      false,
+				Stale:      false,
+				Nodes:      3,
+				ReadyNodes: 2,
+				Racks: []RackReadinessSummary{
+					{Name: "a", Ready: true, Nodes: 2, ReadyNodes: 2},
+					{Name: "b", Ready: false, Nodes: 1, ReadyNodes: 0},
+				},
+			},
+		},
+		{
+			name: "stale status isn't ready",
+			sdc: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := newScyllaDBDatacenter()
+				sdc.Generation = 3
+				return sdc
+			}(),
+			query:              "namespace=default&name=basic",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedSummary: &ReadinessSummary{
+				Namespace:  "default",
+				Name:       "basic",
+				Ready:      false,
+				Stale:      true,
+				Nodes:      3,
+				ReadyNodes: 3,
+				Racks: []RackReadinessSummary{
+					{Name: "a", Ready: true, Nodes: 2, ReadyNodes: 2},
+					{Name: "b", Ready: true, Nodes: 1, ReadyNodes: 1},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			err := indexer.Add(tc.sdc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sdcc := &Controller{
+				scyllaDBDatacenterLister: scyllav1alpha1listers.NewScyllaDBDatacenterLister(indexer),
+			}
+
+			req := httptest.NewRequest(http.MethodGet, naming.ScyllaDBDatacenterReadinessSummaryPath+"?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			sdcc.ServeReadinessSummary(w, req)
+
+			if w.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedSummary == nil {
+				return
+			}
+
+			got := &ReadinessSummary{}
+			err = json.Unmarshal(w.Body.Bytes(), got)
+			if err != nil {
+				t.Fatalf("can't decode response body %q: %v", w.Body.String(), err)
+			}
+
+			if !apiequality.Semantic.DeepEqual(got, tc.expectedSummary) {
+				t.Errorf("expected and got summaries differ: %s", cmp.Diff(tc.expectedSummary, got))
+			}
+		})
+	}
+}
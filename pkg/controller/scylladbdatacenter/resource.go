@@ -151,6 +151,16 @@ func MemberService(sdc *scyllav1alpha1.ScyllaDBDatacenter, rackName, name string
 		}
 	}
 
+	var expectedNodes int32
+	for _, rack := range sdc.Spec.Racks {
+		rackNodes, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
+		if err != nil {
+			return nil, fmt.Errorf("can't get rack %q node count: %w", rack.Name, err)
+		}
+		expectedNodes += *rackNodes
+	}
+	svcAnnotations[naming.ExpectedNodesAnnotation] = strconv.Itoa(int(expectedNodes))
+
 	servicePorts, err := getServicePorts(sdc)
 	if err != nil {
 		return nil, fmt.Errorf("can't get service ports: %w", err)
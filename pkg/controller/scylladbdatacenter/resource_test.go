@@ -76,7 +76,8 @@ func TestMemberService(t *testing.T) {
 	}
 	basicSVCAnnotations := func() map[string]string {
 		return map[string]string{
-			"default-sc-annotation": "bar",
+			"default-sc-annotation":                                "bar",
+			"internal.scylla-operator.scylladb.com/expected-nodes": "0",
 		}
 	}
 	basicPorts := []corev1.ServicePort{
@@ -283,6 +284,49 @@ func TestMemberService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "expected node count is summed over racks",
+			scyllaDBDatacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
+				sdc := basicSC.DeepCopy()
+				sdc.Spec.RackTemplate = &scyllav1alpha1.RackTemplate{
+					Nodes: pointer.Ptr(int32(3)),
+				}
+				sdc.Spec.Racks = []scyllav1alpha1.RackSpec{
+					{
+						Name: "rack",
+					},
+					{
+						Name: "other-rack",
+						RackTemplate: scyllav1alpha1.RackTemplate{
+							Nodes: pointer.Ptr(int32(2)),
+						},
+					},
+				}
+				return sdc
+			}(),
+			rackName:   basicRackName,
+			svcName:    basicSVCName,
+			oldService: nil,
+			jobs:       nil,
+			expectedService: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   basicSVCName,
+					Labels: basicSVCLabels(),
+					Annotations: func() map[string]string {
+						res := basicSVCAnnotations()
+						res["internal.scylla-operator.scylladb.com/expected-nodes"] = "5"
+						return res
+					}(),
+					OwnerReferences: basicSCOwnerRefs,
+				},
+				Spec: corev1.ServiceSpec{
+					Type:                     corev1.ServiceTypeClusterIP,
+					Selector:                 basicSVCSelector,
+					PublishNotReadyAddresses: true,
+					Ports:                    basicPorts,
+				},
+			},
+		},
 		{
 			name: "Service properties are taken from ExposeOptions.NodeService",
 			scyllaDBDatacenter: func() *scyllav1alpha1.ScyllaDBDatacenter {
@@ -326,6 +370,7 @@ func TestMemberService(t *testing.T) {
 					},
 					Annotations: map[string]string{
 						"foo": "bar",
+						"internal.scylla-operator.scylladb.com/expected-nodes": "0",
 					},
 					OwnerReferences: basicSCOwnerRefs,
 				},
@@ -1,24 +1,121 @@
 package scylladbdatacenter
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/blang/semver"
 	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
 	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
 	"github.com/scylladb/scylla-operator/pkg/internalapi"
 	"github.com/scylladb/scylla-operator/pkg/naming"
 	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	"github.com/scylladb/scylla-operator/pkg/util/parallel"
+	"github.com/scylladb/scylla-operator/pkg/version"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 )
 
+const (
+	scyllaDBEnterpriseEdition = "Enterprise"
+	scyllaDBOpenSourceEdition = "OSS"
+
+	scyllaDBRepositoryName           = "scylla"
+	scyllaDBEnterpriseRepositoryName = "scylla-enterprise"
+
+	// scyllaDBEnterpriseMinimalMajorVersion is the lowest major version used by ScyllaDB Enterprise.
+	scyllaDBEnterpriseMinimalMajorVersion = 2000
+)
+
+const (
+	// lastReconcileTimeRefreshInterval is the age after which the last reconcile time is refreshed
+	// even when nothing else in the status changed.
+	lastReconcileTimeRefreshInterval = 5 * time.Minute
+
+	// maxStatusUpdateSuppression is the longest window for which status updates can be suppressed.
+	maxStatusUpdateSuppression = time.Hour
+)
+
+// getStatusUpdateSuppressionEnd returns the time until which status updates are suppressed, capped
+// at maxStatusUpdateSuppression from now. It returns false when status updates aren't suppressed.
+func getStatusUpdateSuppressionEnd(sdc *scyllav1alpha1.ScyllaDBDatacenter, now time.Time) (time.Time, bool) {
+	v, ok := sdc.Annotations[naming.SuppressStatusUpdatesUntilAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	end, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		klog.ErrorS(err, "can't parse status update suppression end, status updates aren't suppressed", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Annotation", naming.SuppressStatusUpdatesUntilAnnotation, "Value", v)
+		return time.Time{}, false
+	}
+
+	if !end.After(now) {
+		return time.Time{}, false
+	}
+
+	maxEnd := now.Add(maxStatusUpdateSuppression)
+	if end.After(maxEnd) {
+		end = maxEnd
+	}
+
+	return end, true
+}
+
+// makeSuppressedStatus returns the status to write while status updates are suppressed. Only the observed generation
+// is carried over, so that observers can still tell the spec was seen. It returns nil when nothing needs to be written.
+func makeSuppressedStatus(currentStatus, status *scyllav1alpha1.ScyllaDBDatacenterStatus) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+	if apiequality.Semantic.DeepEqual(currentStatus.ObservedGeneration, status.ObservedGeneration) {
+		return nil
+	}
+
+	suppressedStatus := currentStatus.DeepCopy()
+	suppressedStatus.ObservedGeneration = status.ObservedGeneration
+
+	return suppressedStatus
+}
+
+// isStatusUpToDate returns true if the status doesn't need to be updated.
+// Differences in the last reconcile time alone don't require an update until it gets older than lastReconcileTimeRefreshInterval.
+func isStatusUpToDate(currentStatus, status *scyllav1alpha1.ScyllaDBDatacenterStatus, now time.Time) bool {
+	if currentStatus.LastReconcileTime == nil || now.Sub(currentStatus.LastReconcileTime.Time) >= lastReconcileTimeRefreshInterval {
+		return false
+	}
+
+	currentStatusCopy := currentStatus.DeepCopy()
+	currentStatusCopy.LastReconcileTime = nil
+	statusCopy := status.DeepCopy()
+	statusCopy.LastReconcileTime = nil
+
+	return apiequality.Semantic.DeepEqual(currentStatusCopy, statusCopy)
+}
+
 func (sdcc *Controller) updateStatus(ctx context.Context, currentSC *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) error {
-	if apiequality.Semantic.DeepEqual(&currentSC.Status, status) {
+	now := metav1.Now()
+
+	suppressionEnd, suppressed := getStatusUpdateSuppressionEnd(currentSC, now.Time)
+	if suppressed {
+		return sdcc.updateSuppressedStatus(ctx, currentSC, status, suppressionEnd.Sub(now.Time))
+	}
+
+	if isStatusUpToDate(&currentSC.Status, status, now.Time) {
 		return nil
 	}
 
@@ -27,6 +124,8 @@ func (sdcc *Controller) updateStatus(ctx context.Context, currentSC *scyllav1alp
 
 	// Make sure that any "live" updates to the status are always manifested in the aggregated fields.
 	updateAggregatedStatusFields(&sdc.Status)
+	updateLastFullyAvailableTime(&currentSC.Status, &sdc.Status, now)
+	sdc.Status.LastReconcileTime = &now
 
 	klog.V(2).InfoS("Updating status", "ScyllaDBDatacenter", klog.KObj(sdc))
 
@@ -37,9 +136,240 @@ func (sdcc *Controller) updateStatus(ctx context.Context, currentSC *scyllav1alp
 
 	klog.V(2).InfoS("Status updated", "ScyllaDBDatacenter", klog.KObj(sdc))
 
+	sdcc.statusHistory.record(types.NamespacedName{Namespace: sdc.Namespace, Name: sdc.Name}, StatusSnapshot{
+		Time:   now,
+		Status: sdc.Status,
+	})
+	sdcc.emitRackStatusChangeEvents(sdc, &currentSC.Status, &sdc.Status)
+
+	return nil
+}
+
+// updateSuppressedStatus writes only the observed generation while status updates are suppressed,
+// and makes sure the full status is written once the suppression ends.
+func (sdcc *Controller) updateSuppressedStatus(ctx context.Context, currentSC *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, remaining time.Duration) error {
+	key, err := keyFunc(currentSC)
+	if err != nil {
+		return fmt.Errorf("can't get key: %w", err)
+	}
+	sdcc.queue.AddAfter(key, remaining)
+
+	suppressedStatus := makeSuppressedStatus(&currentSC.Status, status)
+	if suppressedStatus == nil {
+		klog.V(4).InfoS("Status updates are suppressed", "ScyllaDBDatacenter", klog.KObj(currentSC), "Remaining", remaining)
+		return nil
+	}
+
+	sdc := currentSC.DeepCopy()
+	sdc.Status = *suppressedStatus
+
+	klog.V(2).InfoS("Updating observed generation while status updates are suppressed", "ScyllaDBDatacenter", klog.KObj(sdc), "Remaining", remaining)
+
+	_, err = sdcc.scyllaClient.ScyllaDBDatacenters(sdc.Namespace).UpdateStatus(ctx, sdc, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// emitRackStatusChangeEvents emits an event for every rack of which the status meaningfully changed.
+func (sdcc *Controller) emitRackStatusChangeEvents(sdc *scyllav1alpha1.ScyllaDBDatacenter, oldStatus, newStatus *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	findRackStatus := func(racks []scyllav1alpha1.RackStatus, name string) *scyllav1alpha1.RackStatus {
+		idx := slices.IndexFunc(racks, func(rs scyllav1alpha1.RackStatus) bool {
+			return rs.Name == name
+		})
+		if idx < 0 {
+			return nil
+		}
+
+		return &racks[idx]
+	}
+
+	var diffs []*controllerhelpers.RackStatusDiff
+	for i := range newStatus.Racks {
+		diffs = append(diffs, controllerhelpers.DiffRackStatus(findRackStatus(oldStatus.Racks, newStatus.Racks[i].Name), &newStatus.Racks[i]))
+	}
+	for i := range oldStatus.Racks {
+		if findRackStatus(newStatus.Racks, oldStatus.Racks[i].Name) == nil {
+			diffs = append(diffs, controllerhelpers.DiffRackStatus(&oldStatus.Racks[i], nil))
+		}
+	}
+
+	for _, diff := range diffs {
+		if diff.IsEmpty() {
+			continue
+		}
+
+		sdcc.eventRecorder.Event(sdc, corev1.EventTypeNormal, "RackStatusChanged", diff.String())
+	}
+}
+
+// getStatefulSetMembers returns the existing Pods of the StatefulSet that are controlled by it.
+func (sdcc *Controller) getStatefulSetMembers(sts *appsv1.StatefulSet) []*corev1.Pod {
+	var pods []*corev1.Pod
+	for ord := int32(0); ord < *sts.Spec.Replicas; ord++ {
+		podName := fmt.Sprintf("%s-%d", sts.Name, ord)
+		pod, err := sdcc.podLister.Pods(sts.Namespace).Get(podName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "can't get Pod", "Pod", naming.ManualRef(sts.Namespace, podName))
+			}
+			continue
+		}
+
+		controllerRef := metav1.GetControllerOfNoCopy(pod)
+		if controllerRef == nil || controllerRef.UID != sts.UID {
+			continue
+		}
+
+		pods = append(pods, pod)
+	}
+
+	return pods
+}
+
+// countIgnitionPendingMembers counts the members of which the ignition container isn't ready.
+func countIgnitionPendingMembers(pods []*corev1.Pod) int32 {
+	count := int32(0)
+	for _, pod := range pods {
+		if !controllerhelpers.IsScyllaDBIgnitionContainerReady(pod) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// getGenerationStartTime returns the time when the generation was first observed, carrying it over
+// from the previous status while the generation doesn't change.
+func getGenerationStartTime(oldStatus *scyllav1alpha1.ScyllaDBDatacenterStatus, generation int64, now metav1.Time) *metav1.Time {
+	if oldStatus.ObservedGeneration != nil && *oldStatus.ObservedGeneration == generation && oldStatus.GenerationStartTime != nil {
+		return oldStatus.GenerationStartTime.DeepCopy()
+	}
+
+	// Serialized times only have a second precision.
+	return pointer.Ptr(now.Rfc3339Copy())
+}
+
+// countRestartedMembers counts the members whose ScyllaDB container started after the time.
+func countRestartedMembers(pods []*corev1.Pod, since time.Time) int32 {
+	count := int32(0)
+	for _, startTime := range getScyllaDBContainerStartTimes(pods) {
+		if startTime.After(since) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// setRestartedNodes reports the number of members of each rack that were restarted since the current generation
+// was first observed.
+func (sdcc *Controller) setRestartedNodes(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, statefulSetMap map[string]*appsv1.StatefulSet) {
+	for i, rack := range sdc.Spec.Racks {
+		sts, ok := statefulSetMap[naming.StatefulSetNameForRack(rack, sdc)]
+		if !ok || status.GenerationStartTime == nil {
+			continue
+		}
+
+		status.Racks[i].RestartedNodes = pointer.Ptr(countRestartedMembers(sdcc.getStatefulSetMembers(sts), status.GenerationStartTime.Time))
+	}
+}
+
+// getScalingTargetNodes returns the number of nodes of the next scaling step of a rack,
+// or nil if the rack already has the desired number of nodes.
+// It mirrors syncStatefulSets, which scales racks up at once and down by one node at a time.
+func getScalingTargetNodes(desiredNodes, currentNodes int32) *int32 {
+	switch {
+	case desiredNodes > currentNodes:
+		return pointer.Ptr(desiredNodes)
+
+	case desiredNodes < currentNodes:
+		return pointer.Ptr(currentNodes - 1)
+
+	default:
+		return nil
+	}
+}
+
+// getScyllaDBContainerStartTimes returns the times when the running ScyllaDB containers of the members last started.
+func getScyllaDBContainerStartTimes(pods []*corev1.Pod) []time.Time {
+	var startTimes []time.Time
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != naming.ScyllaContainerName || cs.State.Running == nil {
+				continue
+			}
+
+			startTimes = append(startTimes, cs.State.Running.StartedAt.Time)
+		}
+	}
+
+	return startTimes
+}
+
+// getStartTimeRange returns the earliest and the latest of the start times, or nils when there are none.
+func getStartTimeRange(startTimes []time.Time) (*metav1.Time, *metav1.Time) {
+	if len(startTimes) == 0 {
+		return nil, nil
+	}
+
+	earliest := slices.MinFunc(startTimes, time.Time.Compare)
+	latest := slices.MaxFunc(startTimes, time.Time.Compare)
+
+	return pointer.Ptr(metav1.NewTime(earliest)), pointer.Ptr(metav1.NewTime(latest))
+}
+
+// getStuckTerminatingMembers returns the names of the members that are still terminating after their grace period
+// has passed. The deletion timestamp of a Pod already accounts for its grace period.
+func getStuckTerminatingMembers(pods []*corev1.Pod, now time.Time) []string {
+	var stuckPods []string
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil {
+			continue
+		}
+
+		if now.After(pod.DeletionTimestamp.Time) {
+			stuckPods = append(stuckPods, pod.Name)
+		}
+	}
+
+	return stuckPods
+}
+
+// countMemberInstanceTypes maps the instance types of the nodes hosting the members to the number of members.
+// Members that aren't scheduled or are on nodes without the instance type label are omitted.
+func (sdcc *Controller) countMemberInstanceTypes(pods []*corev1.Pod) map[string]int32 {
+	instanceTypes := map[string]int32{}
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) == 0 {
+			continue
+		}
+
+		node, err := sdcc.nodeLister.Get(pod.Spec.NodeName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "can't get Node", "Node", pod.Spec.NodeName)
+			}
+			continue
+		}
+
+		instanceType, ok := node.Labels[corev1.LabelInstanceTypeStable]
+		if !ok || len(instanceType) == 0 {
+			continue
+		}
+
+		instanceTypes[instanceType]++
+	}
+
+	if len(instanceTypes) == 0 {
+		return nil
+	}
+
+	return instanceTypes
+}
+
 func (sdcc *Controller) getScyllaVersion(sts *appsv1.StatefulSet) (string, error) {
 	firstMemberName := fmt.Sprintf("%s-0", sts.Name)
 	firstMember, err := sdcc.podLister.Pods(sts.Namespace).Get(firstMemberName)
@@ -64,12 +394,14 @@ func (sdcc *Controller) getScyllaVersion(sts *appsv1.StatefulSet) (string, error
 // sts and old status may be nil.
 func (sdcc *Controller) calculateRackStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, sts *appsv1.StatefulSet) *scyllav1alpha1.RackStatus {
 	status := &scyllav1alpha1.RackStatus{
-		Nodes:          pointer.Ptr(int32(0)),
-		CurrentNodes:   pointer.Ptr(int32(0)),
-		UpdatedNodes:   pointer.Ptr(int32(0)),
-		ReadyNodes:     pointer.Ptr(int32(0)),
-		AvailableNodes: pointer.Ptr(int32(0)),
-		Stale:          pointer.Ptr(true),
+		Nodes:                 pointer.Ptr(int32(0)),
+		CurrentNodes:          pointer.Ptr(int32(0)),
+		UpdatedNodes:          pointer.Ptr(int32(0)),
+		ReadyNodes:            pointer.Ptr(int32(0)),
+		AvailableNodes:        pointer.Ptr(int32(0)),
+		IgnitionPendingNodes:  pointer.Ptr(int32(0)),
+		StuckTerminatingNodes: pointer.Ptr(int32(0)),
+		Stale:                 pointer.Ptr(true),
 	}
 
 	if sts == nil {
@@ -83,6 +415,28 @@ func (sdcc *Controller) calculateRackStatus(sdc *scyllav1alpha1.ScyllaDBDatacent
 	status.UpdatedNodes = pointer.Ptr(sts.Status.UpdatedReplicas)
 	status.CurrentNodes = pointer.Ptr(sts.Status.CurrentReplicas)
 	status.Stale = pointer.Ptr(sts.Status.ObservedGeneration < sts.Generation)
+	status.StaleSince = getRackStaleSince(&sdc.Status, status.Name, *status.Stale, metav1.Now())
+	status.AppliedSpecHash = sts.Annotations[naming.ManagedHash]
+
+	rackIdx := slices.IndexFunc(sdc.Spec.Racks, func(rack scyllav1alpha1.RackSpec) bool {
+		return rack.Name == status.Name
+	})
+	if rackIdx >= 0 {
+		status.PlacementUpToDate = pointer.Ptr(isRackPlacementUpToDate(sdc, sdc.Spec.Racks[rackIdx], sts))
+	}
+
+	desiredNodes, err := controllerhelpers.GetRackNodeCount(sdc, status.Name)
+	if err != nil {
+		klog.ErrorS(err, "can't get rack node count", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", status.Name)
+	} else {
+		status.ScalingTargetNodes = getScalingTargetNodes(*desiredNodes, *sts.Spec.Replicas)
+	}
+
+	members := sdcc.getStatefulSetMembers(sts)
+	status.IgnitionPendingNodes = pointer.Ptr(countIgnitionPendingMembers(members))
+	status.StuckTerminatingNodes = pointer.Ptr(int32(len(getStuckTerminatingMembers(members, time.Now()))))
+	status.InstanceTypes = sdcc.countMemberInstanceTypes(members)
+	status.EarliestNodeStartTime, status.LatestNodeStartTime = getStartTimeRange(getScyllaDBContainerStartTimes(members))
 
 	scyllaDBImageVersion, err := naming.ImageToVersion(sdc.Spec.ScyllaDB.Image)
 	if err != nil {
@@ -106,26 +460,126 @@ func (sdcc *Controller) calculateRackStatus(sdc *scyllav1alpha1.ScyllaDBDatacent
 	return status
 }
 
+// getRackStaleSince returns the time since when the rack status has been continuously stale,
+// carrying it over from the previous status while the rack stays stale.
+func getRackStaleSince(oldStatus *scyllav1alpha1.ScyllaDBDatacenterStatus, rackName string, stale bool, now metav1.Time) *metav1.Time {
+	if !stale {
+		return nil
+	}
+
+	idx := slices.IndexFunc(oldStatus.Racks, func(rs scyllav1alpha1.RackStatus) bool {
+		return rs.Name == rackName
+	})
+	if idx >= 0 && oldStatus.Racks[idx].StaleSince != nil {
+		return oldStatus.Racks[idx].StaleSince.DeepCopy()
+	}
+
+	// Serialized times only have a second precision.
+	return pointer.Ptr(now.Rfc3339Copy())
+}
+
 func updateAggregatedStatusFields(status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
 	status.Nodes = pointer.Ptr(int32(0))
 	status.ReadyNodes = pointer.Ptr(int32(0))
 	status.AvailableNodes = pointer.Ptr(int32(0))
+	status.IgnitionPendingNodes = pointer.Ptr(int32(0))
 
+	var updatedNodes int32
 	for rackName := range status.Racks {
 		rackStatus := status.Racks[rackName]
 
 		*status.Nodes += *rackStatus.Nodes
 		*status.ReadyNodes += *rackStatus.ReadyNodes
 		*status.AvailableNodes += *rackStatus.AvailableNodes
+		if rackStatus.IgnitionPendingNodes != nil {
+			*status.IgnitionPendingNodes += *rackStatus.IgnitionPendingNodes
+		}
+		if rackStatus.UpdatedNodes != nil {
+			updatedNodes += min(*rackStatus.UpdatedNodes, *rackStatus.Nodes)
+		}
+	}
+
+	status.UpdatedPercent = pointer.Ptr(getUpdatedPercent(updatedNodes, *status.Nodes))
+}
+
+// setRacksAvailableStatusCondition reflects whether all nodes of every rack are available and none
+// of the rack statuses are stale.
+func setRacksAvailableStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	var messages []string
+	for _, rs := range status.Racks {
+		switch {
+		case rs.Stale == nil || *rs.Stale:
+			messages = append(messages, fmt.Sprintf("Rack %q status is stale.", rs.Name))
+
+		case *rs.AvailableNodes != *rs.Nodes:
+			messages = append(messages, fmt.Sprintf("Rack %q has %d out of %d nodes available.", rs.Name, *rs.AvailableNodes, *rs.Nodes))
+		}
+	}
+
+	if len(messages) != 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.RacksAvailableCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NodesUnavailable",
+			Message:            strings.Join(messages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.RacksAvailableCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setReadyStatus mirrors the aggregated Available condition into the ready field.
+func setReadyStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	status.Ready = pointer.Ptr(apimeta.IsStatusConditionTrue(status.Conditions, scyllav1alpha1.AvailableCondition))
+}
+
+// getUpdatedPercent returns the percentage of updated nodes out of the requested ones, rounded down.
+// No nodes being requested means there is nothing left to update.
+func getUpdatedPercent(updatedNodes, nodes int32) int32 {
+	if nodes <= 0 {
+		return 100
+	}
+
+	return int32(int64(min(updatedNodes, nodes)) * 100 / int64(nodes))
+}
+
+func isFullyAvailable(status *scyllav1alpha1.ScyllaDBDatacenterStatus) bool {
+	return status.Nodes != nil && status.ReadyNodes != nil && *status.Nodes > 0 && *status.ReadyNodes == *status.Nodes
+}
+
+// updateLastFullyAvailableTime records the time of transitions into and out of full availability.
+// The time is kept while the availability doesn't change, so it doesn't cause status updates on every reconcile.
+func updateLastFullyAvailableTime(oldStatus, status *scyllav1alpha1.ScyllaDBDatacenterStatus, now metav1.Time) {
+	wasFullyAvailable := isFullyAvailable(oldStatus)
+	fullyAvailable := isFullyAvailable(status)
+
+	switch {
+	case fullyAvailable != wasFullyAvailable:
+		status.LastFullyAvailableTime = &now
+
+	case fullyAvailable && status.LastFullyAvailableTime == nil:
+		// It hasn't been recorded before, e.g. when the status was written by an older version.
+		status.LastFullyAvailableTime = &now
 	}
 }
 
 // calculateStatus calculates the ScyllaCluster status.
 // This function should always succeed. Do not return an error.
 // If a particular object can be missing, it should be reflected in the value itself, like "Unknown" or "".
-func (sdcc *Controller) calculateStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, statefulSetMap map[string]*appsv1.StatefulSet) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+func (sdcc *Controller) calculateStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, statefulSetMap map[string]*appsv1.StatefulSet, serviceMap map[string]*corev1.Service) *scyllav1alpha1.ScyllaDBDatacenterStatus {
 	status := sdc.Status.DeepCopy()
+	status.GenerationStartTime = getGenerationStartTime(&sdc.Status, sdc.Generation, metav1.Now())
 	status.ObservedGeneration = pointer.Ptr(sdc.Generation)
+	// The version only changes with the operator, so it doesn't cause status updates of its own otherwise.
+	status.ManagedByOperatorVersion = version.Get().GitVersion
 
 	// Clear the previous rack status.
 	status.Racks = []scyllav1alpha1.RackStatus{}
@@ -137,57 +591,2485 @@ func (sdcc *Controller) calculateStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter,
 	}
 
 	updateAggregatedStatusFields(status)
+	sdcc.setRestartedNodes(sdc, status, statefulSetMap)
+
+	setNoRacksDefinedStatusCondition(sdc, status)
+	setRacksAvailableStatusCondition(sdc, status)
+	setQuarantinedStatusCondition(sdc, status)
+	sdcc.setImagePullFailingStatusCondition(sdc, status, statefulSetMap)
+	sdcc.setStuckTerminatingStatusCondition(sdc, status, statefulSetMap, time.Now())
+	setStorageClassMismatchStatusCondition(sdc, status, statefulSetMap)
+	setEditionMismatchStatusCondition(sdc, status, statefulSetMap)
+	setPlacementDriftedStatusCondition(sdc, status)
+	setRackUpdateStalledStatusCondition(sdc, status, statefulSetMap)
+	sdcc.setDowngradeDetectedStatusCondition(sdc, status)
+	setMemberServicesReadyStatusCondition(sdc, status, serviceMap)
+	setServiceIPAllocationFailedStatusCondition(sdc, status, serviceMap)
+	setDuplicateHostIDStatusCondition(sdc, status, serviceMap)
+	sdcc.setAntiAffinityViolated(sdc, status, statefulSetMap)
 
 	return status
 }
 
-func (sdcc *Controller) setPrewarmedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
-	prewarmed := true
+func (sdcc *Controller) setAntiAffinityViolated(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, statefulSetMap map[string]*appsv1.StatefulSet) {
+	rackMembers := map[string][]*corev1.Pod{}
 	for _, rack := range sdc.Spec.Racks {
-		rackNodeCount, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
-		if err != nil {
-			klog.ErrorS(err, "can't get rack node count", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name)
-			prewarmed = false
-			break
+		sts := statefulSetMap[naming.StatefulSetNameForRack(rack, sdc)]
+		if sts == nil {
+			continue
 		}
 
-		for ord := int32(0); ord < *rackNodeCount; ord++ {
-			svcName := naming.MemberServiceName(rack, sdc, int(ord))
-			svc, exists := services[svcName]
-			if !exists {
-				klog.ErrorS(err, "service does not exist", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name, "Service", naming.ManualRef(sdc.Namespace, svcName))
-				prewarmed = false
-				break
-			}
+		rackMembers[rack.Name] = sdcc.getStatefulSetMembers(sts)
+	}
 
-			podName := naming.PodNameFromService(svc)
-			pod, err := sdcc.podLister.Pods(sdc.Namespace).Get(podName)
-			if err != nil {
-				klog.ErrorS(err, "can't get Pod", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name, "Pod", naming.ManualRef(sdc.Namespace, podName))
-				prewarmed = false
-				break
+	setAntiAffinityViolatedStatusCondition(sdc, status, rackMembers)
+}
+
+// setAntiAffinityViolatedStatusCondition reports members of the same rack that are scheduled to the same node.
+// Members that aren't scheduled yet are ignored.
+func setAntiAffinityViolatedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackMembers map[string][]*corev1.Pod) {
+	var collisions []string
+	for _, rack := range sdc.Spec.Racks {
+		nodeMembers := map[string][]string{}
+		for _, pod := range rackMembers[rack.Name] {
+			if len(pod.Spec.NodeName) == 0 {
+				continue
 			}
 
-			if !controllerhelpers.IsScyllaDBIgnitionContainerReady(pod) || !controllerhelpers.IsDelayedVolumeMountContainerRunning(pod) {
-				prewarmed = false
+			nodeMembers[pod.Spec.NodeName] = append(nodeMembers[pod.Spec.NodeName], pod.Name)
+		}
+
+		for _, nodeName := range slices.Sorted(maps.Keys(nodeMembers)) {
+			members := nodeMembers[nodeName]
+			if len(members) < 2 {
+				continue
 			}
+
+			collisions = append(collisions, fmt.Sprintf("members %s of rack %q share node %q", strings.Join(members, ", "), rack.Name, nodeName))
 		}
 	}
 
-	if prewarmed {
+	if len(collisions) > 0 {
 		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
-			Type:               scyllav1alpha1.PrewarmedCondition,
+			Type:               scyllav1alpha1.AntiAffinityViolatedCondition,
 			Status:             metav1.ConditionTrue,
-			Reason:             internalapi.AsExpectedReason,
-			Message:            "",
+			Reason:             "MembersShareNode",
+			Message:            fmt.Sprintf("Several members of a rack are scheduled to the same node, so a failure of the node takes them all down: %s.", strings.Join(collisions, "; ")),
 			ObservedGeneration: sdc.Generation,
 		})
-	} else {
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.AntiAffinityViolatedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setDuplicateHostIDStatusCondition reports members sharing a host ID, as collected in the annotations
+// of their member Services.
+func setDuplicateHostIDStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	hostIDMembers := map[string][]string{}
+	err := forEachExpectedMemberService(sdc, services, func(_ scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+		if svc == nil {
+			return
+		}
+
+		hostID := svc.Annotations[naming.HostIDAnnotation]
+		if len(hostID) == 0 {
+			return
+		}
+
+		hostIDMembers[hostID] = append(hostIDMembers[hostID], svcName)
+	})
+	if err != nil {
 		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
-			Type:               scyllav1alpha1.PrewarmedCondition,
+			Type:               scyllav1alpha1.DuplicateHostIDCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "HostIDsUnknown",
+			Message:            fmt.Sprintf("Can't determine expected member Services: %v.", err),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	var duplicates []string
+	for _, hostID := range slices.Sorted(maps.Keys(hostIDMembers)) {
+		members := hostIDMembers[hostID]
+		if len(members) < 2 {
+			continue
+		}
+
+		duplicates = append(duplicates, fmt.Sprintf("host ID %q is reported by members %s", hostID, strings.Join(members, ", ")))
+	}
+
+	if len(duplicates) > 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.DuplicateHostIDCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "DuplicateHostIDsReported",
+			Message:            fmt.Sprintf("Several members share a host ID, which is usually caused by copied data volumes: %s.", strings.Join(duplicates, "; ")),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.DuplicateHostIDCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setMemberServicesReadyStatusCondition reports whether all member Services expected by the spec exist.
+func setMemberServicesReadyStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	var missingServices []string
+	err := forEachExpectedMemberService(sdc, services, func(_ scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+		if svc == nil {
+			missingServices = append(missingServices, svcName)
+		}
+	})
+	if err != nil {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.MemberServicesReadyCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "MemberServicesUnknown",
+			Message:            fmt.Sprintf("Can't determine expected member Services: %v.", err),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	if len(missingServices) > 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.MemberServicesReadyCondition,
 			Status:             metav1.ConditionFalse,
-			Reason:             "NotAllNodesPrewarmed",
-			Message:            "Not all nodes are prewarmed yet.",
+			Reason:             "MemberServicesMissing",
+			Message:            fmt.Sprintf("Member Service(s) %s don't exist.", strings.Join(missingServices, ", ")),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.MemberServicesReadyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setServiceIPAllocationFailedStatusCondition reports member Services that exist but don't have a cluster IP assigned.
+// Headless member Services intentionally don't have one and missing Services are reported by MemberServicesReady.
+func setServiceIPAllocationFailedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	var unallocatedServices []string
+	err := forEachExpectedMemberService(sdc, services, func(_ scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+		if svc != nil && len(svc.Spec.ClusterIP) == 0 {
+			unallocatedServices = append(unallocatedServices, svcName)
+		}
+	})
+	if err != nil {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ServiceIPAllocationFailedCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "MemberServicesUnknown",
+			Message:            fmt.Sprintf("Can't determine expected member Services: %v.", err),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	if len(unallocatedServices) > 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ServiceIPAllocationFailedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ClusterIPNotAssigned",
+			Message:            fmt.Sprintf("Member Service(s) %s don't have a cluster IP assigned.", strings.Join(unallocatedServices, ", ")),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.ServiceIPAllocationFailedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setNoRacksDefinedStatusCondition surfaces a datacenter without any racks, which would otherwise
+// silently report zero nodes.
+func setNoRacksDefinedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	if len(sdc.Spec.Racks) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.NoRacksDefinedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoRacksDefined",
+			Message:            "ScyllaDBDatacenter doesn't define any racks.",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.NoRacksDefinedCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setQuarantinedStatusCondition reports whether orchestration actions on the datacenter are suspended.
+func setQuarantinedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	if !controllerhelpers.IsScyllaDBDatacenterQuarantined(sdc) {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.QuarantinedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	message := fmt.Sprintf("ScyllaDBDatacenter is quarantined with %q annotation. Orchestration actions are suspended until it is removed.", naming.QuarantineAnnotation)
+	reason := sdc.Annotations[naming.QuarantineAnnotation]
+	if len(reason) != 0 {
+		message = fmt.Sprintf("%s Quarantine reason: %s", message, reason)
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.QuarantinedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "QuarantineAnnotationPresent",
+		Message:            message,
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+func isImagePullFailureReason(reason string) bool {
+	return reason == "ImagePullBackOff" || reason == "ErrImagePull"
+}
+
+// getImagePullFailures maps images that can't be pulled to the Pods that are waiting for them.
+func getImagePullFailures(pods []*corev1.Pod) map[string][]string {
+	failures := map[string][]string{}
+	for _, pod := range pods {
+		var images []string
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, cs := range statuses {
+				if cs.State.Waiting == nil || !isImagePullFailureReason(cs.State.Waiting.Reason) {
+					continue
+				}
+
+				if !slices.Contains(images, cs.Image) {
+					images = append(images, cs.Image)
+				}
+			}
+		}
+
+		for _, image := range images {
+			failures[image] = append(failures[image], naming.ObjRef(pod))
+		}
+	}
+
+	return failures
+}
+
+// formatStorageClassName quotes the storage class name for messages, or names the default storage class when it's unset.
+func formatStorageClassName(storageClassName *string) string {
+	if storageClassName == nil {
+		return "<default>"
+	}
+
+	return fmt.Sprintf("%q", *storageClassName)
+}
+
+// getRackStorageClassMismatch compares the storage class of the data PVC template of the rack's StatefulSet
+// with the desired one. It returns false when they match or when the StatefulSet doesn't have the template.
+func getRackStorageClassMismatch(sdc *scyllav1alpha1.ScyllaDBDatacenter, rack scyllav1alpha1.RackSpec, sts *appsv1.StatefulSet) (*string, *string, bool) {
+	if sdc.Spec.RackTemplate != nil {
+		rack = applyRackTemplateOnRackSpec(sdc.Spec.RackTemplate, rack)
+	}
+
+	var expected *string
+	if rack.ScyllaDB != nil && rack.ScyllaDB.Storage != nil {
+		expected = rack.ScyllaDB.Storage.StorageClassName
+	}
+
+	idx := slices.IndexFunc(sts.Spec.VolumeClaimTemplates, func(pvc corev1.PersistentVolumeClaim) bool {
+		return pvc.Name == naming.PVCTemplateName
+	})
+	if idx < 0 {
+		return nil, nil, false
+	}
+	actual := sts.Spec.VolumeClaimTemplates[idx].Spec.StorageClassName
+
+	if apiequality.Semantic.DeepEqual(expected, actual) {
+		return nil, nil, false
+	}
+
+	return expected, actual, true
+}
+
+func setStorageClassMismatchStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, statefulSetMap map[string]*appsv1.StatefulSet) {
+	var messages []string
+	for _, rack := range sdc.Spec.Racks {
+		sts, ok := statefulSetMap[naming.StatefulSetNameForRack(rack, sdc)]
+		if !ok {
+			continue
+		}
+
+		expected, actual, mismatch := getRackStorageClassMismatch(sdc, rack, sts)
+		if mismatch {
+			messages = append(messages, fmt.Sprintf("Rack %q uses StorageClass %s instead of the desired %s.", rack.Name, formatStorageClassName(actual), formatStorageClassName(expected)))
+		}
+	}
+
+	if len(messages) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.StorageClassMismatchCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.StorageClassMismatchCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StorageClassDiffers",
+		Message:            strings.Join(messages, " ") + " Recreating the PersistentVolumeClaims is required to change it.",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// getImageEdition guesses the ScyllaDB edition of the image, primarily from its repository.
+// Images of other repositories fall back to the version, as ScyllaDB Enterprise uses year-based versions,
+// which are higher than any open source version. The fallback isn't used for the ScyllaDB repository,
+// which ships year-based versions too since the releases of both editions were unified.
+func getImageEdition(image string) (string, error) {
+	repository, imageVersion, err := naming.ImageToRepositoryVersion(image)
+	if err != nil {
+		return "", err
+	}
+
+	switch path.Base(repository) {
+	case scyllaDBEnterpriseRepositoryName:
+		return scyllaDBEnterpriseEdition, nil
+	case scyllaDBRepositoryName:
+		return scyllaDBOpenSourceEdition, nil
+	}
+
+	v, err := semver.ParseTolerant(imageVersion)
+	if err != nil {
+		return "", fmt.Errorf("can't parse version %q: %w", imageVersion, err)
+	}
+
+	if v.Major >= scyllaDBEnterpriseMinimalMajorVersion {
+		return scyllaDBEnterpriseEdition, nil
+	}
+
+	return scyllaDBOpenSourceEdition, nil
+}
+
+// setEditionMismatchStatusCondition reflects whether the racks' StatefulSets run images of different ScyllaDB editions
+// and names the racks of each edition. Racks with images of an unknown edition are ignored.
+func setEditionMismatchStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, statefulSetMap map[string]*appsv1.StatefulSet) {
+	editionRacks := map[string][]string{}
+	for _, rack := range sdc.Spec.Racks {
+		sts, ok := statefulSetMap[naming.StatefulSetNameForRack(rack, sdc)]
+		if !ok {
+			continue
+		}
+
+		idx, err := naming.FindScyllaContainer(sts.Spec.Template.Spec.Containers)
+		if err != nil {
+			continue
+		}
+
+		edition, err := getImageEdition(sts.Spec.Template.Spec.Containers[idx].Image)
+		if err != nil {
+			klog.V(4).InfoS("Can't determine the edition of rack image", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name, "Error", err)
+			continue
+		}
+
+		editionRacks[edition] = append(editionRacks[edition], fmt.Sprintf("%q", rack.Name))
+	}
+
+	if len(editionRacks) <= 1 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.EditionMismatchCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	var editions []string
+	for _, edition := range slices.Sorted(maps.Keys(editionRacks)) {
+		editions = append(editions, fmt.Sprintf("%s in rack(s) %s", edition, strings.Join(editionRacks[edition], ", ")))
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.EditionMismatchCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MixedEditions",
+		Message:            fmt.Sprintf("Racks run different ScyllaDB editions: %s.", strings.Join(editions, "; ")),
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// isRackPlacementUpToDate compares the affinity of the rack's StatefulSet Pod template with the one
+// resulting from the desired rack placement.
+func isRackPlacementUpToDate(sdc *scyllav1alpha1.ScyllaDBDatacenter, rack scyllav1alpha1.RackSpec, sts *appsv1.StatefulSet) bool {
+	if sdc.Spec.RackTemplate != nil {
+		rack = applyRackTemplateOnRackSpec(sdc.Spec.RackTemplate, rack)
+	}
+
+	placement := rack.Placement
+	if placement == nil {
+		placement = &scyllav1alpha1.Placement{}
+	}
+
+	desired := &corev1.Affinity{
+		NodeAffinity:    placement.NodeAffinity,
+		PodAffinity:     placement.PodAffinity,
+		PodAntiAffinity: placement.PodAntiAffinity,
+	}
+
+	actual := sts.Spec.Template.Spec.Affinity
+	if actual == nil {
+		actual = &corev1.Affinity{}
+	}
+
+	return apiequality.Semantic.DeepEqual(desired, actual)
+}
+
+// setPlacementDriftedStatusCondition reflects whether any rack's StatefulSet doesn't have the desired placement applied.
+func setPlacementDriftedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	var driftedRacks []string
+	for _, rs := range status.Racks {
+		if rs.PlacementUpToDate != nil && !*rs.PlacementUpToDate {
+			driftedRacks = append(driftedRacks, fmt.Sprintf("%q", rs.Name))
+		}
+	}
+
+	if len(driftedRacks) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.PlacementDriftedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.PlacementDriftedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AffinityDiffers",
+		Message:            fmt.Sprintf("The Pod template affinity of rack(s) %s doesn't match the desired placement.", strings.Join(driftedRacks, ", ")),
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// rackUpdateState describes the progress of a StatefulSet update.
+type rackUpdateState int
+
+const (
+	rackUpdateNotPending rackUpdateState = iota
+	rackUpdateProgressing
+	rackUpdatePausedByPartition
+	rackUpdateNotProgressing
+)
+
+// getRackUpdateState compares the current and update revisions of the StatefulSet to determine whether an update
+// is pending, and if so, whether the StatefulSet controller can progress it.
+// The StatefulSet controller doesn't update ordinals below the partition, and it doesn't update the next node
+// until all nodes are ready.
+func getRackUpdateState(sts *appsv1.StatefulSet) rackUpdateState {
+	if sts.Status.ObservedGeneration < sts.Generation {
+		// The revisions in status don't reflect the current spec yet.
+		return rackUpdateNotPending
+	}
+
+	if len(sts.Status.UpdateRevision) == 0 || sts.Status.CurrentRevision == sts.Status.UpdateRevision {
+		return rackUpdateNotPending
+	}
+
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	partition := getStatefulSetPartition(sts)
+	if partition > 0 && sts.Status.UpdatedReplicas >= replicas-partition {
+		return rackUpdatePausedByPartition
+	}
+
+	if sts.Status.ReadyReplicas < replicas {
+		return rackUpdateNotProgressing
+	}
+
+	return rackUpdateProgressing
+}
+
+func getStatefulSetPartition(sts *appsv1.StatefulSet) int32 {
+	if sts.Spec.UpdateStrategy.RollingUpdate == nil || sts.Spec.UpdateStrategy.RollingUpdate.Partition == nil {
+		return 0
+	}
+
+	return *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+}
+
+func setRackUpdateStalledStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, statefulSetMap map[string]*appsv1.StatefulSet) {
+	var stalledMessages, progressingMessages []string
+	notProgressing := false
+	for _, rack := range sdc.Spec.Racks {
+		sts, ok := statefulSetMap[naming.StatefulSetNameForRack(rack, sdc)]
+		if !ok {
+			continue
+		}
+
+		replicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			replicas = *sts.Spec.Replicas
+		}
+
+		switch getRackUpdateState(sts) {
+		case rackUpdatePausedByPartition:
+			stalledMessages = append(stalledMessages, fmt.Sprintf(
+				"Rack %q update from revision %q to %q is paused by partition %d with %d of %d node(s) updated.",
+				rack.Name, sts.Status.CurrentRevision, sts.Status.UpdateRevision, getStatefulSetPartition(sts), sts.Status.UpdatedReplicas, replicas,
+			))
+
+		case rackUpdateNotProgressing:
+			notProgressing = true
+			stalledMessages = append(stalledMessages, fmt.Sprintf(
+				"Rack %q update from revision %q to %q is waiting for nodes to become ready with %d of %d node(s) ready and %d updated.",
+				rack.Name, sts.Status.CurrentRevision, sts.Status.UpdateRevision, sts.Status.ReadyReplicas, replicas, sts.Status.UpdatedReplicas,
+			))
+
+		case rackUpdateProgressing:
+			progressingMessages = append(progressingMessages, fmt.Sprintf(
+				"Rack %q is updating from revision %q to %q with %d of %d node(s) updated.",
+				rack.Name, sts.Status.CurrentRevision, sts.Status.UpdateRevision, sts.Status.UpdatedReplicas, replicas,
+			))
+		}
+	}
+
+	if len(stalledMessages) != 0 {
+		reason := "UpdatePausedByPartition"
+		if notProgressing {
+			reason = "UpdateNotProgressing"
+		}
+
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.RackUpdateStalledCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            strings.Join(stalledMessages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	if len(progressingMessages) != 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.RackUpdateStalledCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "UpdateProgressing",
+			Message:            strings.Join(progressingMessages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.RackUpdateStalledCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+func (sdcc *Controller) setStuckTerminatingStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, statefulSetMap map[string]*appsv1.StatefulSet, now time.Time) {
+	var stuckPods []string
+	for _, rack := range sdc.Spec.Racks {
+		sts, ok := statefulSetMap[naming.StatefulSetNameForRack(rack, sdc)]
+		if !ok {
+			continue
+		}
+
+		stuckPods = append(stuckPods, getStuckTerminatingMembers(sdcc.getStatefulSetMembers(sts), now)...)
+	}
+
+	if len(stuckPods) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.StuckTerminatingCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.StuckTerminatingCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "GracePeriodExceeded",
+		Message:            fmt.Sprintf("Pod(s) %s are still terminating after their grace period has passed.", strings.Join(stuckPods, ", ")),
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setImagePullFailingStatusCondition reflects whether any member Pod can't pull its images and names the images
+// together with the affected Pods.
+func (sdcc *Controller) setImagePullFailingStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, statefulSetMap map[string]*appsv1.StatefulSet) {
+	failures := map[string][]string{}
+	for _, rack := range sdc.Spec.Racks {
+		sts, ok := statefulSetMap[naming.StatefulSetNameForRack(rack, sdc)]
+		if !ok {
+			continue
+		}
+
+		for image, pods := range getImagePullFailures(sdcc.getStatefulSetMembers(sts)) {
+			failures[image] = append(failures[image], pods...)
+		}
+	}
+
+	if len(failures) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ImagePullFailingCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	images := slices.Sorted(maps.Keys(failures))
+	messages := make([]string, 0, len(images))
+	for _, image := range images {
+		messages = append(messages, fmt.Sprintf("Image %q can't be pulled for Pod(s) %s.", image, strings.Join(failures[image], ", ")))
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.ImagePullFailingCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ImagePullFailed",
+		Message:            strings.Join(messages, " "),
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setDowngradeDetectedStatusCondition reflects whether any rack is requested to run a ScyllaDB version lower than
+// the one it currently runs. A Warning event is emitted when a downgrade is first detected.
+func (sdcc *Controller) setDowngradeDetectedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	var downgrades []string
+	for _, rackStatus := range status.Racks {
+		if len(rackStatus.CurrentVersion) == 0 || len(rackStatus.UpdatedVersion) == 0 {
+			continue
+		}
+
+		versionCmp, err := naming.CompareVersions(rackStatus.UpdatedVersion, rackStatus.CurrentVersion)
+		if err != nil {
+			klog.V(4).InfoS("Can't compare rack versions", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rackStatus.Name, "Error", err)
+			continue
+		}
+
+		if versionCmp < 0 {
+			downgrades = append(downgrades, fmt.Sprintf("rack %q from %q to %q", rackStatus.Name, rackStatus.CurrentVersion, rackStatus.UpdatedVersion))
+		}
+	}
+
+	if len(downgrades) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.DowngradeDetectedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	message := fmt.Sprintf("ScyllaDB version downgrade isn't supported, but it was requested for %s.", strings.Join(downgrades, ", "))
+	if !apimeta.IsStatusConditionTrue(sdc.Status.Conditions, scyllav1alpha1.DowngradeDetectedCondition) {
+		sdcc.eventRecorder.Event(sdc, corev1.EventTypeWarning, "DowngradeDetected", message)
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.DowngradeDetectedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "VersionDowngradeRequested",
+		Message:            message,
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// getScaledUpRacks describes the racks that have more nodes in the status than in the prior status.
+func getScaledUpRacks(oldStatus, status *scyllav1alpha1.ScyllaDBDatacenterStatus) []string {
+	var scaledUpRacks []string
+	for _, rackStatus := range status.Racks {
+		if rackStatus.Nodes == nil {
+			continue
+		}
+
+		oldRackStatusIdx := slices.IndexFunc(oldStatus.Racks, func(rs scyllav1alpha1.RackStatus) bool {
+			return rs.Name == rackStatus.Name
+		})
+		if oldRackStatusIdx < 0 || oldStatus.Racks[oldRackStatusIdx].Nodes == nil {
+			continue
+		}
+
+		oldNodes := *oldStatus.Racks[oldRackStatusIdx].Nodes
+		if *rackStatus.Nodes > oldNodes {
+			scaledUpRacks = append(scaledUpRacks, fmt.Sprintf("%q from %d to %d", rackStatus.Name, oldNodes, *rackStatus.Nodes))
+		}
+	}
+
+	return scaledUpRacks
+}
+
+// forEachExpectedMemberService calls fn for every member Service expected by the spec, in rack and ordinal order.
+// The svc argument is nil when the Service doesn't exist.
+func forEachExpectedMemberService(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev1.Service, fn func(rack scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service)) error {
+	for _, rack := range sdc.Spec.Racks {
+		rackNodeCount, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
+		if err != nil {
+			return fmt.Errorf("can't get node count of rack %q: %w", rack.Name, err)
+		}
+
+		for ord := int32(0); ord < *rackNodeCount; ord++ {
+			svcName := naming.MemberServiceName(rack, sdc, int(ord))
+			fn(rack, svcName, services[svcName])
+		}
+	}
+
+	return nil
+}
+
+// setPrewarmedStatusCondition reflects whether all nodes are prewarmed. When they aren't, the message says how many
+// nodes are pending prewarm and whether it is caused by racks scaled up since the prior status.
+func (sdcc *Controller) setPrewarmedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	prewarmed := true
+	desiredNodes := int32(0)
+	pendingNodes := int32(0)
+	err := forEachExpectedMemberService(sdc, services, func(rack scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+		desiredNodes++
+
+		if svc == nil {
+			klog.V(2).InfoS("Service does not exist", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name, "Service", naming.ManualRef(sdc.Namespace, svcName))
+			prewarmed = false
+			pendingNodes++
+			return
+		}
+
+		podName := naming.PodNameFromService(svc)
+		pod, err := sdcc.podLister.Pods(sdc.Namespace).Get(podName)
+		if err != nil {
+			klog.ErrorS(err, "can't get Pod", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name, "Pod", naming.ManualRef(sdc.Namespace, podName))
+			prewarmed = false
+			pendingNodes++
+			return
+		}
+
+		if !controllerhelpers.IsScyllaDBPodPrewarmed(pod) {
+			prewarmed = false
+			pendingNodes++
+		}
+	})
+	if err != nil {
+		klog.ErrorS(err, "can't iterate member services", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+		prewarmed = false
+	}
+
+	if prewarmed {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.PrewarmedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+	} else {
+		message := fmt.Sprintf("Not all nodes are prewarmed yet: %d out of %d node(s) are pending prewarm.", pendingNodes, desiredNodes)
+		scaledUpRacks := getScaledUpRacks(&sdc.Status, status)
+		if len(scaledUpRacks) > 0 {
+			message += fmt.Sprintf(" This is due to a scale-up of rack(s) %s.", strings.Join(scaledUpRacks, ", "))
+		}
+
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.PrewarmedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NotAllNodesPrewarmed",
+			Message:            message,
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+const alternatorReadinessCheckTimeout = 2 * time.Second
+
+// setAlternatorReadyNodes counts the nodes of every rack that accept connections on the Alternator port.
+// Racks for which the count can't be determined have their AlternatorReadyNodes left unset.
+func (sdcc *Controller) setAlternatorReadyNodes(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	for i := range status.Racks {
+		status.Racks[i].AlternatorReadyNodes = nil
+	}
+
+	if sdc.Spec.ScyllaDB.AlternatorOptions == nil {
+		return
+	}
+
+	for _, rack := range sdc.Spec.Racks {
+		rackStatusIdx := slices.IndexFunc(status.Racks, func(rs scyllav1alpha1.RackStatus) bool {
+			return rs.Name == rack.Name
+		})
+		if rackStatusIdx < 0 {
+			continue
+		}
+
+		hosts, err := sdcc.getRackScyllaHosts(sdc, rack, services)
+		if err != nil {
+			klog.ErrorS(err, "can't get rack hosts", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name)
+			continue
+		}
+
+		var readyNodes atomic.Int32
+		// Unreachable nodes are expected and reflected in the count, so the dial errors are not propagated.
+		_ = parallel.ForEach(len(hosts), func(i int) error {
+			dialer := net.Dialer{
+				Timeout: alternatorReadinessCheckTimeout,
+			}
+			conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(hosts[i], strconv.Itoa(alternatorTLSPort)))
+			if err != nil {
+				klog.V(4).InfoS("Alternator port is not accepting connections", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name, "Host", hosts[i], "Error", err)
+				return nil
+			}
+			_ = conn.Close()
+
+			readyNodes.Add(1)
+			return nil
+		})
+
+		status.Racks[rackStatusIdx].AlternatorReadyNodes = pointer.Ptr(readyNodes.Load())
+	}
+}
+
+func (sdcc *Controller) getRackScyllaHosts(sdc *scyllav1alpha1.ScyllaDBDatacenter, rack scyllav1alpha1.RackSpec, services map[string]*corev1.Service) ([]string, error) {
+	rackNodeCount, err := controllerhelpers.GetRackNodeCount(sdc, rack.Name)
+	if err != nil {
+		return nil, fmt.Errorf("can't get rack node count: %w", err)
+	}
+
+	hosts := make([]string, 0, *rackNodeCount)
+	for ord := int32(0); ord < *rackNodeCount; ord++ {
+		host, err := sdcc.getMemberScyllaHost(sdc, naming.MemberServiceName(rack, sdc, int(ord)), services)
+		if err != nil {
+			return nil, err
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
+
+func (sdcc *Controller) getMemberScyllaHost(sdc *scyllav1alpha1.ScyllaDBDatacenter, svcName string, services map[string]*corev1.Service) (string, error) {
+	svc, exists := services[svcName]
+	if !exists {
+		return "", fmt.Errorf("service %q does not exist", naming.ManualRef(sdc.Namespace, svcName))
+	}
+
+	podName := naming.PodNameFromService(svc)
+	pod, err := sdcc.podLister.Pods(sdc.Namespace).Get(podName)
+	if err != nil {
+		return "", fmt.Errorf("can't get Pod %q: %w", naming.ManualRef(sdc.Namespace, podName), err)
+	}
+
+	host, err := controllerhelpers.GetScyllaHost(sdc, svc, pod)
+	if err != nil {
+		return "", fmt.Errorf("can't get host of Pod %q: %w", naming.ObjRef(pod), err)
+	}
+
+	return host, nil
+}
+
+// setSchemaVersionStatus reports the schema versions observed on nodes of each rack
+// in the rack status and in the SchemaDisagreement condition.
+func setSchemaVersionStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackSchemaVersions map[string][]string) {
+	allVersions := map[string][]string{}
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.SchemaVersion = ""
+
+		rackVersions := slices.Compact(slices.Sorted(slices.Values(rackSchemaVersions[rackStatus.Name])))
+		for _, v := range rackVersions {
+			allVersions[v] = append(allVersions[v], rackStatus.Name)
+		}
+
+		if len(rackVersions) == 1 {
+			rackStatus.SchemaVersion = rackVersions[0]
+		}
+	}
+
+	switch len(allVersions) {
+	case 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.SchemaDisagreementCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "SchemaVersionUnknown",
+			Message:            "Schema version couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case 1:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.SchemaDisagreementCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		var versionMessages []string
+		for _, v := range slices.Sorted(maps.Keys(allVersions)) {
+			versionMessages = append(versionMessages, fmt.Sprintf("Schema version %q is reported in rack(s) %s.", v, strings.Join(allVersions[v], ", ")))
+		}
+
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.SchemaDisagreementCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "SchemaVersionsDiffer",
+			Message:            strings.Join(versionMessages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// isSchemaMigrating returns true if another schema version is held by more hosts than the node's schema version,
+// which means that the node hasn't applied the latest schema changes yet.
+// When no version is held by a majority of hosts, the node can't be told apart from the others and isn't considered
+// to be migrating.
+func isSchemaMigrating(schemaVersion string, schemaVersions map[string][]string) bool {
+	localHosts := len(schemaVersions[schemaVersion])
+	for v, hosts := range schemaVersions {
+		if v != schemaVersion && len(hosts) > localHosts {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setSchemaMigratingNodesStatus reports the number of nodes still applying schema changes in each rack,
+// out of the nodes that reported it.
+func setSchemaMigratingNodesStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackMigrating map[string][]bool) {
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.SchemaMigratingNodes = nil
+
+		migrating, ok := rackMigrating[rackStatus.Name]
+		if !ok || len(migrating) == 0 {
+			continue
+		}
+
+		var count int32
+		for _, m := range migrating {
+			if m {
+				count++
+			}
+		}
+		rackStatus.SchemaMigratingNodes = pointer.Ptr(count)
+	}
+}
+
+// setMembers reports the states of the individual members of every rack in the status.
+func (sdcc *Controller) setMembers(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	var pods map[string]*corev1.Pod
+	if sdcc.statusOptions.MemberPodDetails {
+		pods = map[string]*corev1.Pod{}
+		err := forEachExpectedMemberService(sdc, services, func(rack scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+			if svc == nil {
+				return
+			}
+
+			podName := naming.PodNameFromService(svc)
+			pod, err := sdcc.podLister.Pods(sdc.Namespace).Get(podName)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					klog.ErrorS(err, "can't get Pod", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name, "Pod", naming.ManualRef(sdc.Namespace, podName))
+				}
+				return
+			}
+
+			pods[svcName] = pod
+		})
+		if err != nil {
+			klog.ErrorS(err, "can't iterate member services", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+		}
+	}
+
+	setMembersStatus(sdc, status, services, pods, sdcc.statusOptions.MemberPodDetails)
+}
+
+// setMembersStatus reports the states of the members of every rack, based on their Services and,
+// with includePodDetails, on their Pods keyed by the member name.
+func setMembersStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service, pods map[string]*corev1.Pod, includePodDetails bool) {
+	rackMembers := map[string][]scyllav1alpha1.MemberStatus{}
+	err := forEachExpectedMemberService(sdc, services, func(rack scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+		member := scyllav1alpha1.MemberStatus{
+			Name: svcName,
+		}
+
+		if svc != nil {
+			member.HostID = svc.Annotations[naming.HostIDAnnotation]
+		}
+
+		pod := pods[svcName]
+		if includePodDetails && pod != nil {
+			member.PodConditions = getMemberPodConditions(pod)
+			member.RestartCount = getScyllaDBContainerRestartCount(pod)
+		}
+
+		rackMembers[rack.Name] = append(rackMembers[rack.Name], member)
+	})
+	if err != nil {
+		klog.ErrorS(err, "can't iterate member services", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+		return
+	}
+
+	for i := range status.Racks {
+		status.Racks[i].Members = rackMembers[status.Racks[i].Name]
+	}
+}
+
+// getMemberPodConditions returns the conditions of the Pod that describe its health as seen by Kubernetes.
+func getMemberPodConditions(pod *corev1.Pod) []corev1.PodCondition {
+	var conditions []corev1.PodCondition
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady || c.Type == corev1.ContainersReady {
+			conditions = append(conditions, c)
+		}
+	}
+
+	return conditions
+}
+
+// getScyllaDBContainerRestartCount returns the restart count of the ScyllaDB container of the Pod,
+// or nil if the container status doesn't exist.
+func getScyllaDBContainerRestartCount(pod *corev1.Pod) *int32 {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == naming.ScyllaContainerName {
+			return pointer.Ptr(cs.RestartCount)
+		}
+	}
+
+	return nil
+}
+
+// hasCompactedUserData returns true if any of the compacted keyspaces isn't a system keyspace.
+// System keyspaces are compacted early on by every node, so they don't indicate that user data was compacted.
+func hasCompactedUserData(compactedKeyspaces []string) bool {
+	for _, ks := range compactedKeyspaces {
+		if !strings.HasPrefix(ks, "system") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setCompactedNodesStatus reports the number of nodes that have completed their first compaction in each rack,
+// out of the nodes that reported it.
+func setCompactedNodesStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackCompacted map[string][]bool) {
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.CompactedNodes = nil
+
+		compacted, ok := rackCompacted[rackStatus.Name]
+		if !ok || len(compacted) == 0 {
+			continue
+		}
+
+		var count int32
+		for _, c := range compacted {
+			if c {
+				count++
+			}
+		}
+		rackStatus.CompactedNodes = pointer.Ptr(count)
+	}
+}
+
+// setShardCountStatus reports the shard counts observed on nodes of each rack
+// in the rack status and in the ShardCountMismatch condition.
+func setShardCountStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackShardCounts map[string][]int32) {
+	known := false
+	var mismatchMessages []string
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.ShardCount = nil
+
+		shardCounts := slices.Compact(slices.Sorted(slices.Values(rackShardCounts[rackStatus.Name])))
+		switch len(shardCounts) {
+		case 0:
+			continue
+
+		case 1:
+			rackStatus.ShardCount = pointer.Ptr(shardCounts[0])
+
+		default:
+			formattedShardCounts := make([]string, 0, len(shardCounts))
+			for _, sc := range shardCounts {
+				formattedShardCounts = append(formattedShardCounts, strconv.Itoa(int(sc)))
+			}
+			mismatchMessages = append(mismatchMessages, fmt.Sprintf("Rack %q has nodes with differing shard counts: %s.", rackStatus.Name, strings.Join(formattedShardCounts, ", ")))
+		}
+
+		known = true
+	}
+
+	switch {
+	case len(mismatchMessages) > 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ShardCountMismatchCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "DifferingShardCounts",
+			Message:            strings.Join(mismatchMessages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ShardCountMismatchCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ShardCountMismatchCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "ShardCountUnknown",
+			Message:            "Shard count couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// setCQLConnectionsStatus reports the sum of CQL connections of the nodes in each rack that reported it.
+func setCQLConnectionsStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackCQLConnections map[string][]int32) {
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.CQLConnections = nil
+
+		cqlConnections, ok := rackCQLConnections[rackStatus.Name]
+		if !ok || len(cqlConnections) == 0 {
+			continue
+		}
+
+		var sum int32
+		for _, c := range cqlConnections {
+			sum += c
+		}
+		rackStatus.CQLConnections = pointer.Ptr(sum)
+	}
+}
+
+// getSeeds returns the seeds of the datacenter that can be found in gossip views: the external seeds
+// that are IP addresses and the first node of the first rack, which bootstraps the datacenter.
+func (sdcc *Controller) getSeeds(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev1.Service) []string {
+	var seeds []string
+	for _, seed := range sdc.Spec.ScyllaDB.ExternalSeeds {
+		if net.ParseIP(seed) == nil {
+			// Gossip views only contain addresses, so hostnames can't be matched.
+			klog.V(4).InfoS("Skipping reachability check of an external seed that isn't an IP address", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Seed", seed)
+			continue
+		}
+
+		seeds = append(seeds, seed)
+	}
+
+	if len(sdc.Spec.Racks) != 0 {
+		host, err := sdcc.getMemberScyllaHost(sdc, naming.MemberServiceName(sdc.Spec.Racks[0], sdc, 0), services)
+		if err != nil {
+			klog.V(4).InfoS("Can't get host of the first node", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Error", err)
+		} else if !slices.Contains(seeds, host) {
+			seeds = append(seeds, host)
+		}
+	}
+
+	return seeds
+}
+
+// setSeedUnreachableStatusCondition reports seeds that none of the reporting nodes of a rack see as up.
+// Racks without any reporting node are skipped, as their view is unknown.
+func setSeedUnreachableStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, seeds []string, rackUpHosts map[string][][]string) {
+	known := false
+	seedUnreachableRacks := map[string][]string{}
+	for _, rack := range sdc.Spec.Racks {
+		nodeViews := rackUpHosts[rack.Name]
+		if len(nodeViews) == 0 {
+			continue
+		}
+		known = true
+
+		for _, seed := range seeds {
+			reachable := slices.ContainsFunc(nodeViews, func(upHosts []string) bool {
+				return slices.Contains(upHosts, seed)
+			})
+			if !reachable {
+				seedUnreachableRacks[seed] = append(seedUnreachableRacks[seed], rack.Name)
+			}
+		}
+	}
+
+	if len(seeds) == 0 || !known {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.SeedUnreachableCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "SeedReachabilityUnknown",
+			Message:            "Seeds or gossip views of nodes couldn't be determined.",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	if len(seedUnreachableRacks) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.SeedUnreachableCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	var messages []string
+	for _, seed := range seeds {
+		racks, ok := seedUnreachableRacks[seed]
+		if !ok {
+			continue
+		}
+
+		messages = append(messages, fmt.Sprintf("Seed %q is unreachable from rack(s) %s.", seed, strings.Join(racks, ", ")))
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.SeedUnreachableCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SeedsUnreachable",
+		Message:            strings.Join(messages, " "),
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// setDataUnderReplicatedStatusCondition reports keyspaces that have a token range with a replica on a down node.
+// The condition is unknown when the replication of no keyspace could be determined.
+func setDataUnderReplicatedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, downHosts []string, keyspaceReplicas map[string][][]string) {
+	if len(keyspaceReplicas) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.DataUnderReplicatedCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "ReplicationUnknown",
+			Message:            "Replication of keyspaces couldn't be determined.",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	var underReplicatedKeyspaces []string
+	var replicaDownHosts []string
+	for _, keyspace := range slices.Sorted(maps.Keys(keyspaceReplicas)) {
+		underReplicated := false
+		for _, replicas := range keyspaceReplicas[keyspace] {
+			for _, replica := range replicas {
+				if !slices.Contains(downHosts, replica) {
+					continue
+				}
+
+				underReplicated = true
+				if !slices.Contains(replicaDownHosts, replica) {
+					replicaDownHosts = append(replicaDownHosts, replica)
+				}
+			}
+		}
+
+		if underReplicated {
+			underReplicatedKeyspaces = append(underReplicatedKeyspaces, keyspace)
+		}
+	}
+
+	if len(underReplicatedKeyspaces) == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.DataUnderReplicatedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	slices.Sort(replicaDownHosts)
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.DataUnderReplicatedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReplicasDown",
+		Message:            fmt.Sprintf("Keyspace(s) %s have replicas on down node(s) %s. Repair or replace the nodes.", strings.Join(underReplicatedKeyspaces, ", "), strings.Join(replicaDownHosts, ", ")),
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// nodeTopology is the datacenter and rack a node reports it belongs to.
+type nodeTopology struct {
+	host       string
+	datacenter string
+	rack       string
+}
+
+// setTopologyMismatchStatusCondition reports nodes whose datacenter or rack differs from the one they belong to in the spec.
+func setTopologyMismatchStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackNodeTopologies map[string][]nodeTopology) {
+	expectedDatacenter := naming.GetScyllaDBDatacenterGossipDatacenterName(sdc)
+
+	known := false
+	var mismatchMessages []string
+	for _, rack := range sdc.Spec.Racks {
+		for _, nt := range rackNodeTopologies[rack.Name] {
+			known = true
+
+			if nt.datacenter == expectedDatacenter && nt.rack == rack.Name {
+				continue
+			}
+
+			mismatchMessages = append(mismatchMessages, fmt.Sprintf("Node %q reports datacenter %q and rack %q, expected datacenter %q and rack %q.", nt.host, nt.datacenter, nt.rack, expectedDatacenter, rack.Name))
+		}
+	}
+
+	switch {
+	case len(mismatchMessages) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.TopologyMismatchCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NodeTopologyMismatch",
+			Message:            strings.Join(mismatchMessages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.TopologyMismatchCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.TopologyMismatchCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "TopologyUnknown",
+			Message:            "Topology couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// nodeTopologyUpgradeState is the state of the upgrade to raft-based topology as seen by a node.
+// An empty state means that the node doesn't support raft-based topology.
+type nodeTopologyUpgradeState struct {
+	host  string
+	state string
+}
+
+// setConsistentTopologyEnabledStatusCondition reports whether all nodes that reported the state of the upgrade
+// to raft-based topology see it as done.
+func setConsistentTopologyEnabledStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackNodeStates map[string][]nodeTopologyUpgradeState) {
+	known := false
+	var unsupportedHosts, states []string
+	stateHosts := map[string][]string{}
+	for _, rack := range sdc.Spec.Racks {
+		for _, ns := range rackNodeStates[rack.Name] {
+			known = true
+
+			switch ns.state {
+			case scyllaclient.TopologyUpgradeStateDone:
+			case "":
+				unsupportedHosts = append(unsupportedHosts, ns.host)
+			default:
+				if _, ok := stateHosts[ns.state]; !ok {
+					states = append(states, ns.state)
+				}
+				stateHosts[ns.state] = append(stateHosts[ns.state], ns.host)
+			}
+		}
+	}
+
+	switch {
+	case !known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "ConsistentTopologyUnknown",
+			Message:            "Topology upgrade state couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case len(unsupportedHosts) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ConsistentTopologyUnsupported",
+			Message:            fmt.Sprintf("Node(s) %s run a ScyllaDB version that doesn't support raft-based topology.", strings.Join(unsupportedHosts, ", ")),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case len(states) != 0:
+		var messages []string
+		for _, state := range states {
+			messages = append(messages, fmt.Sprintf("Upgrade to raft-based topology is in state %q according to node(s) %s.", state, strings.Join(stateHosts[state], ", ")))
+		}
+
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "TopologyUpgradeNotDone",
+			Message:            strings.Join(messages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "TopologyUpgradeDone",
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// getExpectedServiceLevels returns the service levels expected to be configured for workload prioritization,
+// as annotated on the ScyllaDBDatacenter.
+func getExpectedServiceLevels(sdc *scyllav1alpha1.ScyllaDBDatacenter) []string {
+	var serviceLevels []string
+	for _, sl := range strings.Split(sdc.Annotations[naming.ExpectedServiceLevelsAnnotation], ",") {
+		sl = strings.TrimSpace(sl)
+		if len(sl) != 0 {
+			serviceLevels = append(serviceLevels, sl)
+		}
+	}
+
+	return serviceLevels
+}
+
+// nodeServiceLevels are the service levels a node has scheduling groups for.
+type nodeServiceLevels struct {
+	host          string
+	serviceLevels []string
+}
+
+// setWorkloadPrioritizationMisconfiguredStatusCondition reports nodes that don't have scheduling groups
+// of some of the expected service levels.
+func setWorkloadPrioritizationMisconfiguredStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, expectedServiceLevels []string, rackNodeServiceLevels map[string][]nodeServiceLevels) {
+	known := false
+	var mismatchMessages []string
+	for _, rack := range sdc.Spec.Racks {
+		for _, nsl := range rackNodeServiceLevels[rack.Name] {
+			known = true
+
+			var missingServiceLevels []string
+			for _, sl := range expectedServiceLevels {
+				if !slices.Contains(nsl.serviceLevels, sl) {
+					missingServiceLevels = append(missingServiceLevels, sl)
+				}
+			}
+
+			if len(missingServiceLevels) != 0 {
+				mismatchMessages = append(mismatchMessages, fmt.Sprintf("Node %q doesn't have scheduling groups of service level(s) %s.", nsl.host, strings.Join(missingServiceLevels, ", ")))
+			}
+		}
+	}
+
+	switch {
+	case len(mismatchMessages) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ServiceLevelsMissing",
+			Message:            strings.Join(mismatchMessages, " "),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "ServiceLevelsUnknown",
+			Message:            "Scheduling groups couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// nodeTokens are the tokens a node claims to own.
+type nodeTokens struct {
+	host   string
+	tokens []string
+}
+
+// maxReportedOverlappingTokens is the maximum number of overlapping tokens detailed in the TokenRangeOverlap condition.
+const maxReportedOverlappingTokens = 5
+
+// setTokenRangeOverlapStatusCondition reports tokens that are claimed by more than one node.
+// Each token starts the range it is owned by, so a token claimed by several nodes means their ranges overlap.
+func setTokenRangeOverlapStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackNodeTokens map[string][]nodeTokens) {
+	tokenHosts := map[string][]string{}
+	reportingNodes := 0
+	for _, rackName := range slices.Sorted(maps.Keys(rackNodeTokens)) {
+		for _, nt := range rackNodeTokens[rackName] {
+			reportingNodes++
+			for _, token := range nt.tokens {
+				tokenHosts[token] = append(tokenHosts[token], nt.host)
+			}
+		}
+	}
+
+	if reportingNodes == 0 {
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.TokenRangeOverlapCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "TokensUnknown",
+			Message:            "No node reported the tokens it owns.",
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	var overlappingTokens []string
+	for token, hosts := range tokenHosts {
+		if len(hosts) > 1 {
+			overlappingTokens = append(overlappingTokens, token)
+		}
+	}
+
+	if len(overlappingTokens) > 0 {
+		slices.SortFunc(overlappingTokens, func(a, b string) int {
+			// Tokens are numbers, compare them as such when possible.
+			ai, aErr := strconv.ParseInt(a, 10, 64)
+			bi, bErr := strconv.ParseInt(b, 10, 64)
+			if aErr != nil || bErr != nil {
+				return strings.Compare(a, b)
+			}
+			return cmp.Compare(ai, bi)
+		})
+
+		details := make([]string, 0, maxReportedOverlappingTokens)
+		for _, token := range overlappingTokens[:min(len(overlappingTokens), maxReportedOverlappingTokens)] {
+			details = append(details, fmt.Sprintf("token %s is claimed by nodes %s", token, strings.Join(tokenHosts[token], ", ")))
+		}
+		if len(overlappingTokens) > maxReportedOverlappingTokens {
+			details = append(details, fmt.Sprintf("and %d more", len(overlappingTokens)-maxReportedOverlappingTokens))
+		}
+
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.TokenRangeOverlapCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "TokensClaimedByMultipleNodes",
+			Message:            fmt.Sprintf("%d token(s) are claimed by more than one node: %s.", len(overlappingTokens), strings.Join(details, "; ")),
+			ObservedGeneration: sdc.Generation,
+		})
+		return
+	}
+
+	apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               scyllav1alpha1.TokenRangeOverlapCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: sdc.Generation,
+	})
+}
+
+// maxSchemaOverviewKeyspaces is the maximum number of keyspaces listed in the schema overview,
+// which keeps the size of the status bounded for large schemas.
+const maxSchemaOverviewKeyspaces = 100
+
+// isSchemaOverviewStale returns true if the schema overview should be refreshed.
+func isSchemaOverviewStale(schema *scyllav1alpha1.SchemaStatus, now metav1.Time, refreshInterval time.Duration) bool {
+	return schema == nil || now.Sub(schema.LastRefreshTime.Time) >= refreshInterval
+}
+
+// makeSchemaStatus makes a schema overview listing keyspaces sorted by name, up to maxSchemaOverviewKeyspaces.
+func makeSchemaStatus(tableCounts map[string]int32, now metav1.Time) *scyllav1alpha1.SchemaStatus {
+	keyspaces := slices.Sorted(maps.Keys(tableCounts))
+
+	schema := &scyllav1alpha1.SchemaStatus{
+		TotalKeyspaces: int32(len(keyspaces)),
+		// Serialized times only have a second precision.
+		LastRefreshTime: now.Rfc3339Copy(),
+	}
+
+	for _, keyspace := range keyspaces[:min(len(keyspaces), maxSchemaOverviewKeyspaces)] {
+		schema.Keyspaces = append(schema.Keyspaces, scyllav1alpha1.KeyspaceStatus{
+			Name:   keyspace,
+			Tables: tableCounts[keyspace],
+		})
+	}
+
+	return schema
+}
+
+// latencyQuantile is the quantile of latencies reported in datacenter status.
+const latencyQuantile = 0.99
+
+// isLatencyStale returns true if the reported latencies should be refreshed.
+func isLatencyStale(latency *scyllav1alpha1.LatencyStatus, now metav1.Time, refreshInterval time.Duration) bool {
+	return latency == nil || now.Sub(latency.LastRefreshTime.Time) >= refreshInterval
+}
+
+// maxRackLatencyMicroseconds returns the highest of latencies reported by nodes of all racks, in microseconds,
+// or nil if no node reported it.
+func maxRackLatencyMicroseconds(rackLatencies map[string][]time.Duration) *int64 {
+	var res *int64
+	for _, latencies := range rackLatencies {
+		for _, l := range latencies {
+			if res == nil || l.Microseconds() > *res {
+				res = pointer.Ptr(l.Microseconds())
+			}
+		}
+	}
+
+	return res
+}
+
+// makeLatencyStatus makes a latency overview from the latencies reported by the nodes of each rack.
+// It returns nil when neither read nor write latencies were reported by any node.
+func makeLatencyStatus(rackReadLatencies, rackWriteLatencies map[string][]time.Duration, now metav1.Time) *scyllav1alpha1.LatencyStatus {
+	latency := &scyllav1alpha1.LatencyStatus{
+		ReadP99Microseconds:  maxRackLatencyMicroseconds(rackReadLatencies),
+		WriteP99Microseconds: maxRackLatencyMicroseconds(rackWriteLatencies),
+		// Serialized times only have a second precision.
+		LastRefreshTime: now.Rfc3339Copy(),
+	}
+
+	if latency.ReadP99Microseconds == nil && latency.WriteP99Microseconds == nil {
+		return nil
+	}
+
+	return latency
+}
+
+// usedDataBytesTolerancePercent is the maximum relative change of used data bytes of a rack, in percent,
+// for which the previously reported value is kept. Data usage changes with every write and compaction,
+// which would otherwise change the status on every reconcile.
+const usedDataBytesTolerancePercent = 1
+
+type nodeLoad struct {
+	host  string
+	bytes int64
+}
+
+// setUsedDataBytesStatus reports the sum of data bytes stored on the nodes in each rack that reported it.
+// Values within usedDataBytesTolerancePercent of the previously reported ones are kept to avoid constant status updates.
+func setUsedDataBytesStatus(oldStatus, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackLoads map[string][]nodeLoad) {
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.UsedDataBytes = nil
+
+		loads, ok := rackLoads[rackStatus.Name]
+		if !ok || len(loads) == 0 {
+			continue
+		}
+
+		var sum int64
+		for _, l := range loads {
+			sum += l.bytes
+		}
+
+		idx := slices.IndexFunc(oldStatus.Racks, func(rs scyllav1alpha1.RackStatus) bool {
+			return rs.Name == rackStatus.Name
+		})
+		if idx >= 0 && oldStatus.Racks[idx].UsedDataBytes != nil {
+			old := *oldStatus.Racks[idx].UsedDataBytes
+			diff := sum - old
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff*100 <= old*usedDataBytesTolerancePercent {
+				sum = old
+			}
+		}
+
+		rackStatus.UsedDataBytes = pointer.Ptr(sum)
+	}
+}
+
+// DefaultLargePartitionThresholdBytes is the partition size above which partitions are reported as large
+// when the threshold isn't configured. It matches the default large partition warning threshold of ScyllaDB.
+const DefaultLargePartitionThresholdBytes int64 = 1000 * 1024 * 1024
+
+// nodeMaxPartitionSize is the size of the largest partition compacted on a node.
+type nodeMaxPartitionSize struct {
+	host  string
+	bytes int64
+}
+
+// setMaxPartitionBytesStatus reports the size of the largest partition compacted on the nodes in each rack
+// that reported it.
+func setMaxPartitionBytesStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackSizes map[string][]nodeMaxPartitionSize) {
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.MaxPartitionBytes = nil
+
+		sizes, ok := rackSizes[rackStatus.Name]
+		if !ok || len(sizes) == 0 {
+			continue
+		}
+
+		var maxSize int64
+		for _, s := range sizes {
+			maxSize = max(maxSize, s.bytes)
+		}
+
+		rackStatus.MaxPartitionBytes = pointer.Ptr(maxSize)
+	}
+}
+
+// setLargePartitionDetectedStatusCondition reports the nodes that have compacted partitions larger than the threshold.
+func setLargePartitionDetectedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackSizes map[string][]nodeMaxPartitionSize, threshold int64) {
+	known := false
+	var messages []string
+	for _, rack := range sdc.Spec.Racks {
+		for _, s := range rackSizes[rack.Name] {
+			known = true
+
+			if s.bytes > threshold {
+				messages = append(messages, fmt.Sprintf("Node %q in rack %q has a partition of %d bytes.", s.host, rack.Name, s.bytes))
+			}
+		}
+	}
+
+	switch {
+	case !known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.LargePartitionDetectedCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "PartitionSizesUnknown",
+			Message:            "Partition sizes couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case len(messages) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.LargePartitionDetectedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "LargePartitionsFound",
+			Message:            fmt.Sprintf("%s Partitions larger than %d bytes cause latency spikes and instability.", strings.Join(messages, " "), threshold),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.LargePartitionDetectedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// DefaultClockSkewThreshold is the clock drift above which nodes are reported as skewed
+// when the threshold isn't configured.
+const DefaultClockSkewThreshold = 5 * time.Second
+
+// nodeClockOffset is the offset of the clock of a node. Depending on the context, it is relative
+// to the clock of the operator or to the median clock of the datacenter nodes.
+type nodeClockOffset struct {
+	host   string
+	offset time.Duration
+}
+
+// getClockSkews returns the offsets of node clocks relative to the median clock of all nodes.
+// Comparing the nodes with each other keeps the result independent of the clock of the operator.
+func getClockSkews(rackOffsets map[string][]nodeClockOffset) map[string][]nodeClockOffset {
+	var offsets []time.Duration
+	for _, nodeOffsets := range rackOffsets {
+		for _, o := range nodeOffsets {
+			offsets = append(offsets, o.offset)
+		}
+	}
+
+	if len(offsets) == 0 {
+		return map[string][]nodeClockOffset{}
+	}
+
+	slices.Sort(offsets)
+	median := offsets[len(offsets)/2]
+	if len(offsets)%2 == 0 {
+		median = (offsets[len(offsets)/2-1] + offsets[len(offsets)/2]) / 2
+	}
+
+	rackSkews := make(map[string][]nodeClockOffset, len(rackOffsets))
+	for rack, nodeOffsets := range rackOffsets {
+		for _, o := range nodeOffsets {
+			rackSkews[rack] = append(rackSkews[rack], nodeClockOffset{
+				host:   o.host,
+				offset: o.offset - median,
+			})
+		}
+	}
+
+	return rackSkews
+}
+
+func isClockSkewed(skew, threshold time.Duration) bool {
+	return skew > threshold || skew < -threshold
+}
+
+// setClockSkewedNodesStatus reports the number of nodes in each rack whose clock skew exceeds the threshold,
+// out of the nodes that reported their clocks.
+func setClockSkewedNodesStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackSkews map[string][]nodeClockOffset, threshold time.Duration) {
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.ClockSkewedNodes = nil
+
+		skews, ok := rackSkews[rackStatus.Name]
+		if !ok || len(skews) == 0 {
+			continue
+		}
+
+		var count int32
+		for _, s := range skews {
+			if isClockSkewed(s.offset, threshold) {
+				count++
+			}
+		}
+
+		rackStatus.ClockSkewedNodes = pointer.Ptr(count)
+	}
+}
+
+// setClockSkewDetectedStatusCondition reports the nodes whose clock skew exceeds the threshold.
+func setClockSkewDetectedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackSkews map[string][]nodeClockOffset, threshold time.Duration) {
+	known := false
+	var messages []string
+	for _, rack := range sdc.Spec.Racks {
+		for _, s := range rackSkews[rack.Name] {
+			known = true
+
+			if isClockSkewed(s.offset, threshold) {
+				messages = append(messages, fmt.Sprintf("Node %q in rack %q has a clock skew of %v.", s.host, rack.Name, s.offset.Round(time.Second)))
+			}
+		}
+	}
+
+	switch {
+	case !known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ClockSkewDetectedCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "ClockSkewUnknown",
+			Message:            "Clocks couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case len(messages) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ClockSkewDetectedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ClockSkewFound",
+			Message:            fmt.Sprintf("%s Clock skews are relative to the median clock of the nodes and have to stay within %v.", strings.Join(messages, " "), threshold),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.ClockSkewDetectedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// DefaultSnapshotThresholdBytes is the snapshot space usage of a node above which its snapshots are reported as stale
+// when the threshold isn't configured.
+const DefaultSnapshotThresholdBytes int64 = 10 * 1024 * 1024 * 1024
+
+// nodeSnapshotSize is the number of bytes taken by snapshots on a node.
+type nodeSnapshotSize struct {
+	host  string
+	bytes int64
+}
+
+// setSnapshotBytesStatus reports the sum of bytes taken by snapshots on the nodes in each rack that reported it.
+func setSnapshotBytesStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackSizes map[string][]nodeSnapshotSize) {
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.SnapshotBytes = nil
+
+		sizes, ok := rackSizes[rackStatus.Name]
+		if !ok || len(sizes) == 0 {
+			continue
+		}
+
+		var sum int64
+		for _, s := range sizes {
+			sum += s.bytes
+		}
+
+		rackStatus.SnapshotBytes = pointer.Ptr(sum)
+	}
+}
+
+// setStaleSnapshotsDetectedStatusCondition reports the nodes whose snapshots take more space than the threshold.
+func setStaleSnapshotsDetectedStatusCondition(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackSizes map[string][]nodeSnapshotSize, threshold int64) {
+	known := false
+	var messages []string
+	for _, rack := range sdc.Spec.Racks {
+		for _, s := range rackSizes[rack.Name] {
+			known = true
+
+			if s.bytes > threshold {
+				messages = append(messages, fmt.Sprintf("Node %q in rack %q has snapshots taking %d bytes.", s.host, rack.Name, s.bytes))
+			}
+		}
+	}
+
+	switch {
+	case !known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.StaleSnapshotsDetectedCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "SnapshotSizesUnknown",
+			Message:            "Snapshot sizes couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case len(messages) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.StaleSnapshotsDetectedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "SnapshotsAboveThreshold",
+			Message:            fmt.Sprintf("%s Snapshots taking more than %d bytes should be cleared to avoid running out of disk space.", strings.Join(messages, " "), threshold),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.StaleSnapshotsDetectedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// DefaultDiskPressureWatermarkPercent is the data volume usage of a node, in percent of its capacity,
+// above which it is reported under disk pressure when the watermark isn't configured.
+const DefaultDiskPressureWatermarkPercent = 85
+
+func (sdcc *Controller) setDiskPressure(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service, rackLoads map[string][]nodeLoad) {
+	watermarkPercent := sdcc.statusOptions.DiskPressureWatermarkPercent
+	if watermarkPercent == 0 {
+		watermarkPercent = DefaultDiskPressureWatermarkPercent
+	}
+
+	setDiskPressureStatus(sdc, status, rackLoads, sdcc.getDataVolumeCapacities(sdc, services), watermarkPercent)
+}
+
+// getDataVolumeCapacities returns the capacity of the data volume of each node, keyed by its host,
+// as provisioned for its PVC. Nodes whose PVC isn't bound yet are left out.
+func (sdcc *Controller) getDataVolumeCapacities(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev1.Service) map[string]int64 {
+	capacities := map[string]int64{}
+	err := forEachExpectedMemberService(sdc, services, func(rack scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+		if svc == nil {
+			return
+		}
+
+		host, err := sdcc.getMemberScyllaHost(sdc, svcName, services)
+		if err != nil {
+			klog.V(4).InfoS("Can't get node host", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Service", naming.ManualRef(sdc.Namespace, svcName), "Error", err)
+			return
+		}
+
+		pvcName := naming.PVCNameForService(svcName)
+		pvc, err := sdcc.pvcLister.PersistentVolumeClaims(sdc.Namespace).Get(pvcName)
+		if err != nil {
+			klog.V(4).InfoS("Can't get data volume PVC", "ScyllaDBDatacenter", naming.ObjRef(sdc), "PVC", naming.ManualRef(sdc.Namespace, pvcName), "Error", err)
+			return
+		}
+
+		capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+		if !ok {
+			return
+		}
+
+		capacities[host] = capacity.Value()
+	})
+	if err != nil {
+		klog.ErrorS(err, "can't get data volume capacities", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+	}
+
+	return capacities
+}
+
+// setDiskPressureStatus reports the number of nodes in each rack whose data usage exceeds the watermark
+// of their data volume capacity, and names them in the DiskPressure condition.
+// Nodes whose data volume capacity isn't known are left out.
+func setDiskPressureStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackLoads map[string][]nodeLoad, capacities map[string]int64, watermarkPercent int) {
+	known := false
+	var messages []string
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.DiskPressureNodes = nil
+
+		loads, ok := rackLoads[rackStatus.Name]
+		if !ok || len(loads) == 0 {
+			continue
+		}
+
+		rackKnown := false
+		var pressureNodes int32
+		for _, l := range loads {
+			capacity := capacities[l.host]
+			if capacity <= 0 {
+				klog.V(4).InfoS("Can't determine data volume capacity", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rackStatus.Name, "Host", l.host)
+				continue
+			}
+
+			rackKnown = true
+			usagePercent := l.bytes * 100 / capacity
+			if usagePercent > int64(watermarkPercent) {
+				pressureNodes++
+				messages = append(messages, fmt.Sprintf("Node %q in rack %q uses %d%% of its data volume.", l.host, rackStatus.Name, usagePercent))
+			}
+		}
+
+		if !rackKnown {
+			continue
+		}
+
+		known = true
+		rackStatus.DiskPressureNodes = pointer.Ptr(pressureNodes)
+	}
+
+	switch {
+	case !known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.DiskPressureCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "DiskUsageUnknown",
+			Message:            "Data volume usage couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case len(messages) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.DiskPressureCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "DataVolumesNearCapacity",
+			Message:            fmt.Sprintf("%s Storage of nodes using more than %d%% of their data volume should be expanded before they run out of disk space.", strings.Join(messages, " "), watermarkPercent),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.DiskPressureCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// DefaultIntraRackVersionSkewGracePeriod is how long nodes in a rack can run different ScyllaDB versions
+// before the rack is reported when the grace period isn't configured.
+const DefaultIntraRackVersionSkewGracePeriod = 30 * time.Minute
+
+// setIntraRackVersionSkewStatus tracks since when nodes in each rack run different ScyllaDB versions and reports
+// the racks where it lasts longer than the grace period. Racks with no reported versions keep the time from
+// the previous status, so failing queries don't restart the grace period.
+func setIntraRackVersionSkewStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, oldStatus, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackVersions map[string][]string, gracePeriod time.Duration, now metav1.Time) {
+	known := false
+	var messages []string
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+
+		var oldSkewSince *metav1.Time
+		idx := slices.IndexFunc(oldStatus.Racks, func(rs scyllav1alpha1.RackStatus) bool {
+			return rs.Name == rackStatus.Name
+		})
+		if idx >= 0 {
+			oldSkewSince = oldStatus.Racks[idx].VersionSkewSince
+		}
+
+		versions, ok := rackVersions[rackStatus.Name]
+		if !ok || len(versions) == 0 {
+			rackStatus.VersionSkewSince = oldSkewSince.DeepCopy()
+			continue
+		}
+
+		known = true
+		rackStatus.VersionSkewSince = nil
+
+		distinctVersions := slices.Compact(slices.Sorted(slices.Values(versions)))
+		if len(distinctVersions) <= 1 {
+			continue
+		}
+
+		skewSince := oldSkewSince.DeepCopy()
+		if skewSince == nil {
+			// Serialized times only have a second precision.
+			skewSince = pointer.Ptr(now.Rfc3339Copy())
+		}
+		rackStatus.VersionSkewSince = skewSince
+
+		if now.Sub(skewSince.Time) > gracePeriod {
+			quotedVersions := make([]string, 0, len(distinctVersions))
+			for _, v := range distinctVersions {
+				quotedVersions = append(quotedVersions, fmt.Sprintf("%q", v))
+			}
+
+			messages = append(messages, fmt.Sprintf("Nodes in rack %q have been running different versions %s since %s.", rackStatus.Name, strings.Join(quotedVersions, ", "), skewSince.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	switch {
+	case !known:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.IntraRackVersionSkewCondition,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "VersionsUnknown",
+			Message:            "ScyllaDB versions couldn't be determined for any node.",
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case len(messages) != 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.IntraRackVersionSkewCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "VersionSkewPersists",
+			Message:            fmt.Sprintf("%s Nodes are expected to converge on a single version within %v, the rack update may be stuck.", strings.Join(messages, " "), gracePeriod),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.IntraRackVersionSkewCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
+			ObservedGeneration: sdc.Generation,
+		})
+	}
+}
+
+// podMetrics is the subset of PodMetrics of the metrics API (metrics.k8s.io/v1beta1) used for status reporting.
+// It is decoded locally to avoid depending on the metrics API client.
+type podMetrics struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Containers []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+// getScyllaDBContainerUsages returns the resource usage of ScyllaDB containers of Pods in datacenter, keyed by Pod name.
+func (sdcc *Controller) getScyllaDBContainerUsages(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter) (map[string]corev1.ResourceList, error) {
+	restClient := sdcc.kubeClient.Discovery().RESTClient()
+	if restClient == nil {
+		return nil, fmt.Errorf("discovery client doesn't support REST requests")
+	}
+
+	queryCtx, queryCtxCancel := context.WithTimeout(ctx, nodeQueryTimeout)
+	defer queryCtxCancel()
+
+	data, err := restClient.Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1", "namespaces", sdc.Namespace, "pods").
+		Param("labelSelector", naming.ClusterSelector(sdc).String()).
+		DoRaw(queryCtx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get pod metrics: %w", err)
+	}
+
+	list := &podMetricsList{}
+	err = json.Unmarshal(data, list)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode pod metrics: %w", err)
+	}
+
+	usages := make(map[string]corev1.ResourceList, len(list.Items))
+	for _, pm := range list.Items {
+		for _, c := range pm.Containers {
+			if c.Name == naming.ScyllaContainerName {
+				usages[pm.Name] = c.Usage
+				break
+			}
+		}
+	}
+
+	return usages, nil
+}
+
+func (sdcc *Controller) setResourceUtilization(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	pods, err := sdcc.podLister.Pods(sdc.Namespace).List(naming.ClusterSelector(sdc))
+	if err != nil {
+		klog.ErrorS(err, "can't list Pods", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+		setResourceUtilizationStatus(status, nil, nil)
+		return
+	}
+
+	// The metrics API is an optional extension of the cluster, so its absence isn't an error.
+	usages, err := sdcc.getScyllaDBContainerUsages(ctx, sdc)
+	if err != nil {
+		klog.V(4).InfoS("Can't get resource usage of nodes", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Error", err)
+	}
+
+	setResourceUtilizationStatus(status, pods, usages)
+}
+
+// setResourceUtilizationStatus reports the average utilization of ScyllaDB containers in each rack,
+// relative to their requests. Pods without usage or requests of a resource aren't counted for it.
+func setResourceUtilizationStatus(status *scyllav1alpha1.ScyllaDBDatacenterStatus, pods []*corev1.Pod, usages map[string]corev1.ResourceList) {
+	type utilizationSum struct {
+		sum   int64
+		count int64
+	}
+
+	rackUtilizations := map[string]map[corev1.ResourceName]*utilizationSum{}
+	for _, pod := range pods {
+		usage, ok := usages[pod.Name]
+		if !ok {
+			continue
+		}
+
+		rackName, ok := pod.Labels[naming.RackNameLabel]
+		if !ok {
+			continue
+		}
+
+		containerIdx, err := naming.FindScyllaContainer(pod.Spec.Containers)
+		if err != nil {
+			continue
+		}
+		container := &pod.Spec.Containers[containerIdx]
+
+		for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			used, ok := usage[resourceName]
+			if !ok {
+				continue
+			}
+
+			requested, ok := container.Resources.Requests[resourceName]
+			if !ok || requested.IsZero() {
+				continue
+			}
+
+			if rackUtilizations[rackName] == nil {
+				rackUtilizations[rackName] = map[corev1.ResourceName]*utilizationSum{}
+			}
+			u := rackUtilizations[rackName][resourceName]
+			if u == nil {
+				u = &utilizationSum{}
+				rackUtilizations[rackName][resourceName] = u
+			}
+			u.sum += used.MilliValue() * 100 / requested.MilliValue()
+			u.count++
+		}
+	}
+
+	average := func(rackName string, resourceName corev1.ResourceName) *int32 {
+		u := rackUtilizations[rackName][resourceName]
+		if u == nil || u.count == 0 {
+			return nil
+		}
+
+		return pointer.Ptr(int32(u.sum / u.count))
+	}
+
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+		rackStatus.CPUUtilizationPercent = average(rackStatus.Name, corev1.ResourceCPU)
+		rackStatus.MemoryUtilizationPercent = average(rackStatus.Name, corev1.ResourceMemory)
+	}
+}
+
+// nodeStartTimeTolerance is the maximum difference of node start times derived from uptime that are considered equal.
+// It absorbs the skew of computing the start time from the uptime and the current time, which would otherwise change
+// the status on every reconcile.
+const nodeStartTimeTolerance = time.Minute
+
+// setNodeStartTimesStatus replaces the container-derived start time range of racks with the start times reported
+// by their nodes. Racks of which no node reported its uptime keep the container-derived range.
+// Start times within nodeStartTimeTolerance of the previously reported ones are kept to avoid constant status updates.
+func setNodeStartTimesStatus(oldStatus, status *scyllav1alpha1.ScyllaDBDatacenterStatus, rackStartTimes map[string][]time.Time) {
+	keepWithinTolerance := func(oldTime, newTime *metav1.Time) *metav1.Time {
+		if oldTime != nil && newTime != nil && newTime.Sub(oldTime.Time).Abs() < nodeStartTimeTolerance {
+			return oldTime
+		}
+
+		return newTime
+	}
+
+	for i := range status.Racks {
+		rackStatus := &status.Racks[i]
+
+		startTimes := rackStartTimes[rackStatus.Name]
+		if len(startTimes) == 0 {
+			continue
+		}
+
+		earliest, latest := getStartTimeRange(startTimes)
+		// Serialized times only have a second precision.
+		earliest, latest = pointer.Ptr(earliest.Rfc3339Copy()), pointer.Ptr(latest.Rfc3339Copy())
+
+		idx := slices.IndexFunc(oldStatus.Racks, func(rs scyllav1alpha1.RackStatus) bool {
+			return rs.Name == rackStatus.Name
+		})
+		if idx >= 0 {
+			earliest = keepWithinTolerance(oldStatus.Racks[idx].EarliestNodeStartTime, earliest)
+			latest = keepWithinTolerance(oldStatus.Racks[idx].LatestNodeStartTime, latest)
+		}
+
+		rackStatus.EarliestNodeStartTime, rackStatus.LatestNodeStartTime = earliest, latest
+	}
+}
+
+func setCleanupRecommendation(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	setCleanupRecommendedStatus(sdc, status, isCleanupCompleted(sdc, services))
+}
+
+// isCleanupCompleted returns true if the cleanup Jobs completed for the current token ring on every member Service
+// expected by the spec. The current token ring hashes of the Services also have to agree, so a token ring change
+// that isn't reflected on all Services yet isn't mistaken for a completed cleanup.
+func isCleanupCompleted(sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev1.Service) bool {
+	completed := true
+	var tokenRingHash *string
+	err := forEachExpectedMemberService(sdc, services, func(rack scyllav1alpha1.RackSpec, svcName string, svc *corev1.Service) {
+		if svc == nil {
+			completed = false
+			return
+		}
+
+		currentTokenRingHash, ok := svc.Annotations[naming.CurrentTokenRingHashAnnotation]
+		if !ok || len(currentTokenRingHash) == 0 {
+			completed = false
+			return
+		}
+
+		if tokenRingHash == nil {
+			tokenRingHash = &currentTokenRingHash
+		} else if *tokenRingHash != currentTokenRingHash {
+			completed = false
+		}
+
+		if svc.Annotations[naming.LastCleanedUpTokenRingHashAnnotation] != currentTokenRingHash {
+			completed = false
+		}
+	})
+	if err != nil {
+		klog.ErrorS(err, "can't determine whether cleanup completed", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+		return false
+	}
+
+	return completed && tokenRingHash != nil
+}
+
+// setCleanupRecommendedStatus sets the advisory CleanupRecommended condition when the number of nodes
+// of a fully available datacenter differs from the baseline. The baseline is reset once a cleanup completes on all nodes.
+func setCleanupRecommendedStatus(sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, cleanupCompleted bool) {
+	if !isFullyAvailable(status) {
+		// Node counts are only meaningful once the topology change finishes.
+		if apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.CleanupRecommendedCondition) == nil {
+			apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "WaitingForFullAvailability",
+				Message:            "Waiting for all nodes to become ready.",
+				ObservedGeneration: sdc.Generation,
+			})
+		}
+
+		return
+	}
+
+	nodes := *status.Nodes
+	if status.CleanupBaselineNodes == nil || cleanupCompleted {
+		status.CleanupBaselineNodes = pointer.Ptr(nodes)
+	}
+
+	baselineNodes := *status.CleanupBaselineNodes
+	switch {
+	case nodes > baselineNodes:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.CleanupRecommendedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NodesAdded",
+			Message:            fmt.Sprintf("The number of nodes increased from %d to %d. Running a cleanup on the existing nodes is recommended to reclaim space.", baselineNodes, nodes),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	case nodes < baselineNodes:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.CleanupRecommendedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "NodesRemoved",
+			Message:            fmt.Sprintf("The number of nodes decreased from %d to %d. Running a cleanup on the remaining nodes is recommended.", baselineNodes, nodes),
+			ObservedGeneration: sdc.Generation,
+		})
+
+	default:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               scyllav1alpha1.CleanupRecommendedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             internalapi.AsExpectedReason,
+			Message:            "",
 			ObservedGeneration: sdc.Generation,
 		})
 	}
@@ -0,0 +1,151 @@
+package scylladbdatacenter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// StatusSnapshot is a status of a ScyllaDBDatacenter written at a point in time.
+type StatusSnapshot struct {
+	Time   metav1.Time                             `json:"time"`
+	Status scyllav1alpha1.ScyllaDBDatacenterStatus `json:"status"`
+}
+
+// statusRingBuffer keeps the latest snapshots, evicting the oldest one when it's full.
+type statusRingBuffer struct {
+	snapshots []StatusSnapshot
+	// next is the index the next snapshot is written to.
+	next int
+	full bool
+}
+
+func newStatusRingBuffer(size int) *statusRingBuffer {
+	return &statusRingBuffer{
+		snapshots: make([]StatusSnapshot, size),
+	}
+}
+
+func (b *statusRingBuffer) add(snapshot StatusSnapshot) {
+	b.snapshots[b.next] = snapshot
+	b.next = (b.next + 1) % len(b.snapshots)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// list returns the snapshots from the oldest to the newest.
+func (b *statusRingBuffer) list() []StatusSnapshot {
+	if !b.full {
+		return append([]StatusSnapshot{}, b.snapshots[:b.next]...)
+	}
+
+	return append(append([]StatusSnapshot{}, b.snapshots[b.next:]...), b.snapshots[:b.next]...)
+}
+
+// statusHistory keeps a bounded in-memory history of status snapshots of each ScyllaDBDatacenter.
+// It is safe for concurrent use.
+type statusHistory struct {
+	size int
+
+	lock    sync.Mutex
+	buffers map[types.NamespacedName]*statusRingBuffer
+}
+
+// newStatusHistory creates a history keeping the last size snapshots per ScyllaDBDatacenter.
+// A size that isn't positive disables the history.
+func newStatusHistory(size int) *statusHistory {
+	return &statusHistory{
+		size:    size,
+		buffers: map[types.NamespacedName]*statusRingBuffer{},
+	}
+}
+
+func (h *statusHistory) isEnabled() bool {
+	return h != nil && h.size > 0
+}
+
+func (h *statusHistory) record(key types.NamespacedName, snapshot StatusSnapshot) {
+	if !h.isEnabled() {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	b, ok := h.buffers[key]
+	if !ok {
+		b = newStatusRingBuffer(h.size)
+		h.buffers[key] = b
+	}
+
+	b.add(snapshot)
+}
+
+func (h *statusHistory) get(key types.NamespacedName) []StatusSnapshot {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	b, ok := h.buffers[key]
+	if !ok {
+		return []StatusSnapshot{}
+	}
+
+	return b.list()
+}
+
+// forget drops the history of a ScyllaDBDatacenter, e.g. after it was deleted.
+func (h *statusHistory) forget(key types.NamespacedName) {
+	if !h.isEnabled() {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.buffers, key)
+}
+
+// ServeStatusHistory responds with a JSON list of the latest status snapshots written for the ScyllaDBDatacenter
+// identified by the "namespace" and "name" query parameters, from the oldest to the newest.
+// The history is kept in memory only, so it doesn't survive operator restarts or leader changes.
+func (sdcc *Controller) ServeStatusHistory(w http.ResponseWriter, req *http.Request) {
+	if !sdcc.statusHistory.isEnabled() {
+		http.Error(w, "status history is disabled", http.StatusNotFound)
+		return
+	}
+
+	namespace, name := req.URL.Query().Get("namespace"), req.URL.Query().Get("name")
+	if len(namespace) == 0 || len(name) == 0 {
+		http.Error(w, "namespace and name query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	sdc, err := sdcc.scyllaDBDatacenterLister.ScyllaDBDatacenters(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("ScyllaDBDatacenter %q not found", naming.ManualRef(namespace, name)), http.StatusNotFound)
+			return
+		}
+
+		klog.ErrorS(err, "can't get ScyllaDBDatacenter", "ScyllaDBDatacenter", naming.ManualRef(namespace, name))
+		http.Error(w, fmt.Sprintf("can't get ScyllaDBDatacenter: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	snapshots := sdcc.statusHistory.get(types.NamespacedName{Namespace: sdc.Namespace, Name: sdc.Name})
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(snapshots)
+	if err != nil {
+		klog.ErrorS(err, "can't write status history", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+	}
+}
@@ -0,0 +1,210 @@
+package scylladbdatacenter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	scyllav1alpha1listers "github.com/scylladb/scylla-operator/pkg/client/scylla/listers/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTestStatusSnapshot(readyNodes int32) StatusSnapshot {
+	return StatusSnapshot{
+		Time: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, int(readyNodes), 0, time.UTC)),
+		Status: scyllav1alpha1.ScyllaDBDatacenterStatus{
+			ReadyNodes: pointer.Ptr(readyNodes),
+		},
+	}
+}
+
+func TestStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	key := types.NamespacedName{Namespace: "default", Name: "basic"}
+	otherKey := types.NamespacedName{Namespace: "default", Name: "other"}
+
+	tt := []struct {
+		name              string
+		size              int
+		recorded          []int32
+		otherRecorded     []int32
+		expectedSnapshots []StatusSnapshot
+	}{
+		{
+			name:              "disabled history doesn't keep anything",
+			size:              0,
+			recorded:          []int32{1, 2},
+			expectedSnapshots: []StatusSnapshot{},
+		},
+		{
+			name:              "history without snapshots is empty",
+			size:              3,
+			recorded:          nil,
+			expectedSnapshots: []StatusSnapshot{},
+		},
+		{
+			name:     "snapshots are kept from the oldest to the newest until the history is full",
+			size:     3,
+			recorded: []int32{1, 2, 3},
+			expectedSnapshots: []StatusSnapshot{
+				newTestStatusSnapshot(1),
+				newTestStatusSnapshot(2),
+				newTestStatusSnapshot(3),
+			},
+		},
+		{
+			name:     "oldest snapshots are evicted when the history is full",
+			size:     3,
+			recorded: []int32{1, 2, 3, 4, 5},
+			expectedSnapshots: []StatusSnapshot{
+				newTestStatusSnapshot(3),
+				newTestStatusSnapshot(4),
+				newTestStatusSnapshot(5),
+			},
+		},
+		{
+			name:     "snapshots wrapping around the buffer multiple times keep their order",
+			size:     2,
+			recorded: []int32{1, 2, 3, 4, 5, 6, 7},
+			expectedSnapshots: []StatusSnapshot{
+				newTestStatusSnapshot(6),
+				newTestStatusSnapshot(7),
+			},
+		},
+		{
+			name:          "snapshots of other datacenters don't evict snapshots of the datacenter",
+			size:          2,
+			recorded:      []int32{1, 2},
+			otherRecorded: []int32{3, 4, 5},
+			expectedSnapshots: []StatusSnapshot{
+				newTestStatusSnapshot(1),
+				newTestStatusSnapshot(2),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := newStatusHistory(tc.size)
+			for _, readyNodes := range tc.recorded {
+				h.record(key, newTestStatusSnapshot(readyNodes))
+			}
+			for _, readyNodes := range tc.otherRecorded {
+				h.record(otherKey, newTestStatusSnapshot(readyNodes))
+			}
+
+			got := h.get(key)
+			if !apiequality.Semantic.DeepEqual(got, tc.expectedSnapshots) {
+				t.Errorf("expected and got snapshots differ: %s", cmp.Diff(tc.expectedSnapshots, got))
+			}
+
+			h.forget(key)
+			got = h.get(key)
+			if len(got) != 0 {
+				t.Errorf("expected no snapshots after forgetting the history, got %d", len(got))
+			}
+		})
+	}
+}
+
+func TestController_ServeStatusHistory(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+	}
+
+	tt := []struct {
+		name               string
+		historySize        int
+		query              string
+		expectedStatusCode int
+		expectedSnapshots  []StatusSnapshot
+	}{
+		{
+			name:               "disabled history",
+			historySize:        0,
+			query:              "namespace=default&name=basic",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "missing query parameters",
+			historySize:        2,
+			query:              "namespace=default",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "missing datacenter",
+			historySize:        2,
+			query:              "namespace=default&name=missing",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "latest snapshots of the datacenter",
+			historySize:        2,
+			query:              "namespace=default&name=basic",
+			expectedStatusCode: http.StatusOK,
+			expectedSnapshots: []StatusSnapshot{
+				newTestStatusSnapshot(2),
+				newTestStatusSnapshot(3),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sdcCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			err := sdcCache.Add(sdc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sdcc := &Controller{
+				scyllaDBDatacenterLister: scyllav1alpha1listers.NewScyllaDBDatacenterLister(sdcCache),
+				statusHistory:            newStatusHistory(tc.historySize),
+			}
+			for _, readyNodes := range []int32{1, 2, 3} {
+				sdcc.statusHistory.record(types.NamespacedName{Namespace: sdc.Namespace, Name: sdc.Name}, newTestStatusSnapshot(readyNodes))
+			}
+
+			req := httptest.NewRequest(http.MethodGet, naming.ScyllaDBDatacenterStatusHistoryPath+"?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			sdcc.ServeStatusHistory(w, req)
+
+			if w.Code != tc.expectedStatusCode {
+				t.Fatalf("expected status code %d, got %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if tc.expectedSnapshots == nil {
+				return
+			}
+
+			var got []StatusSnapshot
+			err = json.NewDecoder(w.Body).Decode(&got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !apiequality.Semantic.DeepEqual(got, tc.expectedSnapshots) {
+				t.Errorf("expected and got snapshots differ: %s", cmp.Diff(tc.expectedSnapshots, got))
+			}
+		})
+	}
+}
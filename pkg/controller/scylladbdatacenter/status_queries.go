@@ -0,0 +1,629 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	"github.com/scylladb/scylla-operator/pkg/util/parallel"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	nodeQueryTimeout = 5 * time.Second
+
+	// defaultRackQueryTimeout bounds querying all nodes of a rack when the timeout isn't configured.
+	defaultRackQueryTimeout = 10 * time.Second
+
+	// defaultLiveQueryTimeout bounds querying ScyllaDB nodes for the status of a datacenter
+	// when the timeout isn't configured.
+	defaultLiveQueryTimeout = 30 * time.Second
+)
+
+// nodeQuery queries a value from every node and sets the values obtained from all nodes into the status.
+type nodeQuery struct {
+	valueName string
+	query     func(ctx context.Context, client *scyllaclient.Client, host string) (any, error)
+	set       func(rackValues map[string][]any)
+}
+
+// newNodeQuery makes a nodeQuery of values of type T. Nodes that can't be queried are skipped,
+// so set receives the values of the nodes that reported them, keyed by rack name.
+func newNodeQuery[T any](valueName string, query func(ctx context.Context, client *scyllaclient.Client, host string) (T, error), set func(rackValues map[string][]T)) nodeQuery {
+	return nodeQuery{
+		valueName: valueName,
+		query: func(ctx context.Context, client *scyllaclient.Client, host string) (any, error) {
+			return query(ctx, client, host)
+		},
+		set: func(rackValues map[string][]any) {
+			typedRackValues := make(map[string][]T, len(rackValues))
+			for rackName, values := range rackValues {
+				for _, v := range values {
+					typedRackValues[rackName] = append(typedRackValues[rackName], v.(T))
+				}
+			}
+
+			set(typedRackValues)
+		},
+	}
+}
+
+// clusterQuery queries a value shared by the whole cluster through any of the nodes and sets it into the status.
+type clusterQuery struct {
+	query func(ctx context.Context, client *scyllaclient.Client)
+	// unavailable, if set, updates the status when ScyllaDB API can't be contacted at all.
+	unavailable func()
+}
+
+// liveStatusQueries are the queries of the enabled status features that contact ScyllaDB nodes.
+type liveStatusQueries struct {
+	node    []nodeQuery
+	cluster []clusterQuery
+}
+
+func (q *liveStatusQueries) isEmpty() bool {
+	return len(q.node) == 0 && len(q.cluster) == 0
+}
+
+// runLiveStatusQueries runs the queries through a single ScyllaDB client. Every node is queried once for all
+// node queries, and the whole run is bounded by LiveQueryTimeout, so unreachable nodes can't hold back the sync
+// for longer, no matter how many features are enabled.
+func (sdcc *Controller) runLiveStatusQueries(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter, services map[string]*corev1.Service, queries *liveStatusQueries) {
+	if queries.isEmpty() {
+		return
+	}
+
+	liveQueryTimeout := sdcc.statusOptions.LiveQueryTimeout
+	if liveQueryTimeout == 0 {
+		liveQueryTimeout = defaultLiveQueryTimeout
+	}
+
+	ctx, ctxCancel := context.WithTimeout(ctx, liveQueryTimeout)
+	defer ctxCancel()
+
+	rackHosts := make(map[string][]string, len(sdc.Spec.Racks))
+	var allHosts []string
+	for _, rack := range sdc.Spec.Racks {
+		hosts, err := sdcc.getRackScyllaHosts(sdc, rack, services)
+		if err != nil {
+			klog.ErrorS(err, "can't get rack hosts", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rack.Name)
+			continue
+		}
+
+		rackHosts[rack.Name] = hosts
+		allHosts = append(allHosts, hosts...)
+	}
+
+	var scyllaClient *scyllaclient.Client
+	if len(allHosts) != 0 {
+		var err error
+		scyllaClient, err = sdcc.getScyllaClient(ctx, sdc, allHosts)
+		if err != nil {
+			klog.ErrorS(err, "can't get scylla client", "ScyllaDBDatacenter", naming.ObjRef(sdc))
+		} else {
+			defer scyllaClient.Close()
+		}
+	}
+
+	if scyllaClient == nil {
+		for _, q := range queries.node {
+			q.set(map[string][]any{})
+		}
+
+		for _, q := range queries.cluster {
+			if q.unavailable != nil {
+				q.unavailable()
+			}
+		}
+
+		return
+	}
+
+	rackQueryTimeout := sdcc.statusOptions.RackQueryTimeout
+	if rackQueryTimeout == 0 {
+		rackQueryTimeout = defaultRackQueryTimeout
+	}
+
+	runNodeQueries(ctx, sdc, rackHosts, rackQueryTimeout, scyllaClient, queries.node)
+
+	for _, q := range queries.cluster {
+		q.query(ctx, scyllaClient)
+	}
+}
+
+// runNodeQueries queries every host of every rack once for all the queries and sets the obtained values.
+func runNodeQueries(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter, rackHosts map[string][]string, rackQueryTimeout time.Duration, scyllaClient *scyllaclient.Client, queries []nodeQuery) {
+	if len(queries) == 0 {
+		return
+	}
+
+	valueNames := make([]string, 0, len(queries))
+	for _, q := range queries {
+		valueNames = append(valueNames, q.valueName)
+	}
+
+	rackNodeValues := queryRacks(ctx, sdc, rackHosts, rackQueryTimeout, strings.Join(valueNames, ","), func(ctx context.Context, host string) ([]*any, error) {
+		values := make([]*any, len(queries))
+		// Unsupported or failing queries are expected and skipped, so the errors are not propagated.
+		_ = parallel.ForEach(len(queries), func(i int) error {
+			value, err := queries[i].query(ctx, scyllaClient, host)
+			if err != nil {
+				klog.V(4).InfoS("Can't query node", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Host", host, "Value", queries[i].valueName, "Error", err)
+				return nil
+			}
+
+			values[i] = &value
+			return nil
+		})
+
+		return values, nil
+	})
+
+	for i, q := range queries {
+		rackValues := make(map[string][]any, len(rackNodeValues))
+		for rackName, nodeValues := range rackNodeValues {
+			for _, values := range nodeValues {
+				if values[i] != nil {
+					rackValues[rackName] = append(rackValues[rackName], *values[i])
+				}
+			}
+		}
+
+		q.set(rackValues)
+	}
+}
+
+// queryRacks calls query for every host of every rack and returns the obtained values keyed by rack name.
+// Racks are queried in parallel, each bounded by rackQueryTimeout, so a slow rack doesn't hold back the others.
+// Racks that time out are left out entirely, as if none of their nodes reported the value.
+func queryRacks[T any](ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter, rackHosts map[string][]string, rackQueryTimeout time.Duration, valueName string, query func(ctx context.Context, host string) (T, error)) map[string][]T {
+	rackNames := make([]string, 0, len(rackHosts))
+	for rackName := range rackHosts {
+		rackNames = append(rackNames, rackName)
+	}
+
+	rackResults := make([][]T, len(rackNames))
+	rackTimedOut := make([]bool, len(rackNames))
+	// Failures are handled per node and rack, so the errors are not propagated.
+	_ = parallel.ForEach(len(rackNames), func(r int) error {
+		rackName := rackNames[r]
+		hosts := rackHosts[rackName]
+
+		rackCtx, rackCtxCancel := context.WithTimeout(ctx, rackQueryTimeout)
+		defer rackCtxCancel()
+
+		values := make([]*T, len(hosts))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			// Unreachable nodes are expected and skipped, so the errors are not propagated.
+			_ = parallel.ForEach(len(hosts), func(i int) error {
+				queryCtx, queryCtxCancel := context.WithTimeout(rackCtx, nodeQueryTimeout)
+				defer queryCtxCancel()
+
+				value, err := query(queryCtx, hosts[i])
+				if err != nil {
+					klog.V(4).InfoS("Can't query node", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rackName, "Host", hosts[i], "Value", valueName, "Error", err)
+					return nil
+				}
+
+				values[i] = &value
+				return nil
+			})
+		}()
+
+		select {
+		case <-done:
+		case <-rackCtx.Done():
+			// The queries may still be writing the values, so they are abandoned rather than read.
+			klog.V(2).InfoS("Querying rack nodes timed out", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Rack", rackName, "Value", valueName, "Timeout", rackQueryTimeout)
+			rackTimedOut[r] = true
+			return nil
+		}
+
+		for _, v := range values {
+			if v != nil {
+				rackResults[r] = append(rackResults[r], *v)
+			}
+		}
+
+		return nil
+	})
+
+	rackValues := make(map[string][]T, len(rackNames))
+	for r, rackName := range rackNames {
+		if !rackTimedOut[r] && len(rackResults[r]) != 0 {
+			rackValues[rackName] = rackResults[r]
+		}
+	}
+
+	return rackValues
+}
+
+// addSchemaVersionsQuery queries the schema version of every node in the datacenter to report it in the status.
+func (sdcc *Controller) addSchemaVersionsQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("SchemaVersion", func(ctx context.Context, client *scyllaclient.Client, host string) (string, error) {
+		return client.GetSchemaVersion(ctx, host, false)
+	}, func(rackSchemaVersions map[string][]string) {
+		setSchemaVersionStatus(sdc, status, rackSchemaVersions)
+	}))
+}
+
+// addSchemaMigratingNodesQuery queries every node in the datacenter for its schema version and the schema versions
+// it observes in the cluster, to report the number of nodes still applying schema changes in the status.
+func (sdcc *Controller) addSchemaMigratingNodesQuery(queries *liveStatusQueries, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("SchemaMigrating", func(ctx context.Context, client *scyllaclient.Client, host string) (bool, error) {
+		schemaVersion, err := client.GetSchemaVersion(ctx, host, false)
+		if err != nil {
+			return false, err
+		}
+
+		schemaVersions, err := client.SchemaVersions(ctx, host)
+		if err != nil {
+			return false, err
+		}
+
+		return isSchemaMigrating(schemaVersion, schemaVersions), nil
+	}, func(rackMigrating map[string][]bool) {
+		setSchemaMigratingNodesStatus(status, rackMigrating)
+	}))
+}
+
+// addCompactedNodesQuery queries every node in the datacenter for its compaction history to report the number
+// of nodes that have completed their first compaction of user data in the status.
+func (sdcc *Controller) addCompactedNodesQuery(queries *liveStatusQueries, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("Compacted", func(ctx context.Context, client *scyllaclient.Client, host string) (bool, error) {
+		keyspaces, err := client.CompactedKeyspaces(ctx, host)
+		if err != nil {
+			return false, err
+		}
+
+		return hasCompactedUserData(keyspaces), nil
+	}, func(rackCompacted map[string][]bool) {
+		setCompactedNodesStatus(status, rackCompacted)
+	}))
+}
+
+// addShardCountsQuery queries the shard count of every node in the datacenter to report it in the status.
+func (sdcc *Controller) addShardCountsQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("ShardCount", func(ctx context.Context, client *scyllaclient.Client, host string) (int32, error) {
+		return client.ShardCount(ctx, host)
+	}, func(rackShardCounts map[string][]int32) {
+		setShardCountStatus(sdc, status, rackShardCounts)
+	}))
+}
+
+func (sdcc *Controller) addCQLConnectionsQuery(queries *liveStatusQueries, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("CQLConnections", func(ctx context.Context, client *scyllaclient.Client, host string) (int32, error) {
+		return client.CQLConnections(ctx, host)
+	}, func(rackCQLConnections map[string][]int32) {
+		setCQLConnectionsStatus(status, rackCQLConnections)
+	}))
+}
+
+// addNodeLoadsQuery queries the data bytes stored on every node, which both DataUsage and DiskPressure
+// are derived from.
+func (sdcc *Controller) addNodeLoadsQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	queries.node = append(queries.node, newNodeQuery("Load", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeLoad, error) {
+		load, err := client.Load(ctx, host)
+		if err != nil {
+			return nodeLoad{}, err
+		}
+
+		return nodeLoad{host: host, bytes: load}, nil
+	}, func(rackLoads map[string][]nodeLoad) {
+		if sdcc.statusOptions.DataUsage {
+			setUsedDataBytesStatus(&sdc.Status, status, rackLoads)
+		}
+		if sdcc.statusOptions.DiskPressure {
+			sdcc.setDiskPressure(sdc, status, services, rackLoads)
+		}
+	}))
+}
+
+func (sdcc *Controller) addNodeUptimesQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	now := time.Now()
+	queries.node = append(queries.node, newNodeQuery("Uptime", func(ctx context.Context, client *scyllaclient.Client, host string) (time.Time, error) {
+		uptime, err := client.Uptime(ctx, host)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return now.Add(-uptime), nil
+	}, func(rackStartTimes map[string][]time.Time) {
+		setNodeStartTimesStatus(&sdc.Status, status, rackStartTimes)
+	}))
+}
+
+func (sdcc *Controller) addTokenRangeOverlapQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("Tokens", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeTokens, error) {
+		tokens, err := client.GetNodeTokens(ctx, host, host)
+		if err != nil {
+			return nodeTokens{}, err
+		}
+
+		return nodeTokens{host: host, tokens: tokens}, nil
+	}, func(rackNodeTokens map[string][]nodeTokens) {
+		setTokenRangeOverlapStatusCondition(sdc, status, rackNodeTokens)
+	}))
+}
+
+func (sdcc *Controller) addSeedReachabilityQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, services map[string]*corev1.Service) {
+	seeds := sdcc.getSeeds(sdc, services)
+
+	queries.node = append(queries.node, newNodeQuery("GossipView", func(ctx context.Context, client *scyllaclient.Client, host string) ([]string, error) {
+		nodeStatuses, err := client.Status(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var upHosts []string
+		for _, s := range nodeStatuses {
+			if s.Status == scyllaclient.NodeStatusUp {
+				upHosts = append(upHosts, s.Addr)
+			}
+		}
+
+		return upHosts, nil
+	}, func(rackUpHosts map[string][][]string) {
+		setSeedUnreachableStatusCondition(sdc, status, seeds, rackUpHosts)
+	}))
+}
+
+func (sdcc *Controller) addDataReplicationQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.cluster = append(queries.cluster, clusterQuery{
+		query: func(ctx context.Context, client *scyllaclient.Client) {
+			downHosts, keyspaceReplicas := getKeyspaceReplication(ctx, sdc, client)
+			setDataUnderReplicatedStatusCondition(sdc, status, downHosts, keyspaceReplicas)
+		},
+		unavailable: func() {
+			setDataUnderReplicatedStatusCondition(sdc, status, nil, nil)
+		},
+	})
+}
+
+// getKeyspaceReplication returns the nodes that are down and the replicas of each token range of every keyspace
+// whose ring could be described. Keyspaces are nil when the replication couldn't be determined at all.
+func getKeyspaceReplication(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter, scyllaClient *scyllaclient.Client) ([]string, map[string][][]string) {
+	queryCtx, queryCtxCancel := context.WithTimeout(ctx, nodeQueryTimeout)
+	defer queryCtxCancel()
+
+	nodeStatuses, err := scyllaClient.Status(queryCtx, "")
+	if err != nil {
+		klog.V(2).InfoS("Can't get node statuses", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Error", err)
+		return nil, nil
+	}
+
+	keyspaces, err := scyllaClient.Keyspaces(queryCtx)
+	if err != nil {
+		klog.V(2).InfoS("Can't get keyspaces", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Error", err)
+		return nil, nil
+	}
+
+	downHosts := nodeStatuses.DownHosts()
+
+	keyspaceReplicas := make(map[string][][]string, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		replicas, err := scyllaClient.KeyspaceReplicas(queryCtx, keyspace)
+		if err != nil {
+			// Rings of keyspaces that aren't replicated, like the local system ones, can't be described.
+			klog.V(4).InfoS("Can't get keyspace replicas", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Keyspace", keyspace, "Error", err)
+			continue
+		}
+
+		keyspaceReplicas[keyspace] = replicas
+	}
+
+	return downHosts, keyspaceReplicas
+}
+
+func (sdcc *Controller) addTopologyQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("Topology", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeTopology, error) {
+		datacenter, err := client.GetSnitchDatacenter(ctx, host)
+		if err != nil {
+			return nodeTopology{}, err
+		}
+
+		rack, err := client.GetSnitchRack(ctx, host)
+		if err != nil {
+			return nodeTopology{}, err
+		}
+
+		return nodeTopology{host: host, datacenter: datacenter, rack: rack}, nil
+	}, func(rackNodeTopologies map[string][]nodeTopology) {
+		setTopologyMismatchStatusCondition(sdc, status, rackNodeTopologies)
+	}))
+}
+
+func (sdcc *Controller) addWorkloadPrioritizationQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	expectedServiceLevels := getExpectedServiceLevels(sdc)
+	if len(expectedServiceLevels) == 0 {
+		// Workload prioritization isn't in use, so there is nothing to report.
+		apimeta.RemoveStatusCondition(&status.Conditions, scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition)
+		return
+	}
+
+	queries.node = append(queries.node, newNodeQuery("ServiceLevels", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeServiceLevels, error) {
+		serviceLevels, err := client.ServiceLevelSchedulingGroups(ctx, host)
+		if err != nil {
+			return nodeServiceLevels{}, err
+		}
+
+		return nodeServiceLevels{host: host, serviceLevels: serviceLevels}, nil
+	}, func(rackNodeServiceLevels map[string][]nodeServiceLevels) {
+		setWorkloadPrioritizationMisconfiguredStatusCondition(sdc, status, expectedServiceLevels, rackNodeServiceLevels)
+	}))
+}
+
+func (sdcc *Controller) addConsistentTopologyQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	queries.node = append(queries.node, newNodeQuery("TopologyUpgradeState", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeTopologyUpgradeState, error) {
+		state, err := client.TopologyUpgradeState(ctx, host)
+		if err != nil {
+			if errors.Is(err, scyllaclient.ErrTopologyUpgradeStateUnsupported) {
+				return nodeTopologyUpgradeState{host: host}, nil
+			}
+
+			return nodeTopologyUpgradeState{}, err
+		}
+
+		return nodeTopologyUpgradeState{host: host, state: state}, nil
+	}, func(rackNodeStates map[string][]nodeTopologyUpgradeState) {
+		setConsistentTopologyEnabledStatusCondition(sdc, status, rackNodeStates)
+	}))
+}
+
+func (sdcc *Controller) addLargePartitionsQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	threshold := sdcc.statusOptions.LargePartitionThresholdBytes
+	if threshold == 0 {
+		threshold = DefaultLargePartitionThresholdBytes
+	}
+
+	queries.node = append(queries.node, newNodeQuery("MaxPartitionSize", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeMaxPartitionSize, error) {
+		size, err := client.MaxPartitionSize(ctx, host)
+		if err != nil {
+			return nodeMaxPartitionSize{}, err
+		}
+
+		return nodeMaxPartitionSize{host: host, bytes: size}, nil
+	}, func(rackSizes map[string][]nodeMaxPartitionSize) {
+		setMaxPartitionBytesStatus(status, rackSizes)
+		setLargePartitionDetectedStatusCondition(sdc, status, rackSizes, threshold)
+	}))
+}
+
+func (sdcc *Controller) addClockSkewQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	threshold := sdcc.statusOptions.ClockSkewThreshold
+	if threshold == 0 {
+		threshold = DefaultClockSkewThreshold
+	}
+
+	queries.node = append(queries.node, newNodeQuery("ClockOffset", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeClockOffset, error) {
+		offset, err := client.ClockOffset(ctx, host)
+		if err != nil {
+			return nodeClockOffset{}, err
+		}
+
+		return nodeClockOffset{host: host, offset: offset}, nil
+	}, func(rackOffsets map[string][]nodeClockOffset) {
+		rackSkews := getClockSkews(rackOffsets)
+		setClockSkewedNodesStatus(status, rackSkews, threshold)
+		setClockSkewDetectedStatusCondition(sdc, status, rackSkews, threshold)
+	}))
+}
+
+func (sdcc *Controller) addSnapshotsQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus) {
+	threshold := sdcc.statusOptions.SnapshotThresholdBytes
+	if threshold == 0 {
+		threshold = DefaultSnapshotThresholdBytes
+	}
+
+	queries.node = append(queries.node, newNodeQuery("SnapshotSize", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeSnapshotSize, error) {
+		size, err := client.SnapshotSize(ctx, host)
+		if err != nil {
+			return nodeSnapshotSize{}, err
+		}
+
+		return nodeSnapshotSize{host: host, bytes: size}, nil
+	}, func(rackSizes map[string][]nodeSnapshotSize) {
+		setSnapshotBytesStatus(status, rackSizes)
+		setStaleSnapshotsDetectedStatusCondition(sdc, status, rackSizes, threshold)
+	}))
+}
+
+type nodeLatencies struct {
+	read  *time.Duration
+	write *time.Duration
+}
+
+// addLatencyQuery refreshes the reported latencies once they are stale. Latencies are left unset
+// when they can't be determined for any node.
+func (sdcc *Controller) addLatencyQuery(queries *liveStatusQueries, status *scyllav1alpha1.ScyllaDBDatacenterStatus, now metav1.Time) {
+	if !isLatencyStale(status.Latency, now, sdcc.statusOptions.LatencyRefreshInterval) {
+		return
+	}
+
+	queries.node = append(queries.node, newNodeQuery("CoordinatorLatencyQuantile", func(ctx context.Context, client *scyllaclient.Client, host string) (nodeLatencies, error) {
+		var latencies nodeLatencies
+		var errs []error
+
+		read, err := client.CoordinatorLatencyQuantile(ctx, host, scyllaclient.ReadOperation, latencyQuantile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get read latency: %w", err))
+		} else {
+			latencies.read = &read
+		}
+
+		write, err := client.CoordinatorLatencyQuantile(ctx, host, scyllaclient.WriteOperation, latencyQuantile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't get write latency: %w", err))
+		} else {
+			latencies.write = &write
+		}
+
+		if latencies.read == nil && latencies.write == nil {
+			return nodeLatencies{}, errors.Join(errs...)
+		}
+
+		return latencies, nil
+	}, func(rackLatencies map[string][]nodeLatencies) {
+		rackReadLatencies := map[string][]time.Duration{}
+		rackWriteLatencies := map[string][]time.Duration{}
+		for rackName, latencies := range rackLatencies {
+			for _, l := range latencies {
+				if l.read != nil {
+					rackReadLatencies[rackName] = append(rackReadLatencies[rackName], *l.read)
+				}
+				if l.write != nil {
+					rackWriteLatencies[rackName] = append(rackWriteLatencies[rackName], *l.write)
+				}
+			}
+		}
+
+		status.Latency = makeLatencyStatus(rackReadLatencies, rackWriteLatencies, now)
+	}))
+}
+
+func (sdcc *Controller) addIntraRackVersionSkewQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, now metav1.Time) {
+	gracePeriod := sdcc.statusOptions.IntraRackVersionSkewGracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = DefaultIntraRackVersionSkewGracePeriod
+	}
+
+	queries.node = append(queries.node, newNodeQuery("ScyllaVersion", func(ctx context.Context, client *scyllaclient.Client, host string) (string, error) {
+		return client.NodeScyllaVersion(ctx, host)
+	}, func(rackVersions map[string][]string) {
+		setIntraRackVersionSkewStatus(sdc, &sdc.Status, status, rackVersions, gracePeriod, now)
+	}))
+}
+
+// addSchemaOverviewQuery refreshes the schema overview once it's stale. It is queried from any of the nodes,
+// as the schema is shared by the whole cluster. The previous overview is kept when it can't be refreshed.
+func (sdcc *Controller) addSchemaOverviewQuery(queries *liveStatusQueries, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, now metav1.Time) {
+	if !isSchemaOverviewStale(status.Schema, now, sdcc.statusOptions.SchemaOverviewRefreshInterval) {
+		return
+	}
+
+	queries.cluster = append(queries.cluster, clusterQuery{
+		query: func(ctx context.Context, client *scyllaclient.Client) {
+			queryCtx, queryCtxCancel := context.WithTimeout(ctx, nodeQueryTimeout)
+			defer queryCtxCancel()
+
+			tableCounts, err := client.KeyspaceTableCounts(queryCtx)
+			if err != nil {
+				klog.V(2).InfoS("Can't refresh schema overview", "ScyllaDBDatacenter", naming.ObjRef(sdc), "Error", err)
+				return
+			}
+
+			status.Schema = makeSchemaStatus(tableCounts, now)
+		},
+	})
+}
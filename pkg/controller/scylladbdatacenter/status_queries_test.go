@@ -0,0 +1,187 @@
+package scylladbdatacenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestQueryRacks(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+	}
+
+	rackHosts := map[string][]string{
+		"a": {"10.0.0.1", "10.0.0.2"},
+		"b": {"10.0.1.1", "10.0.1.2"},
+		"c": {"10.0.2.1"},
+	}
+
+	// The slow node doesn't respect the context, so the rack can only be given up on by its timeout.
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	query := func(ctx context.Context, host string) (string, error) {
+		switch host {
+		case "10.0.1.2":
+			<-unblock
+			return "", errors.New("unblocked")
+		case "10.0.2.1":
+			return "", errors.New("unreachable")
+		default:
+			return "value-" + host, nil
+		}
+	}
+
+	start := time.Now()
+	got := queryRacks(context.Background(), sdc, rackHosts, 100*time.Millisecond, "Test", query)
+	elapsed := time.Since(start)
+
+	expected := map[string][]string{
+		"a": {"value-10.0.0.1", "value-10.0.0.2"},
+	}
+	if !apiequality.Semantic.DeepEqual(got, expected) {
+		t.Errorf("expected and got rack values differ: %s", cmp.Diff(expected, got))
+	}
+
+	if elapsed > 5*time.Second {
+		t.Errorf("expected the slow rack to be given up on after its timeout, querying took %v", elapsed)
+	}
+}
+
+func TestRunNodeQueries(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+	}
+
+	rackHosts := map[string][]string{
+		"a": {"10.0.0.1", "10.0.0.2"},
+		"b": {"10.0.1.1"},
+		"c": {"10.0.2.1"},
+	}
+
+	expectedQueriedHostCounts := map[string]int{
+		"10.0.0.1": 1,
+		"10.0.0.2": 1,
+		"10.0.1.1": 1,
+		"10.0.2.1": 1,
+	}
+
+	tt := []struct {
+		name                    string
+		rackQueryTimeout        time.Duration
+		liveQueryTimeout        time.Duration
+		expectedRackVersions    map[string][]string
+		expectedRackShardCounts map[string][]int32
+	}{
+		{
+			name:             "every node is queried once for all queries and a slow rack is left out of all of them",
+			rackQueryTimeout: 100 * time.Millisecond,
+			liveQueryTimeout: time.Minute,
+			expectedRackVersions: map[string][]string{
+				"a": {"version-10.0.0.1", "version-10.0.0.2"},
+			},
+			expectedRackShardCounts: map[string][]int32{
+				"a": {2, 2},
+				"b": {2},
+			},
+		},
+		{
+			name:             "slow rack is given up on once the live query timeout passes",
+			rackQueryTimeout: time.Minute,
+			liveQueryTimeout: 100 * time.Millisecond,
+			expectedRackVersions: map[string][]string{
+				"a": {"version-10.0.0.1", "version-10.0.0.2"},
+			},
+			expectedRackShardCounts: map[string][]int32{
+				"a": {2, 2},
+				"b": {2},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The slow node doesn't respect the context, so its rack can only be given up on by a timeout.
+			unblock := make(chan struct{})
+			defer close(unblock)
+
+			var queriedHostsLock sync.Mutex
+			queriedHostCounts := map[string]int{}
+
+			var gotRackVersions map[string][]string
+			var gotRackShardCounts map[string][]int32
+			queries := []nodeQuery{
+				newNodeQuery("ScyllaVersion", func(ctx context.Context, client *scyllaclient.Client, host string) (string, error) {
+					queriedHostsLock.Lock()
+					queriedHostCounts[host]++
+					queriedHostsLock.Unlock()
+
+					if host == "10.0.1.1" {
+						return "", errors.New("unsupported")
+					}
+
+					return "version-" + host, nil
+				}, func(rackVersions map[string][]string) {
+					gotRackVersions = rackVersions
+				}),
+				newNodeQuery("ShardCount", func(ctx context.Context, client *scyllaclient.Client, host string) (int32, error) {
+					if host == "10.0.2.1" {
+						<-unblock
+						return 0, fmt.Errorf("unblocked")
+					}
+
+					return 2, nil
+				}, func(rackShardCounts map[string][]int32) {
+					gotRackShardCounts = rackShardCounts
+				}),
+			}
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), tc.liveQueryTimeout)
+			defer ctxCancel()
+
+			start := time.Now()
+			runNodeQueries(ctx, sdc, rackHosts, tc.rackQueryTimeout, nil, queries)
+			elapsed := time.Since(start)
+
+			if !apiequality.Semantic.DeepEqual(gotRackVersions, tc.expectedRackVersions) {
+				t.Errorf("expected and got rack versions differ: %s", cmp.Diff(tc.expectedRackVersions, gotRackVersions))
+			}
+
+			if !apiequality.Semantic.DeepEqual(gotRackShardCounts, tc.expectedRackShardCounts) {
+				t.Errorf("expected and got rack shard counts differ: %s", cmp.Diff(tc.expectedRackShardCounts, gotRackShardCounts))
+			}
+
+			queriedHostsLock.Lock()
+			defer queriedHostsLock.Unlock()
+			if !apiequality.Semantic.DeepEqual(queriedHostCounts, expectedQueriedHostCounts) {
+				t.Errorf("expected and got queried host counts differ: %s", cmp.Diff(expectedQueriedHostCounts, queriedHostCounts))
+			}
+
+			if elapsed > 5*time.Second {
+				t.Errorf("expected the slow rack to be given up on after a timeout, querying took %v", elapsed)
+			}
+		})
+	}
+}
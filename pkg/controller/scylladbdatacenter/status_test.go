@@ -0,0 +1,5729 @@
+package scylladbdatacenter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/internalapi"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/version"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestController_calculateStatus_NoRacksDefined(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(racks []scyllav1alpha1.RackSpec) *scyllav1alpha1.ScyllaDBDatacenter {
+		return &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "basic",
+				Namespace:  "default",
+				Generation: 2,
+			},
+			Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+				ClusterName:    "basic",
+				DatacenterName: pointer.Ptr("dc"),
+				ScyllaDB: scyllav1alpha1.ScyllaDB{
+					Image: "scylladb/scylla:6.2.0",
+				},
+				Racks: racks,
+			},
+		}
+	}
+
+	tt := []struct {
+		name                       string
+		sdc                        *scyllav1alpha1.ScyllaDBDatacenter
+		statefulSets               map[string]*appsv1.StatefulSet
+		expectedNoRacksCondition   *metav1.Condition
+		expectedAvailableCondition *metav1.Condition
+	}{
+		{
+			name:         "datacenter with zero racks reports NoRacksDefined and isn't available",
+			sdc:          newSDC(nil),
+			statefulSets: map[string]*appsv1.StatefulSet{},
+			expectedNoRacksCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.NoRacksDefinedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "NoRacksDefined",
+				Message:            "ScyllaDBDatacenter doesn't define any racks.",
+				ObservedGeneration: 2,
+			},
+			expectedAvailableCondition: &metav1.Condition{
+				Type:               statefulSetControllerAvailableCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NoRacksDefined",
+				Message:            "No racks are defined",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "datacenter with racks doesn't report NoRacksDefined",
+			sdc: newSDC([]scyllav1alpha1.RackSpec{
+				{
+					Name: "rack",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(0)),
+					},
+				},
+			}),
+			statefulSets: map[string]*appsv1.StatefulSet{
+				"basic-dc-rack": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "basic-dc-rack",
+						Namespace: "default",
+						Labels: map[string]string{
+							naming.RackNameLabel: "rack",
+						},
+					},
+					Spec: appsv1.StatefulSetSpec{
+						Replicas: pointer.Ptr(int32(0)),
+					},
+				},
+			},
+			expectedNoRacksCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.NoRacksDefinedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+			expectedAvailableCondition: &metav1.Condition{
+				Type:               statefulSetControllerAvailableCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sdcc := &Controller{
+				podLister:     corev1listers.NewPodLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+				eventRecorder: record.NewFakeRecorder(10),
+			}
+
+			status := sdcc.calculateStatus(tc.sdc, tc.statefulSets, nil)
+			sdcc.setStatefulSetsAvailableStatusCondition(tc.sdc, status)
+
+			if *status.Nodes != 0 {
+				t.Errorf("expected 0 nodes, got %d", *status.Nodes)
+			}
+
+			gotNoRacksCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.NoRacksDefinedCondition)
+			if gotNoRacksCondition != nil {
+				gotNoRacksCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotNoRacksCondition, tc.expectedNoRacksCondition) {
+				t.Errorf("expected and got NoRacksDefined conditions differ: %s", cmp.Diff(tc.expectedNoRacksCondition, gotNoRacksCondition))
+			}
+
+			gotAvailableCondition := apimeta.FindStatusCondition(status.Conditions, statefulSetControllerAvailableCondition)
+			if gotAvailableCondition != nil {
+				gotAvailableCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotAvailableCondition, tc.expectedAvailableCondition) {
+				t.Errorf("expected and got available conditions differ: %s", cmp.Diff(tc.expectedAvailableCondition, gotAvailableCondition))
+			}
+		})
+	}
+}
+
+func TestController_calculateStatus_ManagedByOperatorVersion(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName: "basic",
+			ScyllaDB: scyllav1alpha1.ScyllaDB{
+				Image: "scylladb/scylla:6.2.0",
+			},
+		},
+		Status: scyllav1alpha1.ScyllaDBDatacenterStatus{
+			LastReconcileTime:        pointer.Ptr(metav1.NewTime(now)),
+			ManagedByOperatorVersion: "v1.15.0",
+		},
+	}
+
+	sdcc := &Controller{
+		podLister:     corev1listers.NewPodLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+		eventRecorder: record.NewFakeRecorder(10),
+	}
+
+	status := sdcc.calculateStatus(sdc, map[string]*appsv1.StatefulSet{}, nil)
+	if status.ManagedByOperatorVersion != version.Get().GitVersion {
+		t.Errorf("expected operator version %q, got %q", version.Get().GitVersion, status.ManagedByOperatorVersion)
+	}
+
+	if isStatusUpToDate(&sdc.Status, status, now) {
+		t.Errorf("expected status reconciled by another operator version to require an update")
+	}
+
+	sdc.Status = *status
+	if !isStatusUpToDate(&sdc.Status, sdcc.calculateStatus(sdc, map[string]*appsv1.StatefulSet{}, nil), now) {
+		t.Errorf("expected status reconciled by the same operator version not to require an update")
+	}
+}
+
+func TestUpdateLastFullyAvailableTime(t *testing.T) {
+	t.Parallel()
+
+	earlier := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	newStatus := func(nodes, readyNodes int32, lastFullyAvailableTime *metav1.Time) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Nodes:                  pointer.Ptr(nodes),
+			ReadyNodes:             pointer.Ptr(readyNodes),
+			LastFullyAvailableTime: lastFullyAvailableTime,
+		}
+	}
+
+	tt := []struct {
+		name     string
+		old      *scyllav1alpha1.ScyllaDBDatacenterStatus
+		new      *scyllav1alpha1.ScyllaDBDatacenterStatus
+		expected *metav1.Time
+	}{
+		{
+			name:     "never fully available datacenter has no time set",
+			old:      &scyllav1alpha1.ScyllaDBDatacenterStatus{},
+			new:      newStatus(3, 2, nil),
+			expected: nil,
+		},
+		{
+			name:     "datacenter without nodes isn't fully available",
+			old:      &scyllav1alpha1.ScyllaDBDatacenterStatus{},
+			new:      newStatus(0, 0, nil),
+			expected: nil,
+		},
+		{
+			name:     "transition into full availability records the time",
+			old:      newStatus(3, 2, &earlier),
+			new:      newStatus(3, 3, &earlier),
+			expected: &now,
+		},
+		{
+			name:     "transition out of full availability records the time",
+			old:      newStatus(3, 3, &earlier),
+			new:      newStatus(3, 2, &earlier),
+			expected: &now,
+		},
+		{
+			name:     "staying fully available keeps the time",
+			old:      newStatus(3, 3, &earlier),
+			new:      newStatus(3, 3, &earlier),
+			expected: &earlier,
+		},
+		{
+			name:     "staying not fully available keeps the time",
+			old:      newStatus(3, 2, &earlier),
+			new:      newStatus(3, 1, &earlier),
+			expected: &earlier,
+		},
+		{
+			name:     "scaling out of a fully available datacenter leaves full availability",
+			old:      newStatus(3, 3, &earlier),
+			new:      newStatus(4, 3, &earlier),
+			expected: &now,
+		},
+		{
+			name:     "fully available datacenter without recorded time gets it set",
+			old:      newStatus(3, 3, nil),
+			new:      newStatus(3, 3, nil),
+			expected: &now,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			updateLastFullyAvailableTime(tc.old, tc.new, now)
+
+			if !apiequality.Semantic.DeepEqual(tc.new.LastFullyAvailableTime, tc.expected) {
+				t.Errorf("expected and got last fully available time differ: %s", cmp.Diff(tc.expected, tc.new.LastFullyAvailableTime))
+			}
+		})
+	}
+}
+
+func TestIsStatusUpToDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	recent := metav1.NewTime(now.Add(-time.Minute))
+	old := metav1.NewTime(now.Add(-lastReconcileTimeRefreshInterval))
+
+	newStatus := func(readyNodes int32, lastReconcileTime *metav1.Time) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Nodes:             pointer.Ptr(int32(3)),
+			ReadyNodes:        pointer.Ptr(readyNodes),
+			LastReconcileTime: lastReconcileTime,
+		}
+	}
+
+	tt := []struct {
+		name          string
+		currentStatus *scyllav1alpha1.ScyllaDBDatacenterStatus
+		status        *scyllav1alpha1.ScyllaDBDatacenterStatus
+		expected      bool
+	}{
+		{
+			name:          "unchanged status with recent reconcile time is up to date",
+			currentStatus: newStatus(3, &recent),
+			status:        newStatus(3, &recent),
+			expected:      true,
+		},
+		{
+			name:          "differing reconcile time alone doesn't require an update",
+			currentStatus: newStatus(3, &recent),
+			status:        newStatus(3, nil),
+			expected:      true,
+		},
+		{
+			name:          "changed status requires an update",
+			currentStatus: newStatus(3, &recent),
+			status:        newStatus(2, &recent),
+			expected:      false,
+		},
+		{
+			name:          "missing reconcile time requires an update",
+			currentStatus: newStatus(3, nil),
+			status:        newStatus(3, nil),
+			expected:      false,
+		},
+		{
+			name:          "old reconcile time requires an update",
+			currentStatus: newStatus(3, &old),
+			status:        newStatus(3, &old),
+			expected:      false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := isStatusUpToDate(tc.currentStatus, tc.status, now)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetStatusUpdateSuppressionEnd(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	newSDC := func(annotations map[string]string) *scyllav1alpha1.ScyllaDBDatacenter {
+		return &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "basic",
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+		}
+	}
+
+	tt := []struct {
+		name               string
+		sdc                *scyllav1alpha1.ScyllaDBDatacenter
+		expectedEnd        time.Time
+		expectedSuppressed bool
+	}{
+		{
+			name:               "updates aren't suppressed without the annotation",
+			sdc:                newSDC(nil),
+			expectedEnd:        time.Time{},
+			expectedSuppressed: false,
+		},
+		{
+			name: "updates are suppressed within the window",
+			sdc: newSDC(map[string]string{
+				naming.SuppressStatusUpdatesUntilAnnotation: now.Add(10 * time.Minute).Format(time.RFC3339),
+			}),
+			expectedEnd:        now.Add(10 * time.Minute),
+			expectedSuppressed: true,
+		},
+		{
+			name: "updates resume once the window passed",
+			sdc: newSDC(map[string]string{
+				naming.SuppressStatusUpdatesUntilAnnotation: now.Format(time.RFC3339),
+			}),
+			expectedEnd:        time.Time{},
+			expectedSuppressed: false,
+		},
+		{
+			name: "window is capped",
+			sdc: newSDC(map[string]string{
+				naming.SuppressStatusUpdatesUntilAnnotation: now.Add(24 * time.Hour).Format(time.RFC3339),
+			}),
+			expectedEnd:        now.Add(maxStatusUpdateSuppression),
+			expectedSuppressed: true,
+		},
+		{
+			name: "malformed time doesn't suppress updates",
+			sdc: newSDC(map[string]string{
+				naming.SuppressStatusUpdatesUntilAnnotation: "tomorrow",
+			}),
+			expectedEnd:        time.Time{},
+			expectedSuppressed: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotEnd, gotSuppressed := getStatusUpdateSuppressionEnd(tc.sdc, now)
+			if gotSuppressed != tc.expectedSuppressed {
+				t.Errorf("expected suppressed %t, got %t", tc.expectedSuppressed, gotSuppressed)
+			}
+			if !gotEnd.Equal(tc.expectedEnd) {
+				t.Errorf("expected end %v, got %v", tc.expectedEnd, gotEnd)
+			}
+		})
+	}
+}
+
+func TestMakeSuppressedStatus(t *testing.T) {
+	t.Parallel()
+
+	newStatus := func(observedGeneration int64, readyNodes int32) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			ObservedGeneration: pointer.Ptr(observedGeneration),
+			Nodes:              pointer.Ptr(int32(3)),
+			ReadyNodes:         pointer.Ptr(readyNodes),
+		}
+	}
+
+	tt := []struct {
+		name          string
+		currentStatus *scyllav1alpha1.ScyllaDBDatacenterStatus
+		status        *scyllav1alpha1.ScyllaDBDatacenterStatus
+		expected      *scyllav1alpha1.ScyllaDBDatacenterStatus
+	}{
+		{
+			name:          "changes are suppressed when the observed generation is the same",
+			currentStatus: newStatus(1, 3),
+			status:        newStatus(1, 2),
+			expected:      nil,
+		},
+		{
+			name:          "only observed generation is carried over",
+			currentStatus: newStatus(1, 3),
+			status:        newStatus(2, 2),
+			expected:      newStatus(2, 3),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := makeSuppressedStatus(tc.currentStatus, tc.status)
+			if !apiequality.Semantic.DeepEqual(got, tc.expected) {
+				t.Errorf("expected and got statuses differ: %s", cmp.Diff(tc.expected, got))
+			}
+		})
+	}
+}
+
+func TestUpdateAggregatedStatusFields_UpdatedPercent(t *testing.T) {
+	t.Parallel()
+
+	newRackStatus := func(name string, nodes int32, updatedNodes *int32) scyllav1alpha1.RackStatus {
+		return scyllav1alpha1.RackStatus{
+			Name:           name,
+			Nodes:          pointer.Ptr(nodes),
+			UpdatedNodes:   updatedNodes,
+			ReadyNodes:     pointer.Ptr(nodes),
+			AvailableNodes: pointer.Ptr(nodes),
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		racks                  []scyllav1alpha1.RackStatus
+		expectedUpdatedPercent int32
+	}{
+		{
+			name:                   "no racks count as fully updated",
+			racks:                  []scyllav1alpha1.RackStatus{},
+			expectedUpdatedPercent: 100,
+		},
+		{
+			name: "racks with zero nodes count as fully updated",
+			racks: []scyllav1alpha1.RackStatus{
+				newRackStatus("a", 0, pointer.Ptr(int32(0))),
+			},
+			expectedUpdatedPercent: 100,
+		},
+		{
+			name: "partial rollout is rounded down",
+			racks: []scyllav1alpha1.RackStatus{
+				newRackStatus("a", 3, pointer.Ptr(int32(3))),
+				newRackStatus("b", 3, pointer.Ptr(int32(1))),
+				newRackStatus("c", 3, nil),
+			},
+			expectedUpdatedPercent: 44,
+		},
+		{
+			name: "complete rollout",
+			racks: []scyllav1alpha1.RackStatus{
+				newRackStatus("a", 3, pointer.Ptr(int32(3))),
+				newRackStatus("b", 3, pointer.Ptr(int32(3))),
+			},
+			expectedUpdatedPercent: 100,
+		},
+		{
+			name: "updated nodes exceeding requested nodes during scale down don't overflow",
+			racks: []scyllav1alpha1.RackStatus{
+				newRackStatus("a", 2, pointer.Ptr(int32(3))),
+				newRackStatus("b", 2, pointer.Ptr(int32(0))),
+			},
+			expectedUpdatedPercent: 50,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				Racks: tc.racks,
+			}
+			updateAggregatedStatusFields(status)
+
+			if status.UpdatedPercent == nil {
+				t.Fatalf("expected updated percent to be set")
+			}
+
+			if *status.UpdatedPercent != tc.expectedUpdatedPercent {
+				t.Errorf("expected updated percent %d, got %d", tc.expectedUpdatedPercent, *status.UpdatedPercent)
+			}
+		})
+	}
+}
+
+func TestController_calculateRackStatus_IgnitionPendingNodes(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName: "basic",
+			ScyllaDB: scyllav1alpha1.ScyllaDB{
+				Image: "scylladb/scylla:6.2.0",
+			},
+		},
+	}
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic-dc-rack",
+			Namespace: "default",
+			UID:       "sts-uid",
+			Labels: map[string]string{
+				naming.RackNameLabel: "rack",
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Ptr(int32(4)),
+		},
+	}
+
+	newPod := func(ordinal int, controllerUID types.UID, ignitionReady *bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-rack-%d", ordinal),
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "StatefulSet",
+						Name:       "basic-dc-rack",
+						UID:        controllerUID,
+						Controller: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  naming.ScyllaContainerName,
+						Image: "scylladb/scylla:6.2.0",
+					},
+				},
+			},
+		}
+
+		if ignitionReady != nil {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name:  naming.ScyllaDBIgnitionContainerName,
+					Ready: *ignitionReady,
+				},
+			}
+		}
+
+		return pod
+	}
+
+	tt := []struct {
+		name                         string
+		pods                         []*corev1.Pod
+		expectedIgnitionPendingNodes int32
+	}{
+		{
+			name: "all pods have ignition ready",
+			pods: []*corev1.Pod{
+				newPod(0, "sts-uid", pointer.Ptr(true)),
+				newPod(1, "sts-uid", pointer.Ptr(true)),
+				newPod(2, "sts-uid", pointer.Ptr(true)),
+				newPod(3, "sts-uid", pointer.Ptr(true)),
+			},
+			expectedIgnitionPendingNodes: 0,
+		},
+		{
+			name: "mixed ignition ready and not ready pods",
+			pods: []*corev1.Pod{
+				newPod(0, "sts-uid", pointer.Ptr(true)),
+				newPod(1, "sts-uid", pointer.Ptr(false)),
+				newPod(2, "sts-uid", nil),
+				newPod(3, "sts-uid", pointer.Ptr(true)),
+			},
+			expectedIgnitionPendingNodes: 2,
+		},
+		{
+			name: "missing and foreign pods aren't counted",
+			pods: []*corev1.Pod{
+				newPod(0, "sts-uid", pointer.Ptr(false)),
+				newPod(2, "foreign-uid", pointer.Ptr(false)),
+			},
+			expectedIgnitionPendingNodes: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pod := range tc.pods {
+				err := podCache.Add(pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sdcc := &Controller{
+				podLister:  corev1listers.NewPodLister(podCache),
+				nodeLister: corev1listers.NewNodeLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+			}
+
+			rackStatus := sdcc.calculateRackStatus(sdc, sts)
+			if rackStatus.IgnitionPendingNodes == nil {
+				t.Fatalf("expected ignition pending nodes to be set")
+			}
+
+			if *rackStatus.IgnitionPendingNodes != tc.expectedIgnitionPendingNodes {
+				t.Errorf("expected %d ignition pending nodes, got %d", tc.expectedIgnitionPendingNodes, *rackStatus.IgnitionPendingNodes)
+			}
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				Racks: []scyllav1alpha1.RackStatus{*rackStatus, *rackStatus},
+			}
+			updateAggregatedStatusFields(status)
+			if *status.IgnitionPendingNodes != 2*tc.expectedIgnitionPendingNodes {
+				t.Errorf("expected %d aggregated ignition pending nodes, got %d", 2*tc.expectedIgnitionPendingNodes, *status.IgnitionPendingNodes)
+			}
+		})
+	}
+}
+
+func TestController_calculateRackStatus_InstanceTypes(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName: "basic",
+			ScyllaDB: scyllav1alpha1.ScyllaDB{
+				Image: "scylladb/scylla:6.2.0",
+			},
+		},
+	}
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic-dc-rack",
+			Namespace: "default",
+			UID:       "sts-uid",
+			Labels: map[string]string{
+				naming.RackNameLabel: "rack",
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Ptr(int32(4)),
+		},
+	}
+
+	newPod := func(ordinal int, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-rack-%d", ordinal),
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "StatefulSet",
+						Name:       "basic-dc-rack",
+						UID:        "sts-uid",
+						Controller: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{
+						Name:  naming.ScyllaContainerName,
+						Image: "scylladb/scylla:6.2.0",
+					},
+				},
+			},
+		}
+	}
+
+	newNode := func(name string, instanceType string) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{},
+			},
+		}
+
+		if len(instanceType) != 0 {
+			node.Labels[corev1.LabelInstanceTypeStable] = instanceType
+		}
+
+		return node
+	}
+
+	tt := []struct {
+		name                  string
+		pods                  []*corev1.Pod
+		nodes                 []*corev1.Node
+		expectedInstanceTypes map[string]int32
+	}{
+		{
+			name: "homogeneous rack",
+			pods: []*corev1.Pod{
+				newPod(0, "node-0"),
+				newPod(1, "node-1"),
+			},
+			nodes: []*corev1.Node{
+				newNode("node-0", "i4i.2xlarge"),
+				newNode("node-1", "i4i.2xlarge"),
+			},
+			expectedInstanceTypes: map[string]int32{
+				"i4i.2xlarge": 2,
+			},
+		},
+		{
+			name: "heterogeneous rack",
+			pods: []*corev1.Pod{
+				newPod(0, "node-0"),
+				newPod(1, "node-1"),
+				newPod(2, "node-2"),
+			},
+			nodes: []*corev1.Node{
+				newNode("node-0", "i4i.2xlarge"),
+				newNode("node-1", "i4i.4xlarge"),
+				newNode("node-2", "i4i.2xlarge"),
+			},
+			expectedInstanceTypes: map[string]int32{
+				"i4i.2xlarge": 2,
+				"i4i.4xlarge": 1,
+			},
+		},
+		{
+			name: "unscheduled pods, missing nodes and nodes without the label aren't counted",
+			pods: []*corev1.Pod{
+				newPod(0, "node-0"),
+				newPod(1, ""),
+				newPod(2, "missing-node"),
+				newPod(3, "unlabeled-node"),
+			},
+			nodes: []*corev1.Node{
+				newNode("node-0", "i4i.2xlarge"),
+				newNode("unlabeled-node", ""),
+			},
+			expectedInstanceTypes: map[string]int32{
+				"i4i.2xlarge": 1,
+			},
+		},
+		{
+			name: "instance types are omitted when no node has the label",
+			pods: []*corev1.Pod{
+				newPod(0, "unlabeled-node"),
+			},
+			nodes: []*corev1.Node{
+				newNode("unlabeled-node", ""),
+			},
+			expectedInstanceTypes: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pod := range tc.pods {
+				err := podCache.Add(pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			nodeCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, node := range tc.nodes {
+				err := nodeCache.Add(node)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sdcc := &Controller{
+				podLister:  corev1listers.NewPodLister(podCache),
+				nodeLister: corev1listers.NewNodeLister(nodeCache),
+			}
+
+			rackStatus := sdcc.calculateRackStatus(sdc, sts)
+			if !apiequality.Semantic.DeepEqual(rackStatus.InstanceTypes, tc.expectedInstanceTypes) {
+				t.Errorf("expected and got instance types differ: %s", cmp.Diff(tc.expectedInstanceTypes, rackStatus.InstanceTypes))
+			}
+		})
+	}
+}
+
+func TestController_calculateStatus_ImagePullFailing(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 3,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			ScyllaDB: scyllav1alpha1.ScyllaDB{
+				Image: "scylladb/scylla:6.2.1",
+			},
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "a",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(2)),
+					},
+				},
+				{
+					Name: "b",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(1)),
+					},
+				},
+			},
+		},
+	}
+
+	newStatefulSet := func(rack string, replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-%s", rack),
+				Namespace: "default",
+				UID:       types.UID(fmt.Sprintf("sts-%s-uid", rack)),
+				Labels: map[string]string{
+					naming.RackNameLabel: rack,
+				},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Ptr(replicas),
+			},
+		}
+	}
+
+	statefulSets := map[string]*appsv1.StatefulSet{
+		"basic-dc-a": newStatefulSet("a", 2),
+		"basic-dc-b": newStatefulSet("b", 1),
+	}
+
+	waiting := func(reason string) corev1.ContainerState {
+		return corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{
+				Reason: reason,
+			},
+		}
+	}
+
+	newPod := func(rack string, ordinal int, initContainerStatuses, containerStatuses []corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-%s-%d", rack, ordinal),
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "StatefulSet",
+						Name:       fmt.Sprintf("basic-dc-%s", rack),
+						UID:        types.UID(fmt.Sprintf("sts-%s-uid", rack)),
+						Controller: pointer.Ptr(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  naming.ScyllaContainerName,
+						Image: "scylladb/scylla:6.2.0",
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: initContainerStatuses,
+				ContainerStatuses:     containerStatuses,
+			},
+		}
+	}
+
+	tt := []struct {
+		name              string
+		pods              []*corev1.Pod
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "no image pull failures",
+			pods: []*corev1.Pod{
+				newPod("a", 0, nil, []corev1.ContainerStatus{
+					{Name: naming.ScyllaContainerName, Image: "scylladb/scylla:6.2.0", State: waiting("ContainerCreating")},
+				}),
+				newPod("a", 1, nil, nil),
+				newPod("b", 0, nil, nil),
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ImagePullFailingCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 3,
+			},
+		},
+		{
+			name: "image pull failures across racks and container kinds name images and pods",
+			pods: []*corev1.Pod{
+				newPod("a", 0, nil, []corev1.ContainerStatus{
+					{Name: naming.ScyllaContainerName, Image: "scylladb/scylla:6.2.1", State: waiting("ImagePullBackOff")},
+				}),
+				newPod("a", 1, []corev1.ContainerStatus{
+					{Name: "sidecar-injection", Image: "scylladb/scylla-operator:broken", State: waiting("ErrImagePull")},
+				}, nil),
+				newPod("b", 0, nil, []corev1.ContainerStatus{
+					{Name: naming.ScyllaContainerName, Image: "scylladb/scylla:6.2.1", State: waiting("ErrImagePull")},
+				}),
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ImagePullFailingCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "ImagePullFailed",
+				Message:            `Image "scylladb/scylla-operator:broken" can't be pulled for Pod(s) default/basic-dc-a-1. Image "scylladb/scylla:6.2.1" can't be pulled for Pod(s) default/basic-dc-a-0, default/basic-dc-b-0.`,
+				ObservedGeneration: 3,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pod := range tc.pods {
+				err := podCache.Add(pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sdcc := &Controller{
+				podLister:     corev1listers.NewPodLister(podCache),
+				nodeLister:    corev1listers.NewNodeLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+				eventRecorder: record.NewFakeRecorder(10),
+			}
+
+			status := sdcc.calculateStatus(sdc, statefulSets, nil)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.ImagePullFailingCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestController_setPrewarmedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	newSDC := func(nodes int32, oldRackNodes *int32) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "basic",
+				Namespace:  "default",
+				Generation: 2,
+			},
+			Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+				ClusterName:    "basic",
+				DatacenterName: pointer.Ptr("dc"),
+				Racks: []scyllav1alpha1.RackSpec{
+					{
+						Name: "rack",
+						RackTemplate: scyllav1alpha1.RackTemplate{
+							Nodes: pointer.Ptr(nodes),
+						},
+					},
+				},
+			},
+		}
+
+		if oldRackNodes != nil {
+			sdc.Status.Racks = []scyllav1alpha1.RackStatus{
+				{
+					Name:  "rack",
+					Nodes: oldRackNodes,
+				},
+			}
+		}
+
+		return sdc
+	}
+
+	newStatus := func(rackNodes int32) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:  "rack",
+					Nodes: pointer.Ptr(rackNodes),
+				},
+			},
+		}
+	}
+
+	newService := func(ordinal int) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-rack-%d", ordinal),
+				Namespace: "default",
+			},
+		}
+	}
+
+	newPod := func(ordinal int, prewarmed bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-rack-%d", ordinal),
+				Namespace: "default",
+			},
+		}
+
+		if prewarmed {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name:  naming.ScyllaDBIgnitionContainerName,
+					Ready: true,
+				},
+				{
+					Name: naming.DelayedVolumeMountContainerName,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			}
+		}
+
+		return pod
+	}
+
+	tt := []struct {
+		name              string
+		sdc               *scyllav1alpha1.ScyllaDBDatacenter
+		status            *scyllav1alpha1.ScyllaDBDatacenterStatus
+		services          []*corev1.Service
+		pods              []*corev1.Pod
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:     "all nodes are prewarmed",
+			sdc:      newSDC(2, pointer.Ptr(int32(2))),
+			status:   newStatus(2),
+			services: []*corev1.Service{newService(0), newService(1)},
+			pods:     []*corev1.Pod{newPod(0, true), newPod(1, true)},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.PrewarmedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:     "nodes pending prewarm without a scale-up",
+			sdc:      newSDC(2, pointer.Ptr(int32(2))),
+			status:   newStatus(2),
+			services: []*corev1.Service{newService(0), newService(1)},
+			pods:     []*corev1.Pod{newPod(0, true), newPod(1, false)},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.PrewarmedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NotAllNodesPrewarmed",
+				Message:            "Not all nodes are prewarmed yet: 1 out of 2 node(s) are pending prewarm.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:     "scale-up of a prewarmed rack reports the new nodes pending prewarm",
+			sdc:      newSDC(4, pointer.Ptr(int32(2))),
+			status:   newStatus(4),
+			services: []*corev1.Service{newService(0), newService(1), newService(2)},
+			pods:     []*corev1.Pod{newPod(0, true), newPod(1, true), newPod(2, false)},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.PrewarmedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NotAllNodesPrewarmed",
+				Message:            `Not all nodes are prewarmed yet: 2 out of 4 node(s) are pending prewarm. This is due to a scale-up of rack(s) "rack" from 2 to 4.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:     "scale-down isn't reported as a scale-up",
+			sdc:      newSDC(1, pointer.Ptr(int32(2))),
+			status:   newStatus(1),
+			services: []*corev1.Service{newService(0)},
+			pods:     []*corev1.Pod{newPod(0, false)},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.PrewarmedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NotAllNodesPrewarmed",
+				Message:            "Not all nodes are prewarmed yet: 1 out of 1 node(s) are pending prewarm.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:     "new rack isn't reported as a scale-up",
+			sdc:      newSDC(1, nil),
+			status:   newStatus(1),
+			services: []*corev1.Service{newService(0)},
+			pods:     []*corev1.Pod{newPod(0, false)},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.PrewarmedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NotAllNodesPrewarmed",
+				Message:            "Not all nodes are prewarmed yet: 1 out of 1 node(s) are pending prewarm.",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pod := range tc.pods {
+				err := podCache.Add(pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			services := map[string]*corev1.Service{}
+			for _, svc := range tc.services {
+				services[svc.Name] = svc
+			}
+
+			sdcc := &Controller{
+				podLister: corev1listers.NewPodLister(podCache),
+			}
+
+			sdcc.setPrewarmedStatusCondition(tc.sdc, tc.status, services)
+
+			gotCondition := apimeta.FindStatusCondition(tc.status.Conditions, scyllav1alpha1.PrewarmedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetSchemaVersionStatus(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	newStatus := func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:          "a",
+					SchemaVersion: "stale",
+				},
+				{
+					Name: "b",
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		rackSchemaVersions     map[string][]string
+		expectedSchemaVersions []string
+		expectedCondition      *metav1.Condition
+	}{
+		{
+			name:                   "no versions could be determined",
+			rackSchemaVersions:     map[string][]string{},
+			expectedSchemaVersions: []string{"", ""},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.SchemaDisagreementCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "SchemaVersionUnknown",
+				Message:            "Schema version couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "all racks agree",
+			rackSchemaVersions: map[string][]string{
+				"a": {"v1", "v1"},
+				"b": {"v1"},
+			},
+			expectedSchemaVersions: []string{"v1", "v1"},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.SchemaDisagreementCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "unreachable rack doesn't cause disagreement",
+			rackSchemaVersions: map[string][]string{
+				"a": {"v1"},
+			},
+			expectedSchemaVersions: []string{"v1", ""},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.SchemaDisagreementCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "racks disagree",
+			rackSchemaVersions: map[string][]string{
+				"a": {"v1"},
+				"b": {"v2"},
+			},
+			expectedSchemaVersions: []string{"v1", "v2"},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.SchemaDisagreementCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "SchemaVersionsDiffer",
+				Message:            `Schema version "v1" is reported in rack(s) a. Schema version "v2" is reported in rack(s) b.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes within a rack disagree",
+			rackSchemaVersions: map[string][]string{
+				"a": {"v2", "v1"},
+				"b": {"v1"},
+			},
+			expectedSchemaVersions: []string{"", "v1"},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.SchemaDisagreementCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "SchemaVersionsDiffer",
+				Message:            `Schema version "v1" is reported in rack(s) a, b. Schema version "v2" is reported in rack(s) a.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newStatus()
+			setSchemaVersionStatus(sdc, status, tc.rackSchemaVersions)
+
+			var gotSchemaVersions []string
+			for _, rs := range status.Racks {
+				gotSchemaVersions = append(gotSchemaVersions, rs.SchemaVersion)
+			}
+			if !apiequality.Semantic.DeepEqual(gotSchemaVersions, tc.expectedSchemaVersions) {
+				t.Errorf("expected and got schema versions differ: %s", cmp.Diff(tc.expectedSchemaVersions, gotSchemaVersions))
+			}
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.SchemaDisagreementCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetMemberServicesReadyStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "a",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(2)),
+					},
+				},
+				{
+					Name: "b",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(1)),
+					},
+				},
+			},
+		},
+	}
+
+	newServices := func(names ...string) map[string]*corev1.Service {
+		services := map[string]*corev1.Service{}
+		for _, name := range names {
+			services[name] = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+			}
+		}
+		return services
+	}
+
+	tt := []struct {
+		name              string
+		services          map[string]*corev1.Service
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:     "all member services exist",
+			services: newServices("basic-dc-a-0", "basic-dc-a-1", "basic-dc-b-0"),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.MemberServicesReadyCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:     "unexpected services are ignored",
+			services: newServices("basic-dc-a-0", "basic-dc-a-1", "basic-dc-b-0", "basic-dc-b-1", "basic-client"),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.MemberServicesReadyCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:     "missing member services are named",
+			services: newServices("basic-dc-a-0"),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.MemberServicesReadyCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "MemberServicesMissing",
+				Message:            "Member Service(s) basic-dc-a-1, basic-dc-b-0 don't exist.",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setMemberServicesReadyStatusCondition(sdc, status, tc.services)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.MemberServicesReadyCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetServiceIPAllocationFailedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "a",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(2)),
+					},
+				},
+				{
+					Name: "b",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(1)),
+					},
+				},
+			},
+		},
+	}
+
+	newServices := func(clusterIPs map[string]string) map[string]*corev1.Service {
+		services := map[string]*corev1.Service{}
+		for name, clusterIP := range clusterIPs {
+			services[name] = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: clusterIP,
+				},
+			}
+		}
+		return services
+	}
+
+	tt := []struct {
+		name              string
+		services          map[string]*corev1.Service
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "all member services have a cluster IP assigned",
+			services: newServices(map[string]string{
+				"basic-dc-a-0": "10.96.0.10",
+				"basic-dc-a-1": "10.96.0.11",
+				"basic-dc-b-0": "10.96.0.12",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ServiceIPAllocationFailedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "headless and missing member services are ignored",
+			services: newServices(map[string]string{
+				"basic-dc-a-0": corev1.ClusterIPNone,
+				"basic-dc-b-0": corev1.ClusterIPNone,
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ServiceIPAllocationFailedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "member services without a cluster IP are named",
+			services: newServices(map[string]string{
+				"basic-dc-a-0": "",
+				"basic-dc-a-1": "10.96.0.11",
+				"basic-dc-b-0": "",
+				"basic-client": "",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ServiceIPAllocationFailedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "ClusterIPNotAssigned",
+				Message:            "Member Service(s) basic-dc-a-0, basic-dc-b-0 don't have a cluster IP assigned.",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setServiceIPAllocationFailedStatusCondition(sdc, status, tc.services)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.ServiceIPAllocationFailedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetDuplicateHostIDStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "a",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(2)),
+					},
+				},
+				{
+					Name: "b",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(1)),
+					},
+				},
+			},
+		},
+	}
+
+	newServices := func(hostIDs map[string]string) map[string]*corev1.Service {
+		services := map[string]*corev1.Service{}
+		for name, hostID := range hostIDs {
+			services[name] = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+			}
+			if len(hostID) != 0 {
+				services[name].Annotations = map[string]string{
+					naming.HostIDAnnotation: hostID,
+				}
+			}
+		}
+		return services
+	}
+
+	tt := []struct {
+		name              string
+		services          map[string]*corev1.Service
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "unique host IDs",
+			services: newServices(map[string]string{
+				"basic-dc-a-0": "host-0",
+				"basic-dc-a-1": "host-1",
+				"basic-dc-b-0": "host-2",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DuplicateHostIDCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "missing services and host IDs aren't duplicates",
+			services: newServices(map[string]string{
+				"basic-dc-a-0": "",
+				"basic-dc-a-1": "",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DuplicateHostIDCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "host ID shared across racks is reported with its members",
+			services: newServices(map[string]string{
+				"basic-dc-a-0": "host-0",
+				"basic-dc-a-1": "host-1",
+				"basic-dc-b-0": "host-0",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DuplicateHostIDCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "DuplicateHostIDsReported",
+				Message:            `Several members share a host ID, which is usually caused by copied data volumes: host ID "host-0" is reported by members basic-dc-a-0, basic-dc-b-0.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "host IDs of unexpected services are ignored",
+			services: newServices(map[string]string{
+				"basic-dc-a-0": "host-0",
+				"basic-dc-b-1": "host-0",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DuplicateHostIDCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setDuplicateHostIDStatusCondition(sdc, status, tc.services)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.DuplicateHostIDCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetAntiAffinityViolatedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	newPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+			},
+		}
+	}
+
+	tt := []struct {
+		name              string
+		rackMembers       map[string][]*corev1.Pod
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "members on distinct nodes don't violate anti-affinity",
+			rackMembers: map[string][]*corev1.Pod{
+				"a": {newPod("basic-a-0", "node-1"), newPod("basic-a-1", "node-2")},
+				"b": {newPod("basic-b-0", "node-1")},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.AntiAffinityViolatedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "unscheduled members are ignored",
+			rackMembers: map[string][]*corev1.Pod{
+				"a": {newPod("basic-a-0", ""), newPod("basic-a-1", "")},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.AntiAffinityViolatedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "members of a rack sharing a node are reported",
+			rackMembers: map[string][]*corev1.Pod{
+				"a": {newPod("basic-a-0", "node-2"), newPod("basic-a-1", "node-1"), newPod("basic-a-2", "node-2")},
+				"b": {newPod("basic-b-0", "node-3"), newPod("basic-b-1", "node-3")},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.AntiAffinityViolatedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "MembersShareNode",
+				Message:            `Several members of a rack are scheduled to the same node, so a failure of the node takes them all down: members basic-a-0, basic-a-2 of rack "a" share node "node-2"; members basic-b-0, basic-b-1 of rack "b" share node "node-3".`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setAntiAffinityViolatedStatusCondition(sdc, status, tc.rackMembers)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.AntiAffinityViolatedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestController_calculateStatus_StuckTerminating(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 3,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			ScyllaDB: scyllav1alpha1.ScyllaDB{
+				Image: "scylladb/scylla:6.2.0",
+			},
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "a",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(2)),
+					},
+				},
+				{
+					Name: "b",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(1)),
+					},
+				},
+			},
+		},
+	}
+
+	newStatefulSet := func(rack string, replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-%s", rack),
+				Namespace: "default",
+				UID:       types.UID(fmt.Sprintf("sts-%s-uid", rack)),
+				Labels: map[string]string{
+					naming.RackNameLabel: rack,
+				},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Ptr(replicas),
+			},
+		}
+	}
+
+	statefulSets := map[string]*appsv1.StatefulSet{
+		"basic-dc-a": newStatefulSet("a", 2),
+		"basic-dc-b": newStatefulSet("b", 1),
+	}
+
+	newPod := func(rack string, ordinal int, deletionTimestamp *metav1.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("basic-dc-%s-%d", rack, ordinal),
+				Namespace:         "default",
+				DeletionTimestamp: deletionTimestamp,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "StatefulSet",
+						Name:       fmt.Sprintf("basic-dc-%s", rack),
+						UID:        types.UID(fmt.Sprintf("sts-%s-uid", rack)),
+						Controller: pointer.Ptr(true),
+					},
+				},
+			},
+		}
+	}
+
+	gracePeriodPassed := &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	gracePeriodPending := &metav1.Time{Time: time.Now().Add(time.Hour)}
+
+	tt := []struct {
+		name                          string
+		pods                          []*corev1.Pod
+		expectedStuckTerminatingNodes []int32
+		expectedCondition             *metav1.Condition
+	}{
+		{
+			name: "no terminating pods",
+			pods: []*corev1.Pod{
+				newPod("a", 0, nil),
+				newPod("a", 1, nil),
+				newPod("b", 0, nil),
+			},
+			expectedStuckTerminatingNodes: []int32{0, 0},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.StuckTerminatingCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 3,
+			},
+		},
+		{
+			name: "pods terminating within their grace period aren't stuck",
+			pods: []*corev1.Pod{
+				newPod("a", 0, gracePeriodPending),
+				newPod("a", 1, nil),
+				newPod("b", 0, gracePeriodPending),
+			},
+			expectedStuckTerminatingNodes: []int32{0, 0},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.StuckTerminatingCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 3,
+			},
+		},
+		{
+			name: "pods terminating after their grace period are stuck",
+			pods: []*corev1.Pod{
+				newPod("a", 0, gracePeriodPassed),
+				newPod("a", 1, gracePeriodPending),
+				newPod("b", 0, gracePeriodPassed),
+			},
+			expectedStuckTerminatingNodes: []int32{1, 1},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.StuckTerminatingCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "GracePeriodExceeded",
+				Message:            "Pod(s) basic-dc-a-0, basic-dc-b-0 are still terminating after their grace period has passed.",
+				ObservedGeneration: 3,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, pod := range tc.pods {
+				err := podCache.Add(pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sdcc := &Controller{
+				podLister:     corev1listers.NewPodLister(podCache),
+				nodeLister:    corev1listers.NewNodeLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+				eventRecorder: record.NewFakeRecorder(10),
+			}
+
+			status := sdcc.calculateStatus(sdc, statefulSets, nil)
+
+			var gotStuckTerminatingNodes []int32
+			for _, rs := range status.Racks {
+				gotStuckTerminatingNodes = append(gotStuckTerminatingNodes, *rs.StuckTerminatingNodes)
+			}
+			if !apiequality.Semantic.DeepEqual(gotStuckTerminatingNodes, tc.expectedStuckTerminatingNodes) {
+				t.Errorf("expected and got stuck terminating nodes differ: %s", cmp.Diff(tc.expectedStuckTerminatingNodes, gotStuckTerminatingNodes))
+			}
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.StuckTerminatingCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetShardCountStatus(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	newStatus := func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:       "a",
+					ShardCount: pointer.Ptr(int32(16)),
+				},
+				{
+					Name: "b",
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                string
+		rackShardCounts     map[string][]int32
+		expectedShardCounts []*int32
+		expectedCondition   *metav1.Condition
+	}{
+		{
+			name:                "no shard counts could be determined",
+			rackShardCounts:     map[string][]int32{},
+			expectedShardCounts: []*int32{nil, nil},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ShardCountMismatchCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "ShardCountUnknown",
+				Message:            "Shard count couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "racks with differing but internally consistent shard counts don't mismatch",
+			rackShardCounts: map[string][]int32{
+				"a": {4, 4},
+				"b": {8},
+			},
+			expectedShardCounts: []*int32{pointer.Ptr(int32(4)), pointer.Ptr(int32(8))},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ShardCountMismatchCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes within a rack have differing shard counts",
+			rackShardCounts: map[string][]int32{
+				"a": {8, 4, 8},
+				"b": {8},
+			},
+			expectedShardCounts: []*int32{nil, pointer.Ptr(int32(8))},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ShardCountMismatchCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "DifferingShardCounts",
+				Message:            `Rack "a" has nodes with differing shard counts: 4, 8.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newStatus()
+			setShardCountStatus(sdc, status, tc.rackShardCounts)
+
+			var gotShardCounts []*int32
+			for _, rs := range status.Racks {
+				gotShardCounts = append(gotShardCounts, rs.ShardCount)
+			}
+			if !apiequality.Semantic.DeepEqual(gotShardCounts, tc.expectedShardCounts) {
+				t.Errorf("expected and got shard counts differ: %s", cmp.Diff(tc.expectedShardCounts, gotShardCounts))
+			}
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.ShardCountMismatchCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetStorageClassMismatchStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	newRack := func(name string, storageClassName *string) scyllav1alpha1.RackSpec {
+		rack := scyllav1alpha1.RackSpec{
+			Name: name,
+			RackTemplate: scyllav1alpha1.RackTemplate{
+				Nodes: pointer.Ptr(int32(1)),
+			},
+		}
+
+		if storageClassName != nil {
+			rack.ScyllaDB = &scyllav1alpha1.ScyllaDBTemplate{
+				Storage: &scyllav1alpha1.StorageOptions{
+					StorageClassName: storageClassName,
+				},
+			}
+		}
+
+		return rack
+	}
+
+	newSDC := func(rackTemplateStorageClassName *string, racks ...scyllav1alpha1.RackSpec) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "basic",
+				Namespace:  "default",
+				Generation: 2,
+			},
+			Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+				ClusterName:    "basic",
+				DatacenterName: pointer.Ptr("dc"),
+				Racks:          racks,
+			},
+		}
+
+		if rackTemplateStorageClassName != nil {
+			sdc.Spec.RackTemplate = &scyllav1alpha1.RackTemplate{
+				ScyllaDB: &scyllav1alpha1.ScyllaDBTemplate{
+					Storage: &scyllav1alpha1.StorageOptions{
+						StorageClassName: rackTemplateStorageClassName,
+					},
+				},
+			}
+		}
+
+		return sdc
+	}
+
+	newStatefulSet := func(rack string, storageClassName *string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-%s", rack),
+				Namespace: "default",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: naming.PVCTemplateName,
+						},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: storageClassName,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newStatefulSets := func(statefulSets ...*appsv1.StatefulSet) map[string]*appsv1.StatefulSet {
+		m := map[string]*appsv1.StatefulSet{}
+		for _, sts := range statefulSets {
+			m[sts.Name] = sts
+		}
+		return m
+	}
+
+	asExpectedCondition := &metav1.Condition{
+		Type:               scyllav1alpha1.StorageClassMismatchCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: 2,
+	}
+
+	tt := []struct {
+		name              string
+		sdc               *scyllav1alpha1.ScyllaDBDatacenter
+		statefulSets      map[string]*appsv1.StatefulSet
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:              "matching storage classes",
+			sdc:               newSDC(nil, newRack("a", pointer.Ptr("fast")), newRack("b", nil)),
+			statefulSets:      newStatefulSets(newStatefulSet("a", pointer.Ptr("fast")), newStatefulSet("b", nil)),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:              "storage class inherited from the rack template matches",
+			sdc:               newSDC(pointer.Ptr("fast"), newRack("a", nil)),
+			statefulSets:      newStatefulSets(newStatefulSet("a", pointer.Ptr("fast"))),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:              "missing StatefulSet is ignored",
+			sdc:               newSDC(nil, newRack("a", pointer.Ptr("fast"))),
+			statefulSets:      newStatefulSets(),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:         "differing storage classes are reported with both names",
+			sdc:          newSDC(nil, newRack("a", pointer.Ptr("fast")), newRack("b", pointer.Ptr("fast"))),
+			statefulSets: newStatefulSets(newStatefulSet("a", pointer.Ptr("slow")), newStatefulSet("b", nil)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.StorageClassMismatchCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "StorageClassDiffers",
+				Message:            `Rack "a" uses StorageClass "slow" instead of the desired "fast". Rack "b" uses StorageClass <default> instead of the desired "fast". Recreating the PersistentVolumeClaims is required to change it.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setStorageClassMismatchStatusCondition(tc.sdc, status, tc.statefulSets)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.StorageClassMismatchCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetRackUpdateStalledStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	newStatefulSet := func(rack string, partition *int32, currentRevision, updateRevision string, updatedReplicas, readyReplicas int32) *appsv1.StatefulSet {
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       fmt.Sprintf("basic-dc-%s", rack),
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Ptr(int32(3)),
+			},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 1,
+				CurrentRevision:    currentRevision,
+				UpdateRevision:     updateRevision,
+				UpdatedReplicas:    updatedReplicas,
+				ReadyReplicas:      readyReplicas,
+			},
+		}
+
+		if partition != nil {
+			sts.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: partition,
+			}
+		}
+
+		return sts
+	}
+
+	newStatefulSets := func(statefulSets ...*appsv1.StatefulSet) map[string]*appsv1.StatefulSet {
+		m := map[string]*appsv1.StatefulSet{}
+		for _, sts := range statefulSets {
+			m[sts.Name] = sts
+		}
+		return m
+	}
+
+	asExpectedCondition := &metav1.Condition{
+		Type:               scyllav1alpha1.RackUpdateStalledCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: 2,
+	}
+
+	tt := []struct {
+		name              string
+		statefulSets      map[string]*appsv1.StatefulSet
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:              "matching revisions",
+			statefulSets:      newStatefulSets(newStatefulSet("a", nil, "rev-1", "rev-1", 3, 3), newStatefulSet("b", nil, "rev-1", "rev-1", 3, 3)),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name: "revisions of a StatefulSet with a stale status are ignored",
+			statefulSets: newStatefulSets(func() *appsv1.StatefulSet {
+				sts := newStatefulSet("a", nil, "rev-1", "rev-2", 0, 2)
+				sts.Generation = 2
+				return sts
+			}()),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:         "actively progressing update",
+			statefulSets: newStatefulSets(newStatefulSet("a", nil, "rev-1", "rev-2", 1, 3), newStatefulSet("b", nil, "rev-1", "rev-1", 3, 3)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.RackUpdateStalledCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "UpdateProgressing",
+				Message:            `Rack "a" is updating from revision "rev-1" to "rev-2" with 1 of 3 node(s) updated.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:         "update paused by partition",
+			statefulSets: newStatefulSets(newStatefulSet("a", pointer.Ptr(int32(2)), "rev-1", "rev-2", 1, 3)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.RackUpdateStalledCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "UpdatePausedByPartition",
+				Message:            `Rack "a" update from revision "rev-1" to "rev-2" is paused by partition 2 with 1 of 3 node(s) updated.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:         "update progressing above the partition",
+			statefulSets: newStatefulSets(newStatefulSet("a", pointer.Ptr(int32(1)), "rev-1", "rev-2", 1, 3)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.RackUpdateStalledCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "UpdateProgressing",
+				Message:            `Rack "a" is updating from revision "rev-1" to "rev-2" with 1 of 3 node(s) updated.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:         "update waiting for nodes to become ready takes precedence over a partition",
+			statefulSets: newStatefulSets(newStatefulSet("a", pointer.Ptr(int32(2)), "rev-1", "rev-2", 1, 3), newStatefulSet("b", nil, "rev-1", "rev-2", 1, 2)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.RackUpdateStalledCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "UpdateNotProgressing",
+				Message:            `Rack "a" update from revision "rev-1" to "rev-2" is paused by partition 2 with 1 of 3 node(s) updated. Rack "b" update from revision "rev-1" to "rev-2" is waiting for nodes to become ready with 2 of 3 node(s) ready and 1 updated.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setRackUpdateStalledStatusCondition(sdc, status, tc.statefulSets)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.RackUpdateStalledCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetCleanupRecommendedStatus(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	newStatus := func(nodes, readyNodes int32, baselineNodes *int32) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Nodes:                pointer.Ptr(nodes),
+			ReadyNodes:           pointer.Ptr(readyNodes),
+			CleanupBaselineNodes: baselineNodes,
+		}
+	}
+
+	tt := []struct {
+		name                  string
+		status                *scyllav1alpha1.ScyllaDBDatacenterStatus
+		cleanupCompleted      bool
+		expectedBaselineNodes *int32
+		expectedCondition     *metav1.Condition
+	}{
+		{
+			name:                  "datacenter that was never fully available has unknown condition",
+			status:                newStatus(3, 2, nil),
+			cleanupCompleted:      false,
+			expectedBaselineNodes: nil,
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "WaitingForFullAvailability",
+				Message:            "Waiting for all nodes to become ready.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:                  "first full availability records the baseline",
+			status:                newStatus(3, 3, nil),
+			cleanupCompleted:      false,
+			expectedBaselineNodes: pointer.Ptr(int32(3)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:                  "added nodes recommend a cleanup",
+			status:                newStatus(5, 5, pointer.Ptr(int32(3))),
+			cleanupCompleted:      false,
+			expectedBaselineNodes: pointer.Ptr(int32(3)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "NodesAdded",
+				Message:            "The number of nodes increased from 3 to 5. Running a cleanup on the existing nodes is recommended to reclaim space.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:                  "removed nodes recommend a cleanup",
+			status:                newStatus(2, 2, pointer.Ptr(int32(3))),
+			cleanupCompleted:      false,
+			expectedBaselineNodes: pointer.Ptr(int32(3)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "NodesRemoved",
+				Message:            "The number of nodes decreased from 3 to 2. Running a cleanup on the remaining nodes is recommended.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "completed cleanup after removing nodes clears the condition",
+			status: func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+				status := newStatus(2, 2, pointer.Ptr(int32(3)))
+				status.Conditions = []metav1.Condition{
+					{
+						Type:               scyllav1alpha1.CleanupRecommendedCondition,
+						Status:             metav1.ConditionTrue,
+						Reason:             "NodesRemoved",
+						Message:            "The number of nodes decreased from 3 to 2. Running a cleanup on the remaining nodes is recommended.",
+						ObservedGeneration: 2,
+					},
+				}
+				return status
+			}(),
+			cleanupCompleted:      true,
+			expectedBaselineNodes: pointer.Ptr(int32(2)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:                  "completed cleanup resets the baseline",
+			status:                newStatus(5, 5, pointer.Ptr(int32(3))),
+			cleanupCompleted:      true,
+			expectedBaselineNodes: pointer.Ptr(int32(5)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "existing condition is kept while the topology change is in progress",
+			status: func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+				status := newStatus(5, 4, pointer.Ptr(int32(3)))
+				status.Conditions = []metav1.Condition{
+					{
+						Type:               scyllav1alpha1.CleanupRecommendedCondition,
+						Status:             metav1.ConditionTrue,
+						Reason:             "NodesAdded",
+						Message:            "The number of nodes increased from 3 to 4. Running a cleanup on the existing nodes is recommended to reclaim space.",
+						ObservedGeneration: 1,
+					},
+				}
+				return status
+			}(),
+			cleanupCompleted:      true,
+			expectedBaselineNodes: pointer.Ptr(int32(3)),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.CleanupRecommendedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "NodesAdded",
+				Message:            "The number of nodes increased from 3 to 4. Running a cleanup on the existing nodes is recommended to reclaim space.",
+				ObservedGeneration: 1,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := tc.status.DeepCopy()
+			setCleanupRecommendedStatus(sdc, status, tc.cleanupCompleted)
+
+			if !apiequality.Semantic.DeepEqual(status.CleanupBaselineNodes, tc.expectedBaselineNodes) {
+				t.Errorf("expected and got baseline nodes differ: %s", cmp.Diff(tc.expectedBaselineNodes, status.CleanupBaselineNodes))
+			}
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.CleanupRecommendedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestIsCleanupCompleted(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "a",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(2)),
+					},
+				},
+			},
+		},
+	}
+
+	newService := func(name string, currentTokenRingHash, lastCleanedUpTokenRingHash *string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: map[string]string{},
+			},
+		}
+
+		if currentTokenRingHash != nil {
+			svc.Annotations[naming.CurrentTokenRingHashAnnotation] = *currentTokenRingHash
+		}
+		if lastCleanedUpTokenRingHash != nil {
+			svc.Annotations[naming.LastCleanedUpTokenRingHashAnnotation] = *lastCleanedUpTokenRingHash
+		}
+
+		return svc
+	}
+
+	newServices := func(services ...*corev1.Service) map[string]*corev1.Service {
+		m := map[string]*corev1.Service{}
+		for _, svc := range services {
+			m[svc.Name] = svc
+		}
+		return m
+	}
+
+	tt := []struct {
+		name     string
+		services map[string]*corev1.Service
+		expected bool
+	}{
+		{
+			name: "all services cleaned up for the current token ring",
+			services: newServices(
+				newService("basic-dc-a-0", pointer.Ptr("new"), pointer.Ptr("new")),
+				newService("basic-dc-a-1", pointer.Ptr("new"), pointer.Ptr("new")),
+			),
+			expected: true,
+		},
+		{
+			name: "service with a pending cleanup",
+			services: newServices(
+				newService("basic-dc-a-0", pointer.Ptr("new"), pointer.Ptr("old")),
+				newService("basic-dc-a-1", pointer.Ptr("new"), pointer.Ptr("new")),
+			),
+			expected: false,
+		},
+		{
+			name: "token ring change not reflected on all services yet",
+			services: newServices(
+				newService("basic-dc-a-0", pointer.Ptr("old"), pointer.Ptr("old")),
+				newService("basic-dc-a-1", pointer.Ptr("new"), pointer.Ptr("new")),
+			),
+			expected: false,
+		},
+		{
+			name: "service without a current token ring hash",
+			services: newServices(
+				newService("basic-dc-a-0", nil, nil),
+				newService("basic-dc-a-1", pointer.Ptr("new"), pointer.Ptr("new")),
+			),
+			expected: false,
+		},
+		{
+			name: "missing service",
+			services: newServices(
+				newService("basic-dc-a-0", pointer.Ptr("new"), pointer.Ptr("new")),
+			),
+			expected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := isCleanupCompleted(sdc, tc.services)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetCQLConnectionsStatus(t *testing.T) {
+	t.Parallel()
+
+	newStatus := func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:           "a",
+					CQLConnections: pointer.Ptr(int32(42)),
+				},
+				{
+					Name: "b",
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		rackCQLConnections     map[string][]int32
+		expectedCQLConnections []*int32
+	}{
+		{
+			name:                   "no node reported connections",
+			rackCQLConnections:     map[string][]int32{},
+			expectedCQLConnections: []*int32{nil, nil},
+		},
+		{
+			name: "connections of nodes are summed per rack",
+			rackCQLConnections: map[string][]int32{
+				"a": {10, 0, 5},
+				"b": {7},
+			},
+			expectedCQLConnections: []*int32{pointer.Ptr(int32(15)), pointer.Ptr(int32(7))},
+		},
+		{
+			name: "rack without reported connections is unset",
+			rackCQLConnections: map[string][]int32{
+				"b": {3, 4},
+			},
+			expectedCQLConnections: []*int32{nil, pointer.Ptr(int32(7))},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newStatus()
+			setCQLConnectionsStatus(status, tc.rackCQLConnections)
+
+			var gotCQLConnections []*int32
+			for _, rs := range status.Racks {
+				gotCQLConnections = append(gotCQLConnections, rs.CQLConnections)
+			}
+			if !apiequality.Semantic.DeepEqual(gotCQLConnections, tc.expectedCQLConnections) {
+				t.Errorf("expected and got CQL connections differ: %s", cmp.Diff(tc.expectedCQLConnections, gotCQLConnections))
+			}
+		})
+	}
+}
+
+func TestIsSchemaMigrating(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name           string
+		schemaVersion  string
+		schemaVersions map[string][]string
+		expected       bool
+	}{
+		{
+			name:          "schema agreement",
+			schemaVersion: "v1",
+			schemaVersions: map[string][]string{
+				"v1": {"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+			},
+			expected: false,
+		},
+		{
+			name:          "node holding the majority version",
+			schemaVersion: "v2",
+			schemaVersions: map[string][]string{
+				"v1": {"10.0.0.1"},
+				"v2": {"10.0.0.2", "10.0.0.3"},
+			},
+			expected: false,
+		},
+		{
+			name:          "node holding a minority version",
+			schemaVersion: "v1",
+			schemaVersions: map[string][]string{
+				"v1": {"10.0.0.1"},
+				"v2": {"10.0.0.2", "10.0.0.3"},
+			},
+			expected: true,
+		},
+		{
+			name:          "node version missing from the observed versions",
+			schemaVersion: "v3",
+			schemaVersions: map[string][]string{
+				"v2": {"10.0.0.2", "10.0.0.3"},
+			},
+			expected: true,
+		},
+		{
+			name:          "tie between versions",
+			schemaVersion: "v1",
+			schemaVersions: map[string][]string{
+				"v1": {"10.0.0.1"},
+				"v2": {"10.0.0.2"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := isSchemaMigrating(tc.schemaVersion, tc.schemaVersions)
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetSchemaMigratingNodesStatus(t *testing.T) {
+	t.Parallel()
+
+	newStatus := func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:                 "a",
+					SchemaMigratingNodes: pointer.Ptr(int32(2)),
+				},
+				{
+					Name: "b",
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                         string
+		rackMigrating                map[string][]bool
+		expectedSchemaMigratingNodes []*int32
+	}{
+		{
+			name:                         "no node reported its schema state",
+			rackMigrating:                map[string][]bool{},
+			expectedSchemaMigratingNodes: []*int32{nil, nil},
+		},
+		{
+			name: "migrating nodes are counted per rack",
+			rackMigrating: map[string][]bool{
+				"a": {true, false, true},
+				"b": {false},
+			},
+			expectedSchemaMigratingNodes: []*int32{pointer.Ptr(int32(2)), pointer.Ptr(int32(0))},
+		},
+		{
+			name: "rack without reporting nodes is unset",
+			rackMigrating: map[string][]bool{
+				"b": {true},
+			},
+			expectedSchemaMigratingNodes: []*int32{nil, pointer.Ptr(int32(1))},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newStatus()
+			setSchemaMigratingNodesStatus(status, tc.rackMigrating)
+
+			var gotSchemaMigratingNodes []*int32
+			for _, rs := range status.Racks {
+				gotSchemaMigratingNodes = append(gotSchemaMigratingNodes, rs.SchemaMigratingNodes)
+			}
+			if !apiequality.Semantic.DeepEqual(gotSchemaMigratingNodes, tc.expectedSchemaMigratingNodes) {
+				t.Errorf("expected and got schema migrating nodes differ: %s", cmp.Diff(tc.expectedSchemaMigratingNodes, gotSchemaMigratingNodes))
+			}
+		})
+	}
+}
+
+func TestSetMembersStatus(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic",
+			Namespace: "default",
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{
+					Name: "a",
+					RackTemplate: scyllav1alpha1.RackTemplate{
+						Nodes: pointer.Ptr(int32(2)),
+					},
+				},
+			},
+		},
+	}
+
+	services := map[string]*corev1.Service{
+		"basic-basic-a-0": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "basic-basic-a-0",
+				Annotations: map[string]string{
+					naming.HostIDAnnotation: "host-id-0",
+				},
+			},
+		},
+		"basic-basic-a-1": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "basic-basic-a-1",
+			},
+		},
+	}
+
+	readyCondition := corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"}
+	containersReadyCondition := corev1.PodCondition{Type: corev1.ContainersReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady"}
+	pods := map[string]*corev1.Pod{
+		"basic-basic-a-0": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "basic-basic-a-0",
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+					readyCondition,
+					containersReadyCondition,
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "scylladb-api-status-probe", RestartCount: 7},
+					{Name: naming.ScyllaContainerName, RestartCount: 3},
+				},
+			},
+		},
+	}
+
+	tt := []struct {
+		name              string
+		includePodDetails bool
+		expectedMembers   []scyllav1alpha1.MemberStatus
+	}{
+		{
+			name:              "members are reported without pod details",
+			includePodDetails: false,
+			expectedMembers: []scyllav1alpha1.MemberStatus{
+				{Name: "basic-basic-a-0", HostID: "host-id-0"},
+				{Name: "basic-basic-a-1"},
+			},
+		},
+		{
+			name:              "members are reported with pod details of existing pods",
+			includePodDetails: true,
+			expectedMembers: []scyllav1alpha1.MemberStatus{
+				{
+					Name:          "basic-basic-a-0",
+					HostID:        "host-id-0",
+					PodConditions: []corev1.PodCondition{readyCondition, containersReadyCondition},
+					RestartCount:  pointer.Ptr(int32(3)),
+				},
+				{Name: "basic-basic-a-1"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				Racks: []scyllav1alpha1.RackStatus{
+					{Name: "a"},
+				},
+			}
+			setMembersStatus(sdc, status, services, pods, tc.includePodDetails)
+
+			if !apiequality.Semantic.DeepEqual(status.Racks[0].Members, tc.expectedMembers) {
+				t.Errorf("expected and got members differ: %s", cmp.Diff(tc.expectedMembers, status.Racks[0].Members))
+			}
+		})
+	}
+}
+
+func TestHasCompactedUserData(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name               string
+		compactedKeyspaces []string
+		expected           bool
+	}{
+		{
+			name:               "no compactions",
+			compactedKeyspaces: nil,
+			expected:           false,
+		},
+		{
+			name:               "only system keyspaces were compacted",
+			compactedKeyspaces: []string{"system", "system_schema", "system_distributed"},
+			expected:           false,
+		},
+		{
+			name:               "user keyspace was compacted",
+			compactedKeyspaces: []string{"system", "users"},
+			expected:           true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := hasCompactedUserData(tc.compactedKeyspaces)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetCompactedNodesStatus(t *testing.T) {
+	t.Parallel()
+
+	newStatus := func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:           "a",
+					CompactedNodes: pointer.Ptr(int32(2)),
+				},
+				{
+					Name: "b",
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		rackCompacted          map[string][]bool
+		expectedCompactedNodes []*int32
+	}{
+		{
+			name:                   "no node reported its compaction history",
+			rackCompacted:          map[string][]bool{},
+			expectedCompactedNodes: []*int32{nil, nil},
+		},
+		{
+			name: "compacted nodes are counted per rack",
+			rackCompacted: map[string][]bool{
+				"a": {true, false, true},
+				"b": {false},
+			},
+			expectedCompactedNodes: []*int32{pointer.Ptr(int32(2)), pointer.Ptr(int32(0))},
+		},
+		{
+			name: "rack without reporting nodes is unset",
+			rackCompacted: map[string][]bool{
+				"b": {true},
+			},
+			expectedCompactedNodes: []*int32{nil, pointer.Ptr(int32(1))},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newStatus()
+			setCompactedNodesStatus(status, tc.rackCompacted)
+
+			var gotCompactedNodes []*int32
+			for _, rs := range status.Racks {
+				gotCompactedNodes = append(gotCompactedNodes, rs.CompactedNodes)
+			}
+			if !apiequality.Semantic.DeepEqual(gotCompactedNodes, tc.expectedCompactedNodes) {
+				t.Errorf("expected and got compacted nodes differ: %s", cmp.Diff(tc.expectedCompactedNodes, gotCompactedNodes))
+			}
+		})
+	}
+}
+
+func TestGetRackStaleSince(t *testing.T) {
+	t.Parallel()
+
+	staleSince := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC))
+
+	newStatus := func(stale bool, staleSince *metav1.Time) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:       "a",
+					Stale:      pointer.Ptr(stale),
+					StaleSince: staleSince,
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name               string
+		oldStatus          *scyllav1alpha1.ScyllaDBDatacenterStatus
+		rackName           string
+		stale              bool
+		expectedStaleSince *metav1.Time
+	}{
+		{
+			name:               "rack that isn't stale has no stale time",
+			oldStatus:          newStatus(false, nil),
+			rackName:           "a",
+			stale:              false,
+			expectedStaleSince: nil,
+		},
+		{
+			name:               "rack that becomes stale is stale since now",
+			oldStatus:          newStatus(false, nil),
+			rackName:           "a",
+			stale:              true,
+			expectedStaleSince: pointer.Ptr(metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))),
+		},
+		{
+			name:               "new rack that is stale is stale since now",
+			oldStatus:          newStatus(true, &staleSince),
+			rackName:           "b",
+			stale:              true,
+			expectedStaleSince: pointer.Ptr(metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))),
+		},
+		{
+			name:               "rack that stays stale keeps its stale time",
+			oldStatus:          newStatus(true, &staleSince),
+			rackName:           "a",
+			stale:              true,
+			expectedStaleSince: &staleSince,
+		},
+		{
+			name:               "rack that recovers has no stale time",
+			oldStatus:          newStatus(true, &staleSince),
+			rackName:           "a",
+			stale:              false,
+			expectedStaleSince: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := getRackStaleSince(tc.oldStatus, tc.rackName, tc.stale, now)
+			if !apiequality.Semantic.DeepEqual(got, tc.expectedStaleSince) {
+				t.Errorf("expected and got stale times differ: %s", cmp.Diff(tc.expectedStaleSince, got))
+			}
+		})
+	}
+}
+
+func TestSetUsedDataBytesStatus(t *testing.T) {
+	t.Parallel()
+
+	newStatus := func(usedDataBytesA, usedDataBytesB *int64) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:          "a",
+					UsedDataBytes: usedDataBytesA,
+				},
+				{
+					Name:          "b",
+					UsedDataBytes: usedDataBytesB,
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                  string
+		oldStatus             *scyllav1alpha1.ScyllaDBDatacenterStatus
+		rackLoads             map[string][]nodeLoad
+		expectedUsedDataBytes []*int64
+	}{
+		{
+			name:                  "no node reported its load",
+			oldStatus:             newStatus(pointer.Ptr(int64(1000)), nil),
+			rackLoads:             map[string][]nodeLoad{},
+			expectedUsedDataBytes: []*int64{nil, nil},
+		},
+		{
+			name:      "loads of nodes are summed per rack",
+			oldStatus: newStatus(nil, nil),
+			rackLoads: map[string][]nodeLoad{
+				"a": {{bytes: 1000}, {bytes: 0}, {bytes: 500}},
+				"b": {{bytes: 700}},
+			},
+			expectedUsedDataBytes: []*int64{pointer.Ptr(int64(1500)), pointer.Ptr(int64(700))},
+		},
+		{
+			name:      "small changes keep the previously reported value",
+			oldStatus: newStatus(pointer.Ptr(int64(10000)), pointer.Ptr(int64(10000))),
+			rackLoads: map[string][]nodeLoad{
+				"a": {{bytes: 10100}},
+				"b": {{bytes: 9900}},
+			},
+			expectedUsedDataBytes: []*int64{pointer.Ptr(int64(10000)), pointer.Ptr(int64(10000))},
+		},
+		{
+			name:      "changes above the tolerance are reported",
+			oldStatus: newStatus(pointer.Ptr(int64(10000)), pointer.Ptr(int64(10000))),
+			rackLoads: map[string][]nodeLoad{
+				"a": {{bytes: 10101}},
+				"b": {{bytes: 0}},
+			},
+			expectedUsedDataBytes: []*int64{pointer.Ptr(int64(10101)), pointer.Ptr(int64(0))},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := tc.oldStatus.DeepCopy()
+			setUsedDataBytesStatus(tc.oldStatus, status, tc.rackLoads)
+
+			var gotUsedDataBytes []*int64
+			for _, rs := range status.Racks {
+				gotUsedDataBytes = append(gotUsedDataBytes, rs.UsedDataBytes)
+			}
+			if !apiequality.Semantic.DeepEqual(gotUsedDataBytes, tc.expectedUsedDataBytes) {
+				t.Errorf("expected and got used data bytes differ: %s", cmp.Diff(tc.expectedUsedDataBytes, gotUsedDataBytes))
+			}
+		})
+	}
+}
+
+func TestSetResourceUtilizationStatus(t *testing.T) {
+	t.Parallel()
+
+	newPod := func(name, rack string, requests corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					naming.RackNameLabel: rack,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: naming.ScyllaContainerName,
+						Resources: corev1.ResourceRequirements{
+							Requests: requests,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	}
+
+	newStatus := func() *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:                     "a",
+					CPUUtilizationPercent:    pointer.Ptr(int32(10)),
+					MemoryUtilizationPercent: pointer.Ptr(int32(10)),
+				},
+				{
+					Name: "b",
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                string
+		pods                []*corev1.Pod
+		usages              map[string]corev1.ResourceList
+		expectedUtilization [][2]*int32
+	}{
+		{
+			name: "metrics API isn't available",
+			pods: []*corev1.Pod{
+				newPod("a-0", "a", requests),
+			},
+			usages:              nil,
+			expectedUtilization: [][2]*int32{{nil, nil}, {nil, nil}},
+		},
+		{
+			name: "utilization is averaged per rack",
+			pods: []*corev1.Pod{
+				newPod("a-0", "a", requests),
+				newPod("a-1", "a", requests),
+				newPod("b-0", "b", requests),
+			},
+			usages: map[string]corev1.ResourceList{
+				"a-0": {
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+				"a-1": {
+					corev1.ResourceCPU:    resource.MustParse("1500m"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+				"b-0": {
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			expectedUtilization: [][2]*int32{
+				{pointer.Ptr(int32(50)), pointer.Ptr(int32(75))},
+				{pointer.Ptr(int32(50)), pointer.Ptr(int32(25))},
+			},
+		},
+		{
+			name: "pods without usage or requests of a resource aren't counted for it",
+			pods: []*corev1.Pod{
+				newPod("a-0", "a", requests),
+				newPod("a-1", "a", corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+				}),
+				newPod("b-0", "b", requests),
+			},
+			usages: map[string]corev1.ResourceList{
+				"a-0": {
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+				"a-1": {
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			expectedUtilization: [][2]*int32{
+				{pointer.Ptr(int32(50)), pointer.Ptr(int32(25))},
+				{nil, nil},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newStatus()
+			setResourceUtilizationStatus(status, tc.pods, tc.usages)
+
+			var gotUtilization [][2]*int32
+			for _, rs := range status.Racks {
+				gotUtilization = append(gotUtilization, [2]*int32{rs.CPUUtilizationPercent, rs.MemoryUtilizationPercent})
+			}
+			if !apiequality.Semantic.DeepEqual(gotUtilization, tc.expectedUtilization) {
+				t.Errorf("expected and got utilization differ: %s", cmp.Diff(tc.expectedUtilization, gotUtilization))
+			}
+		})
+	}
+}
+
+func TestSetSeedUnreachableStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+				{Name: "c"},
+			},
+		},
+	}
+
+	unknownCondition := &metav1.Condition{
+		Type:               scyllav1alpha1.SeedUnreachableCondition,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "SeedReachabilityUnknown",
+		Message:            "Seeds or gossip views of nodes couldn't be determined.",
+		ObservedGeneration: 2,
+	}
+
+	tt := []struct {
+		name              string
+		seeds             []string
+		rackUpHosts       map[string][][]string
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:  "no node reported its gossip view",
+			seeds: []string{"10.0.0.1"},
+			rackUpHosts: map[string][][]string{
+				"a": {},
+			},
+			expectedCondition: unknownCondition,
+		},
+		{
+			name:  "no seeds are known",
+			seeds: nil,
+			rackUpHosts: map[string][][]string{
+				"a": {{"10.0.0.1"}},
+			},
+			expectedCondition: unknownCondition,
+		},
+		{
+			name:  "seeds are reachable from all reporting racks",
+			seeds: []string{"10.0.0.1", "192.168.0.1"},
+			rackUpHosts: map[string][][]string{
+				"a": {{"10.0.0.1", "192.168.0.1"}},
+				"b": {{"10.0.0.2"}, {"10.0.0.1", "192.168.0.1"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.SeedUnreachableCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:  "seeds that no node of a rack sees as up are reported",
+			seeds: []string{"10.0.0.1", "192.168.0.1"},
+			rackUpHosts: map[string][][]string{
+				"a": {{"10.0.0.1", "192.168.0.1"}},
+				"b": {{"10.0.0.2"}, {"10.0.0.3"}},
+				"c": {{"10.0.0.1"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.SeedUnreachableCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "SeedsUnreachable",
+				Message:            `Seed "10.0.0.1" is unreachable from rack(s) b. Seed "192.168.0.1" is unreachable from rack(s) b, c.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setSeedUnreachableStatusCondition(sdc, status, tc.seeds, tc.rackUpHosts)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.SeedUnreachableCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetDataUnderReplicatedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	tt := []struct {
+		name              string
+		downHosts         []string
+		keyspaceReplicas  map[string][][]string
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:             "replication of no keyspace is known",
+			downHosts:        []string{"10.0.0.3"},
+			keyspaceReplicas: nil,
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DataUnderReplicatedCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "ReplicationUnknown",
+				Message:            "Replication of keyspaces couldn't be determined.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:      "no replica is down",
+			downHosts: []string{"10.0.0.4"},
+			keyspaceReplicas: map[string][][]string{
+				"ks": {{"10.0.0.1", "10.0.0.2"}, {"10.0.0.2", "10.0.0.3"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DataUnderReplicatedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:      "keyspaces with replicas on down nodes are reported",
+			downHosts: []string{"10.0.0.3", "10.0.0.1"},
+			keyspaceReplicas: map[string][][]string{
+				"ks_b":  {{"10.0.0.1", "10.0.0.2"}},
+				"ks_a":  {{"10.0.0.2", "10.0.0.4"}, {"10.0.0.3", "10.0.0.4"}},
+				"other": {{"10.0.0.2", "10.0.0.4"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DataUnderReplicatedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "ReplicasDown",
+				Message:            "Keyspace(s) ks_a, ks_b have replicas on down node(s) 10.0.0.1, 10.0.0.3. Repair or replace the nodes.",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setDataUnderReplicatedStatusCondition(sdc, status, tc.downHosts, tc.keyspaceReplicas)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.DataUnderReplicatedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetTopologyMismatchStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			DatacenterName: pointer.Ptr("us-east-1"),
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	tt := []struct {
+		name               string
+		rackNodeTopologies map[string][]nodeTopology
+		expectedCondition  *metav1.Condition
+	}{
+		{
+			name:               "no node reported its topology",
+			rackNodeTopologies: map[string][]nodeTopology{},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.TopologyMismatchCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "TopologyUnknown",
+				Message:            "Topology couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "all nodes report the expected topology",
+			rackNodeTopologies: map[string][]nodeTopology{
+				"a": {{host: "10.0.0.1", datacenter: "us-east-1", rack: "a"}},
+				"b": {{host: "10.0.0.2", datacenter: "us-east-1", rack: "b"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.TopologyMismatchCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes reporting a different datacenter or rack are reported",
+			rackNodeTopologies: map[string][]nodeTopology{
+				"a": {
+					{host: "10.0.0.1", datacenter: "us-east-1", rack: "a"},
+					{host: "10.0.0.3", datacenter: "datacenter1", rack: "rack1"},
+				},
+				"b": {{host: "10.0.0.2", datacenter: "us-east-1", rack: "a"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.TopologyMismatchCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "NodeTopologyMismatch",
+				Message:            `Node "10.0.0.3" reports datacenter "datacenter1" and rack "rack1", expected datacenter "us-east-1" and rack "a". Node "10.0.0.2" reports datacenter "us-east-1" and rack "a", expected datacenter "us-east-1" and rack "b".`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setTopologyMismatchStatusCondition(sdc, status, tc.rackNodeTopologies)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.TopologyMismatchCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestGetExpectedServiceLevels(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                  string
+		annotations           map[string]string
+		expectedServiceLevels []string
+	}{
+		{
+			name:                  "no annotation",
+			annotations:           nil,
+			expectedServiceLevels: nil,
+		},
+		{
+			name: "service levels are trimmed and empty ones are skipped",
+			annotations: map[string]string{
+				naming.ExpectedServiceLevelsAnnotation: " oltp, ,olap,",
+			},
+			expectedServiceLevels: []string{"oltp", "olap"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+
+			got := getExpectedServiceLevels(sdc)
+			if !apiequality.Semantic.DeepEqual(got, tc.expectedServiceLevels) {
+				t.Errorf("expected and got service levels differ: %s", cmp.Diff(tc.expectedServiceLevels, got))
+			}
+		})
+	}
+}
+
+func TestSetWorkloadPrioritizationMisconfiguredStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	tt := []struct {
+		name                  string
+		rackNodeServiceLevels map[string][]nodeServiceLevels
+		expectedCondition     *metav1.Condition
+	}{
+		{
+			name:                  "no node reported its scheduling groups",
+			rackNodeServiceLevels: map[string][]nodeServiceLevels{},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "ServiceLevelsUnknown",
+				Message:            "Scheduling groups couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "all nodes have scheduling groups of the expected service levels",
+			rackNodeServiceLevels: map[string][]nodeServiceLevels{
+				"a": {{host: "10.0.0.1", serviceLevels: []string{"default", "oltp", "olap"}}},
+				"b": {{host: "10.0.0.2", serviceLevels: []string{"olap", "oltp"}}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes missing scheduling groups of expected service levels are reported",
+			rackNodeServiceLevels: map[string][]nodeServiceLevels{
+				"a": {
+					{host: "10.0.0.1", serviceLevels: []string{"oltp", "olap"}},
+					{host: "10.0.0.3", serviceLevels: []string{"default"}},
+				},
+				"b": {{host: "10.0.0.2", serviceLevels: []string{"oltp"}}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "ServiceLevelsMissing",
+				Message:            `Node "10.0.0.3" doesn't have scheduling groups of service level(s) oltp, olap. Node "10.0.0.2" doesn't have scheduling groups of service level(s) olap.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setWorkloadPrioritizationMisconfiguredStatusCondition(sdc, status, []string{"oltp", "olap"}, tc.rackNodeServiceLevels)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.WorkloadPrioritizationMisconfiguredCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetConsistentTopologyEnabledStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	tt := []struct {
+		name              string
+		rackNodeStates    map[string][]nodeTopologyUpgradeState
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:           "no node reported its topology upgrade state",
+			rackNodeStates: map[string][]nodeTopologyUpgradeState{},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "ConsistentTopologyUnknown",
+				Message:            "Topology upgrade state couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "all nodes finished the upgrade to raft-based topology",
+			rackNodeStates: map[string][]nodeTopologyUpgradeState{
+				"a": {{host: "10.0.0.1", state: "done"}},
+				"b": {{host: "10.0.0.2", state: "done"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "TopologyUpgradeDone",
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes that don't support raft-based topology are reported",
+			rackNodeStates: map[string][]nodeTopologyUpgradeState{
+				"a": {{host: "10.0.0.1", state: "done"}},
+				"b": {{host: "10.0.0.2"}, {host: "10.0.0.3", state: "not_upgraded"}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ConsistentTopologyUnsupported",
+				Message:            "Node(s) 10.0.0.2 run a ScyllaDB version that doesn't support raft-based topology.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes that didn't finish the upgrade to raft-based topology are reported by state",
+			rackNodeStates: map[string][]nodeTopologyUpgradeState{
+				"a": {
+					{host: "10.0.0.1", state: "not_upgraded"},
+					{host: "10.0.0.3", state: "done"},
+				},
+				"b": {
+					{host: "10.0.0.2", state: "build_coordinator_state"},
+					{host: "10.0.0.4", state: "not_upgraded"},
+				},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ConsistentTopologyEnabledCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "TopologyUpgradeNotDone",
+				Message:            `Upgrade to raft-based topology is in state "not_upgraded" according to node(s) 10.0.0.1, 10.0.0.4. Upgrade to raft-based topology is in state "build_coordinator_state" according to node(s) 10.0.0.2.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setConsistentTopologyEnabledStatusCondition(sdc, status, tc.rackNodeStates)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.ConsistentTopologyEnabledCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetMaxPartitionBytesStatus(t *testing.T) {
+	t.Parallel()
+
+	status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+		Racks: []scyllav1alpha1.RackStatus{
+			{
+				Name:              "a",
+				MaxPartitionBytes: pointer.Ptr(int64(42)),
+			},
+			{
+				Name:              "b",
+				MaxPartitionBytes: pointer.Ptr(int64(42)),
+			},
+		},
+	}
+
+	setMaxPartitionBytesStatus(status, map[string][]nodeMaxPartitionSize{
+		"a": {
+			{host: "10.0.0.1", bytes: 1000},
+			{host: "10.0.0.2", bytes: 3000},
+			{host: "10.0.0.3", bytes: 0},
+		},
+	})
+
+	var gotMaxPartitionBytes []*int64
+	for _, rs := range status.Racks {
+		gotMaxPartitionBytes = append(gotMaxPartitionBytes, rs.MaxPartitionBytes)
+	}
+	expectedMaxPartitionBytes := []*int64{pointer.Ptr(int64(3000)), nil}
+	if !apiequality.Semantic.DeepEqual(gotMaxPartitionBytes, expectedMaxPartitionBytes) {
+		t.Errorf("expected and got max partition bytes differ: %s", cmp.Diff(expectedMaxPartitionBytes, gotMaxPartitionBytes))
+	}
+}
+
+func TestSetLargePartitionDetectedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	tt := []struct {
+		name              string
+		rackSizes         map[string][]nodeMaxPartitionSize
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:      "no node reported its max partition size",
+			rackSizes: map[string][]nodeMaxPartitionSize{},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.LargePartitionDetectedCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "PartitionSizesUnknown",
+				Message:            "Partition sizes couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "partitions up to the threshold aren't reported",
+			rackSizes: map[string][]nodeMaxPartitionSize{
+				"a": {{host: "10.0.0.1", bytes: 1000}},
+				"b": {{host: "10.0.0.2", bytes: 200}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.LargePartitionDetectedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes with partitions above the threshold are reported",
+			rackSizes: map[string][]nodeMaxPartitionSize{
+				"a": {
+					{host: "10.0.0.1", bytes: 1001},
+					{host: "10.0.0.3", bytes: 10},
+				},
+				"b": {{host: "10.0.0.2", bytes: 5000}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.LargePartitionDetectedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "LargePartitionsFound",
+				Message:            `Node "10.0.0.1" in rack "a" has a partition of 1001 bytes. Node "10.0.0.2" in rack "b" has a partition of 5000 bytes. Partitions larger than 1000 bytes cause latency spikes and instability.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setLargePartitionDetectedStatusCondition(sdc, status, tc.rackSizes, 1000)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.LargePartitionDetectedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestGetClockSkews(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name              string
+		rackOffsets       map[string][]nodeClockOffset
+		expectedRackSkews map[string][]nodeClockOffset
+	}{
+		{
+			name:              "no node reported its clock",
+			rackOffsets:       map[string][]nodeClockOffset{},
+			expectedRackSkews: map[string][]nodeClockOffset{},
+		},
+		{
+			name: "skews are relative to the median of an odd number of clocks",
+			rackOffsets: map[string][]nodeClockOffset{
+				"a": {
+					{host: "10.0.0.1", offset: 2 * time.Second},
+					{host: "10.0.0.2", offset: 3 * time.Second},
+				},
+				"b": {{host: "10.0.0.3", offset: 20 * time.Second}},
+			},
+			expectedRackSkews: map[string][]nodeClockOffset{
+				"a": {
+					{host: "10.0.0.1", offset: -time.Second},
+					{host: "10.0.0.2", offset: 0},
+				},
+				"b": {{host: "10.0.0.3", offset: 17 * time.Second}},
+			},
+		},
+		{
+			name: "skews are relative to the median of an even number of clocks",
+			rackOffsets: map[string][]nodeClockOffset{
+				"a": {
+					{host: "10.0.0.1", offset: 0},
+					{host: "10.0.0.2", offset: 2 * time.Second},
+				},
+			},
+			expectedRackSkews: map[string][]nodeClockOffset{
+				"a": {
+					{host: "10.0.0.1", offset: -time.Second},
+					{host: "10.0.0.2", offset: time.Second},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := getClockSkews(tc.rackOffsets)
+			if !reflect.DeepEqual(got, tc.expectedRackSkews) {
+				t.Errorf("expected and got clock skews differ: %s", cmp.Diff(tc.expectedRackSkews, got, cmp.AllowUnexported(nodeClockOffset{})))
+			}
+		})
+	}
+}
+
+func TestSetClockSkewDetectedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	tt := []struct {
+		name                     string
+		rackSkews                map[string][]nodeClockOffset
+		expectedClockSkewedNodes []*int32
+		expectedCondition        *metav1.Condition
+	}{
+		{
+			name:                     "no node reported its clock",
+			rackSkews:                map[string][]nodeClockOffset{},
+			expectedClockSkewedNodes: []*int32{nil, nil},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ClockSkewDetectedCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "ClockSkewUnknown",
+				Message:            "Clocks couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "skews within the threshold aren't reported",
+			rackSkews: map[string][]nodeClockOffset{
+				"a": {{host: "10.0.0.1", offset: 5 * time.Second}},
+				"b": {{host: "10.0.0.2", offset: -5 * time.Second}},
+			},
+			expectedClockSkewedNodes: []*int32{pointer.Ptr(int32(0)), pointer.Ptr(int32(0))},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ClockSkewDetectedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes with skews beyond the threshold are counted and listed",
+			rackSkews: map[string][]nodeClockOffset{
+				"a": {
+					{host: "10.0.0.1", offset: 7 * time.Second},
+					{host: "10.0.0.3", offset: 0},
+				},
+				"b": {{host: "10.0.0.2", offset: -6 * time.Second}},
+			},
+			expectedClockSkewedNodes: []*int32{pointer.Ptr(int32(1)), pointer.Ptr(int32(1))},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.ClockSkewDetectedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "ClockSkewFound",
+				Message:            `Node "10.0.0.1" in rack "a" has a clock skew of 7s. Node "10.0.0.2" in rack "b" has a clock skew of -6s. Clock skews are relative to the median clock of the nodes and have to stay within 5s.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				Racks: []scyllav1alpha1.RackStatus{
+					{Name: "a"},
+					{Name: "b"},
+				},
+			}
+			setClockSkewedNodesStatus(status, tc.rackSkews, 5*time.Second)
+			setClockSkewDetectedStatusCondition(sdc, status, tc.rackSkews, 5*time.Second)
+
+			var gotClockSkewedNodes []*int32
+			for _, rs := range status.Racks {
+				gotClockSkewedNodes = append(gotClockSkewedNodes, rs.ClockSkewedNodes)
+			}
+			if !apiequality.Semantic.DeepEqual(gotClockSkewedNodes, tc.expectedClockSkewedNodes) {
+				t.Errorf("expected and got clock skewed nodes differ: %s", cmp.Diff(tc.expectedClockSkewedNodes, gotClockSkewedNodes))
+			}
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.ClockSkewDetectedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetTokenRangeOverlapStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	tt := []struct {
+		name              string
+		rackNodeTokens    map[string][]nodeTokens
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:           "no node reported its tokens",
+			rackNodeTokens: map[string][]nodeTokens{},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.TokenRangeOverlapCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "TokensUnknown",
+				Message:            "No node reported the tokens it owns.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "disjoint tokens",
+			rackNodeTokens: map[string][]nodeTokens{
+				"a": {
+					{host: "10.0.0.1", tokens: []string{"-100", "200"}},
+					{host: "10.0.0.2", tokens: []string{"-50", "300"}},
+				},
+				"b": {
+					{host: "10.0.0.3", tokens: []string{"0"}},
+				},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.TokenRangeOverlapCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "tokens claimed by nodes across racks are reported in numerical order",
+			rackNodeTokens: map[string][]nodeTokens{
+				"a": {
+					{host: "10.0.0.1", tokens: []string{"-100", "200", "1000"}},
+				},
+				"b": {
+					{host: "10.0.0.3", tokens: []string{"1000", "-100"}},
+				},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.TokenRangeOverlapCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "TokensClaimedByMultipleNodes",
+				Message:            "2 token(s) are claimed by more than one node: token -100 is claimed by nodes 10.0.0.1, 10.0.0.3; token 1000 is claimed by nodes 10.0.0.1, 10.0.0.3.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "number of detailed tokens is capped",
+			rackNodeTokens: map[string][]nodeTokens{
+				"a": {
+					{host: "10.0.0.1", tokens: []string{"1", "2", "3", "4", "5", "6", "7"}},
+					{host: "10.0.0.2", tokens: []string{"1", "2", "3", "4", "5", "6", "7"}},
+				},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.TokenRangeOverlapCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "TokensClaimedByMultipleNodes",
+				Message:            "7 token(s) are claimed by more than one node: token 1 is claimed by nodes 10.0.0.1, 10.0.0.2; token 2 is claimed by nodes 10.0.0.1, 10.0.0.2; token 3 is claimed by nodes 10.0.0.1, 10.0.0.2; token 4 is claimed by nodes 10.0.0.1, 10.0.0.2; token 5 is claimed by nodes 10.0.0.1, 10.0.0.2; and 2 more.",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setTokenRangeOverlapStatusCondition(sdc, status, tc.rackNodeTokens)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.TokenRangeOverlapCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestIsSchemaOverviewStale(t *testing.T) {
+	t.Parallel()
+
+	const refreshInterval = 10 * time.Minute
+
+	now := metav1.NewTime(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC))
+
+	tt := []struct {
+		name     string
+		schema   *scyllav1alpha1.SchemaStatus
+		expected bool
+	}{
+		{
+			name:     "missing overview is stale",
+			schema:   nil,
+			expected: true,
+		},
+		{
+			name: "recently refreshed overview isn't stale",
+			schema: &scyllav1alpha1.SchemaStatus{
+				LastRefreshTime: metav1.NewTime(now.Add(-refreshInterval + time.Second)),
+			},
+			expected: false,
+		},
+		{
+			name: "overview refreshed a refresh interval ago is stale",
+			schema: &scyllav1alpha1.SchemaStatus{
+				LastRefreshTime: metav1.NewTime(now.Add(-refreshInterval)),
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := isSchemaOverviewStale(tc.schema, now, refreshInterval)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMakeSchemaStatus(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.NewTime(time.Date(2024, 1, 1, 1, 0, 0, 500, time.UTC))
+
+	manyKeyspaces := map[string]int32{}
+	for i := range maxSchemaOverviewKeyspaces + 2 {
+		manyKeyspaces[fmt.Sprintf("ks_%03d", i)] = 1
+	}
+
+	tt := []struct {
+		name                   string
+		tableCounts            map[string]int32
+		expectedKeyspaces      []scyllav1alpha1.KeyspaceStatus
+		expectedTotalKeyspaces int32
+	}{
+		{
+			name:                   "no keyspaces",
+			tableCounts:            map[string]int32{},
+			expectedKeyspaces:      nil,
+			expectedTotalKeyspaces: 0,
+		},
+		{
+			name: "keyspaces are sorted by name",
+			tableCounts: map[string]int32{
+				"system":      30,
+				"my_keyspace": 2,
+				"empty":       0,
+			},
+			expectedKeyspaces: []scyllav1alpha1.KeyspaceStatus{
+				{Name: "empty", Tables: 0},
+				{Name: "my_keyspace", Tables: 2},
+				{Name: "system", Tables: 30},
+			},
+			expectedTotalKeyspaces: 3,
+		},
+		{
+			name:        "number of listed keyspaces is capped",
+			tableCounts: manyKeyspaces,
+			expectedKeyspaces: func() []scyllav1alpha1.KeyspaceStatus {
+				var keyspaces []scyllav1alpha1.KeyspaceStatus
+				for i := range maxSchemaOverviewKeyspaces {
+					keyspaces = append(keyspaces, scyllav1alpha1.KeyspaceStatus{Name: fmt.Sprintf("ks_%03d", i), Tables: 1})
+				}
+				return keyspaces
+			}(),
+			expectedTotalKeyspaces: maxSchemaOverviewKeyspaces + 2,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := makeSchemaStatus(tc.tableCounts, now)
+			expected := &scyllav1alpha1.SchemaStatus{
+				Keyspaces:       tc.expectedKeyspaces,
+				TotalKeyspaces:  tc.expectedTotalKeyspaces,
+				LastRefreshTime: metav1.NewTime(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)),
+			}
+			if !apiequality.Semantic.DeepEqual(got, expected) {
+				t.Errorf("expected and got schema overviews differ: %s", cmp.Diff(expected, got))
+			}
+		})
+	}
+}
+
+func TestSetQuarantinedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	newScyllaDBDatacenter := func(annotations map[string]string) *scyllav1alpha1.ScyllaDBDatacenter {
+		return &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "basic",
+				Namespace:   "default",
+				Generation:  2,
+				Annotations: annotations,
+			},
+		}
+	}
+
+	tt := []struct {
+		name              string
+		sdc               *scyllav1alpha1.ScyllaDBDatacenter
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "datacenter without quarantine annotation isn't quarantined",
+			sdc:  newScyllaDBDatacenter(nil),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.QuarantinedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "datacenter with empty quarantine annotation is quarantined",
+			sdc: newScyllaDBDatacenter(map[string]string{
+				naming.QuarantineAnnotation: "",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.QuarantinedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "QuarantineAnnotationPresent",
+				Message:            `ScyllaDBDatacenter is quarantined with "scylla-operator.scylladb.com/quarantine" annotation. Orchestration actions are suspended until it is removed.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "quarantine reason is included in the message",
+			sdc: newScyllaDBDatacenter(map[string]string{
+				naming.QuarantineAnnotation: "investigating incident 42",
+			}),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.QuarantinedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "QuarantineAnnotationPresent",
+				Message:            `ScyllaDBDatacenter is quarantined with "scylla-operator.scylladb.com/quarantine" annotation. Orchestration actions are suspended until it is removed. Quarantine reason: investigating incident 42`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setQuarantinedStatusCondition(tc.sdc, status)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.QuarantinedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestGetScyllaDBContainerStartTimes(t *testing.T) {
+	t.Parallel()
+
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	newPod := func(containerStatuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: containerStatuses,
+			},
+		}
+	}
+
+	newRunningContainerStatus := func(name string, startedAt time.Time) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name: name,
+			State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{
+					StartedAt: metav1.NewTime(startedAt),
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name             string
+		pods             []*corev1.Pod
+		expectedEarliest *metav1.Time
+		expectedLatest   *metav1.Time
+	}{
+		{
+			name:             "no pods",
+			pods:             nil,
+			expectedEarliest: nil,
+			expectedLatest:   nil,
+		},
+		{
+			name: "pods without running ScyllaDB containers",
+			pods: []*corev1.Pod{
+				newPod(corev1.ContainerStatus{
+					Name: naming.ScyllaContainerName,
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{},
+					},
+				}),
+				newPod(newRunningContainerStatus(naming.ScyllaDBIgnitionContainerName, later)),
+			},
+			expectedEarliest: nil,
+			expectedLatest:   nil,
+		},
+		{
+			name: "range of running ScyllaDB containers",
+			pods: []*corev1.Pod{
+				newPod(newRunningContainerStatus(naming.ScyllaContainerName, later)),
+				newPod(newRunningContainerStatus(naming.ScyllaContainerName, earlier)),
+				newPod(),
+			},
+			expectedEarliest: pointer.Ptr(metav1.NewTime(earlier)),
+			expectedLatest:   pointer.Ptr(metav1.NewTime(later)),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotEarliest, gotLatest := getStartTimeRange(getScyllaDBContainerStartTimes(tc.pods))
+			if !apiequality.Semantic.DeepEqual(gotEarliest, tc.expectedEarliest) {
+				t.Errorf("expected and got earliest start times differ: %s", cmp.Diff(tc.expectedEarliest, gotEarliest))
+			}
+			if !apiequality.Semantic.DeepEqual(gotLatest, tc.expectedLatest) {
+				t.Errorf("expected and got latest start times differ: %s", cmp.Diff(tc.expectedLatest, gotLatest))
+			}
+		})
+	}
+}
+
+func TestSetNodeStartTimesStatus(t *testing.T) {
+	t.Parallel()
+
+	containerStartTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	previous := metav1.NewTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	newStatus := func(earliest, latest *metav1.Time) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		return &scyllav1alpha1.ScyllaDBDatacenterStatus{
+			Racks: []scyllav1alpha1.RackStatus{
+				{
+					Name:                  "a",
+					EarliestNodeStartTime: earliest,
+					LatestNodeStartTime:   latest,
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name             string
+		oldStatus        *scyllav1alpha1.ScyllaDBDatacenterStatus
+		rackStartTimes   map[string][]time.Time
+		expectedEarliest *metav1.Time
+		expectedLatest   *metav1.Time
+	}{
+		{
+			name:             "rack without reported uptimes keeps container start times",
+			oldStatus:        newStatus(nil, nil),
+			rackStartTimes:   map[string][]time.Time{},
+			expectedEarliest: &containerStartTime,
+			expectedLatest:   &containerStartTime,
+		},
+		{
+			name:      "reported start times are truncated to seconds",
+			oldStatus: newStatus(nil, nil),
+			rackStartTimes: map[string][]time.Time{
+				"a": {
+					previous.Add(2*time.Hour + 500*time.Millisecond),
+					previous.Add(time.Hour + 300*time.Millisecond),
+				},
+			},
+			expectedEarliest: pointer.Ptr(metav1.NewTime(previous.Add(time.Hour))),
+			expectedLatest:   pointer.Ptr(metav1.NewTime(previous.Add(2 * time.Hour))),
+		},
+		{
+			name:      "start times within tolerance of the previous ones are kept",
+			oldStatus: newStatus(&previous, &previous),
+			rackStartTimes: map[string][]time.Time{
+				"a": {
+					previous.Add(2 * time.Second),
+					previous.Add(-3 * time.Second),
+				},
+			},
+			expectedEarliest: &previous,
+			expectedLatest:   &previous,
+		},
+		{
+			name:      "restarted node changes the latest start time",
+			oldStatus: newStatus(&previous, &previous),
+			rackStartTimes: map[string][]time.Time{
+				"a": {
+					previous.Add(time.Second),
+					previous.Add(time.Hour),
+				},
+			},
+			expectedEarliest: &previous,
+			expectedLatest:   pointer.Ptr(metav1.NewTime(previous.Add(time.Hour))),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := newStatus(&containerStartTime, &containerStartTime)
+			setNodeStartTimesStatus(tc.oldStatus, status, tc.rackStartTimes)
+
+			gotEarliest, gotLatest := status.Racks[0].EarliestNodeStartTime, status.Racks[0].LatestNodeStartTime
+			if !apiequality.Semantic.DeepEqual(gotEarliest, tc.expectedEarliest) {
+				t.Errorf("expected and got earliest start times differ: %s", cmp.Diff(tc.expectedEarliest, gotEarliest))
+			}
+			if !apiequality.Semantic.DeepEqual(gotLatest, tc.expectedLatest) {
+				t.Errorf("expected and got latest start times differ: %s", cmp.Diff(tc.expectedLatest, gotLatest))
+			}
+		})
+	}
+}
+
+func TestController_calculateRackStatus_ScalingTargetNodes(t *testing.T) {
+	t.Parallel()
+
+	newScyllaDBDatacenter := func(nodes int32) *scyllav1alpha1.ScyllaDBDatacenter {
+		return &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic",
+				Namespace: "default",
+			},
+			Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+				ClusterName: "basic",
+				ScyllaDB: scyllav1alpha1.ScyllaDB{
+					Image: "scylladb/scylla:6.2.0",
+				},
+				Racks: []scyllav1alpha1.RackSpec{
+					{
+						Name: "rack",
+						RackTemplate: scyllav1alpha1.RackTemplate{
+							Nodes: pointer.Ptr(nodes),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newStatefulSet := func(replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic-dc-rack",
+				Namespace: "default",
+				Labels: map[string]string{
+					naming.RackNameLabel: "rack",
+				},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Ptr(replicas),
+			},
+		}
+	}
+
+	tt := []struct {
+		name                       string
+		sdc                        *scyllav1alpha1.ScyllaDBDatacenter
+		sts                        *appsv1.StatefulSet
+		expectedScalingTargetNodes *int32
+	}{
+		{
+			name:                       "rack with desired number of nodes isn't scaling",
+			sdc:                        newScyllaDBDatacenter(3),
+			sts:                        newStatefulSet(3),
+			expectedScalingTargetNodes: nil,
+		},
+		{
+			name:                       "rack scaling up targets the desired number of nodes at once",
+			sdc:                        newScyllaDBDatacenter(5),
+			sts:                        newStatefulSet(3),
+			expectedScalingTargetNodes: pointer.Ptr(int32(5)),
+		},
+		{
+			name:                       "rack scaling down targets one node less as an intermediate step",
+			sdc:                        newScyllaDBDatacenter(2),
+			sts:                        newStatefulSet(5),
+			expectedScalingTargetNodes: pointer.Ptr(int32(4)),
+		},
+		{
+			name:                       "rack scaling down by a single node targets the desired number of nodes",
+			sdc:                        newScyllaDBDatacenter(4),
+			sts:                        newStatefulSet(5),
+			expectedScalingTargetNodes: pointer.Ptr(int32(4)),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sdcc := &Controller{
+				podLister:  corev1listers.NewPodLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})),
+				nodeLister: corev1listers.NewNodeLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+			}
+
+			rackStatus := sdcc.calculateRackStatus(tc.sdc, tc.sts)
+			if !apiequality.Semantic.DeepEqual(rackStatus.ScalingTargetNodes, tc.expectedScalingTargetNodes) {
+				t.Errorf("expected and got scaling target nodes differ: %s", cmp.Diff(tc.expectedScalingTargetNodes, rackStatus.ScalingTargetNodes))
+			}
+		})
+	}
+}
+
+func TestIsRackPlacementUpToDate(t *testing.T) {
+	t.Parallel()
+
+	newNodeAffinity := func(zone string) *corev1.NodeAffinity {
+		return &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "topology.kubernetes.io/zone",
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{zone},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newRack := func(nodeAffinity *corev1.NodeAffinity) scyllav1alpha1.RackSpec {
+		rack := scyllav1alpha1.RackSpec{
+			Name: "a",
+		}
+
+		if nodeAffinity != nil {
+			rack.Placement = &scyllav1alpha1.Placement{
+				NodeAffinity: nodeAffinity,
+			}
+		}
+
+		return rack
+	}
+
+	newSDC := func(rackTemplateNodeAffinity *corev1.NodeAffinity) *scyllav1alpha1.ScyllaDBDatacenter {
+		sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic",
+				Namespace: "default",
+			},
+		}
+
+		if rackTemplateNodeAffinity != nil {
+			sdc.Spec.RackTemplate = &scyllav1alpha1.RackTemplate{
+				Placement: &scyllav1alpha1.Placement{
+					NodeAffinity: rackTemplateNodeAffinity,
+				},
+			}
+		}
+
+		return sdc
+	}
+
+	newStatefulSet := func(affinity *corev1.Affinity) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "basic-dc-a",
+				Namespace: "default",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Affinity: affinity,
+					},
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name     string
+		sdc      *scyllav1alpha1.ScyllaDBDatacenter
+		rack     scyllav1alpha1.RackSpec
+		sts      *appsv1.StatefulSet
+		expected bool
+	}{
+		{
+			name:     "matching node affinity",
+			sdc:      newSDC(nil),
+			rack:     newRack(newNodeAffinity("us-east-1a")),
+			sts:      newStatefulSet(&corev1.Affinity{NodeAffinity: newNodeAffinity("us-east-1a")}),
+			expected: true,
+		},
+		{
+			name:     "node affinity inherited from the rack template matches",
+			sdc:      newSDC(newNodeAffinity("us-east-1a")),
+			rack:     newRack(nil),
+			sts:      newStatefulSet(&corev1.Affinity{NodeAffinity: newNodeAffinity("us-east-1a")}),
+			expected: true,
+		},
+		{
+			name:     "no placement matches an empty affinity",
+			sdc:      newSDC(nil),
+			rack:     newRack(nil),
+			sts:      newStatefulSet(&corev1.Affinity{}),
+			expected: true,
+		},
+		{
+			name:     "no placement matches a missing affinity",
+			sdc:      newSDC(nil),
+			rack:     newRack(nil),
+			sts:      newStatefulSet(nil),
+			expected: true,
+		},
+		{
+			name:     "drifted node affinity",
+			sdc:      newSDC(nil),
+			rack:     newRack(newNodeAffinity("us-east-1b")),
+			sts:      newStatefulSet(&corev1.Affinity{NodeAffinity: newNodeAffinity("us-east-1a")}),
+			expected: false,
+		},
+		{
+			name:     "node affinity not applied yet",
+			sdc:      newSDC(nil),
+			rack:     newRack(newNodeAffinity("us-east-1a")),
+			sts:      newStatefulSet(&corev1.Affinity{}),
+			expected: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := isRackPlacementUpToDate(tc.sdc, tc.rack, tc.sts)
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetPlacementDriftedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	tt := []struct {
+		name              string
+		racks             []scyllav1alpha1.RackStatus
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "all racks have the desired placement",
+			racks: []scyllav1alpha1.RackStatus{
+				{Name: "a", PlacementUpToDate: pointer.Ptr(true)},
+				{Name: "b"},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.PlacementDriftedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "drifted racks are reported",
+			racks: []scyllav1alpha1.RackStatus{
+				{Name: "a", PlacementUpToDate: pointer.Ptr(false)},
+				{Name: "b", PlacementUpToDate: pointer.Ptr(true)},
+				{Name: "c", PlacementUpToDate: pointer.Ptr(false)},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.PlacementDriftedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "AffinityDiffers",
+				Message:            `The Pod template affinity of rack(s) "a", "c" doesn't match the desired placement.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				Racks: tc.racks,
+			}
+			setPlacementDriftedStatusCondition(sdc, status)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.PlacementDriftedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetSnapshotBytesStatus(t *testing.T) {
+	t.Parallel()
+
+	status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+		Racks: []scyllav1alpha1.RackStatus{
+			{
+				Name:          "a",
+				SnapshotBytes: pointer.Ptr(int64(42)),
+			},
+			{
+				Name:          "b",
+				SnapshotBytes: pointer.Ptr(int64(42)),
+			},
+		},
+	}
+
+	setSnapshotBytesStatus(status, map[string][]nodeSnapshotSize{
+		"a": {
+			{host: "10.0.0.1", bytes: 1000},
+			{host: "10.0.0.2", bytes: 3000},
+			{host: "10.0.0.3", bytes: 0},
+		},
+	})
+
+	var gotSnapshotBytes []*int64
+	for _, rs := range status.Racks {
+		gotSnapshotBytes = append(gotSnapshotBytes, rs.SnapshotBytes)
+	}
+	expectedSnapshotBytes := []*int64{pointer.Ptr(int64(4000)), nil}
+	if !apiequality.Semantic.DeepEqual(gotSnapshotBytes, expectedSnapshotBytes) {
+		t.Errorf("expected and got snapshot bytes differ: %s", cmp.Diff(expectedSnapshotBytes, gotSnapshotBytes))
+	}
+}
+
+func TestSetStaleSnapshotsDetectedStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+			},
+		},
+	}
+
+	tt := []struct {
+		name              string
+		rackSizes         map[string][]nodeSnapshotSize
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:      "no node reported its snapshot size",
+			rackSizes: map[string][]nodeSnapshotSize{},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.StaleSnapshotsDetectedCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "SnapshotSizesUnknown",
+				Message:            "Snapshot sizes couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "snapshots up to the threshold aren't reported",
+			rackSizes: map[string][]nodeSnapshotSize{
+				"a": {{host: "10.0.0.1", bytes: 1000}},
+				"b": {{host: "10.0.0.2", bytes: 0}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.StaleSnapshotsDetectedCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "nodes with snapshots above the threshold are reported",
+			rackSizes: map[string][]nodeSnapshotSize{
+				"a": {
+					{host: "10.0.0.1", bytes: 1001},
+					{host: "10.0.0.3", bytes: 10},
+				},
+				"b": {{host: "10.0.0.2", bytes: 5000}},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.StaleSnapshotsDetectedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "SnapshotsAboveThreshold",
+				Message:            `Node "10.0.0.1" in rack "a" has snapshots taking 1001 bytes. Node "10.0.0.2" in rack "b" has snapshots taking 5000 bytes. Snapshots taking more than 1000 bytes should be cleared to avoid running out of disk space.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setStaleSnapshotsDetectedStatusCondition(sdc, status, tc.rackSizes, 1000)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.StaleSnapshotsDetectedCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestMakeLatencyStatus(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.NewTime(time.Date(2024, 1, 1, 1, 0, 0, 500, time.UTC))
+
+	tt := []struct {
+		name               string
+		rackReadLatencies  map[string][]time.Duration
+		rackWriteLatencies map[string][]time.Duration
+		expected           *scyllav1alpha1.LatencyStatus
+	}{
+		{
+			name:               "no node reported its latencies",
+			rackReadLatencies:  map[string][]time.Duration{},
+			rackWriteLatencies: map[string][]time.Duration{},
+			expected:           nil,
+		},
+		{
+			name: "highest latencies of all racks are reported",
+			rackReadLatencies: map[string][]time.Duration{
+				"a": {2 * time.Millisecond, 5 * time.Millisecond},
+				"b": {3 * time.Millisecond},
+			},
+			rackWriteLatencies: map[string][]time.Duration{
+				"a": {time.Millisecond},
+				"b": {1500 * time.Microsecond},
+			},
+			expected: &scyllav1alpha1.LatencyStatus{
+				ReadP99Microseconds:  pointer.Ptr(int64(5000)),
+				WriteP99Microseconds: pointer.Ptr(int64(1500)),
+				LastRefreshTime:      metav1.NewTime(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "latencies of a single operation are reported",
+			rackReadLatencies: map[string][]time.Duration{
+				"a": {2 * time.Millisecond},
+			},
+			rackWriteLatencies: map[string][]time.Duration{},
+			expected: &scyllav1alpha1.LatencyStatus{
+				ReadP99Microseconds:  pointer.Ptr(int64(2000)),
+				WriteP99Microseconds: nil,
+				LastRefreshTime:      metav1.NewTime(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := makeLatencyStatus(tc.rackReadLatencies, tc.rackWriteLatencies, now)
+			if !apiequality.Semantic.DeepEqual(got, tc.expected) {
+				t.Errorf("expected and got latency statuses differ: %s", cmp.Diff(tc.expected, got))
+			}
+		})
+	}
+}
+
+func TestSetRacksAvailableStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	newRackStatus := func(name string, nodes, availableNodes int32, stale bool) scyllav1alpha1.RackStatus {
+		return scyllav1alpha1.RackStatus{
+			Name:           name,
+			Nodes:          pointer.Ptr(nodes),
+			AvailableNodes: pointer.Ptr(availableNodes),
+			Stale:          pointer.Ptr(stale),
+		}
+	}
+
+	tt := []struct {
+		name              string
+		racks             []scyllav1alpha1.RackStatus
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "all racks are available",
+			racks: []scyllav1alpha1.RackStatus{
+				newRackStatus("a", 3, 3, false),
+				newRackStatus("b", 0, 0, false),
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.RacksAvailableCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "racks with unavailable nodes or stale statuses are reported",
+			racks: []scyllav1alpha1.RackStatus{
+				newRackStatus("a", 3, 3, false),
+				newRackStatus("b", 3, 2, false),
+				newRackStatus("c", 3, 3, true),
+				newRackStatus("d", 3, 1, true),
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.RacksAvailableCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NodesUnavailable",
+				Message:            `Rack "b" has 2 out of 3 nodes available. Rack "c" status is stale. Rack "d" status is stale.`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				Racks: tc.racks,
+			}
+			setRacksAvailableStatusCondition(sdc, status)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.RacksAvailableCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetReadyStatus(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		conditions    []metav1.Condition
+		expectedReady *bool
+	}{
+		{
+			name: "available datacenter is ready",
+			conditions: []metav1.Condition{
+				{Type: scyllav1alpha1.AvailableCondition, Status: metav1.ConditionTrue},
+			},
+			expectedReady: pointer.Ptr(true),
+		},
+		{
+			name: "unavailable datacenter isn't ready",
+			conditions: []metav1.Condition{
+				{Type: scyllav1alpha1.AvailableCondition, Status: metav1.ConditionFalse},
+			},
+			expectedReady: pointer.Ptr(false),
+		},
+		{
+			name:          "datacenter without the Available condition isn't ready",
+			conditions:    nil,
+			expectedReady: pointer.Ptr(false),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				Conditions: tc.conditions,
+			}
+			setReadyStatus(status)
+
+			if !apiequality.Semantic.DeepEqual(status.Ready, tc.expectedReady) {
+				t.Errorf("expected and got ready differ: %s", cmp.Diff(tc.expectedReady, status.Ready))
+			}
+		})
+	}
+}
+
+func TestGetGenerationStartTime(t *testing.T) {
+	t.Parallel()
+
+	generationStartTime := metav1.NewTime(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 500, time.UTC))
+
+	tt := []struct {
+		name                        string
+		oldStatus                   *scyllav1alpha1.ScyllaDBDatacenterStatus
+		generation                  int64
+		expectedGenerationStartTime *metav1.Time
+	}{
+		{
+			name:                        "generation that was never observed starts now",
+			oldStatus:                   &scyllav1alpha1.ScyllaDBDatacenterStatus{},
+			generation:                  1,
+			expectedGenerationStartTime: pointer.Ptr(metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))),
+		},
+		{
+			name: "new generation starts now",
+			oldStatus: &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				ObservedGeneration:  pointer.Ptr(int64(1)),
+				GenerationStartTime: &generationStartTime,
+			},
+			generation:                  2,
+			expectedGenerationStartTime: pointer.Ptr(metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))),
+		},
+		{
+			name: "observed generation keeps its start time",
+			oldStatus: &scyllav1alpha1.ScyllaDBDatacenterStatus{
+				ObservedGeneration:  pointer.Ptr(int64(2)),
+				GenerationStartTime: &generationStartTime,
+			},
+			generation:                  2,
+			expectedGenerationStartTime: &generationStartTime,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := getGenerationStartTime(tc.oldStatus, tc.generation, now)
+			if !apiequality.Semantic.DeepEqual(got, tc.expectedGenerationStartTime) {
+				t.Errorf("expected and got generation start times differ: %s", cmp.Diff(tc.expectedGenerationStartTime, got))
+			}
+		})
+	}
+}
+
+func TestCountRestartedMembers(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	newPod := func(name string, scyllaDBStartTime *time.Time) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+		}
+
+		if scyllaDBStartTime != nil {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name: naming.ScyllaContainerName,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{
+							StartedAt: metav1.NewTime(*scyllaDBStartTime),
+						},
+					},
+				},
+			}
+		}
+
+		return pod
+	}
+
+	tt := []struct {
+		name     string
+		pods     []*corev1.Pod
+		expected int32
+	}{
+		{
+			name:     "no members",
+			pods:     nil,
+			expected: 0,
+		},
+		{
+			name: "only members started after the time are counted",
+			pods: []*corev1.Pod{
+				newPod("basic-dc-a-0", pointer.Ptr(since.Add(-time.Hour))),
+				newPod("basic-dc-a-1", pointer.Ptr(since.Add(time.Minute))),
+				newPod("basic-dc-a-2", pointer.Ptr(since)),
+				newPod("basic-dc-a-3", pointer.Ptr(since.Add(time.Hour))),
+			},
+			expected: 2,
+		},
+		{
+			name: "members without a running ScyllaDB container aren't counted",
+			pods: []*corev1.Pod{
+				newPod("basic-dc-a-0", nil),
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := countRestartedMembers(tc.pods, since)
+			if got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetImageEdition(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name            string
+		image           string
+		expectedEdition string
+		expectedErr     bool
+	}{
+		{
+			name:            "open source version",
+			image:           "docker.io/scylladb/scylla:6.2.3",
+			expectedEdition: "OSS",
+		},
+		{
+			name:            "year-based enterprise version",
+			image:           "docker.io/scylladb/scylla-enterprise:2024.1.12",
+			expectedEdition: "Enterprise",
+		},
+		{
+			name:            "year-based version of the unified releases",
+			image:           "docker.io/scylladb/scylla:2025.1.0",
+			expectedEdition: "OSS",
+		},
+		{
+			name:            "non-semver tag of a known repository",
+			image:           "docker.io/scylladb/scylla-enterprise:latest",
+			expectedEdition: "Enterprise",
+		},
+		{
+			name:            "year-based version of another repository",
+			image:           "registry.example.com/mirror/scylladb:2024.1.12",
+			expectedEdition: "Enterprise",
+		},
+		{
+			name:            "open source version of another repository",
+			image:           "registry.example.com/mirror/scylladb:6.2.3",
+			expectedEdition: "OSS",
+		},
+		{
+			name:        "non-semver tag of another repository",
+			image:       "registry.example.com/mirror/scylladb:latest",
+			expectedErr: true,
+		},
+		{
+			name:        "untagged image",
+			image:       "docker.io/scylladb/scylla",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			edition, err := getImageEdition(tc.image)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+
+			if edition != tc.expectedEdition {
+				t.Errorf("expected edition %q, got %q", tc.expectedEdition, edition)
+			}
+		})
+	}
+}
+
+func TestSetEditionMismatchStatusCondition(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: scyllav1alpha1.ScyllaDBDatacenterSpec{
+			ClusterName:    "basic",
+			DatacenterName: pointer.Ptr("dc"),
+			Racks: []scyllav1alpha1.RackSpec{
+				{Name: "a"},
+				{Name: "b"},
+				{Name: "c"},
+			},
+		},
+	}
+
+	newStatefulSet := func(rack string, image string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("basic-dc-%s", rack),
+				Namespace: "default",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  naming.ScyllaContainerName,
+								Image: image,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newStatefulSets := func(statefulSets ...*appsv1.StatefulSet) map[string]*appsv1.StatefulSet {
+		m := map[string]*appsv1.StatefulSet{}
+		for _, sts := range statefulSets {
+			m[sts.Name] = sts
+		}
+		return m
+	}
+
+	asExpectedCondition := &metav1.Condition{
+		Type:               scyllav1alpha1.EditionMismatchCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: 2,
+	}
+
+	tt := []struct {
+		name              string
+		statefulSets      map[string]*appsv1.StatefulSet
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "uniform open source editions",
+			statefulSets: newStatefulSets(
+				newStatefulSet("a", "docker.io/scylladb/scylla:6.2.3"),
+				newStatefulSet("b", "docker.io/scylladb/scylla:6.2.2"),
+				newStatefulSet("c", "docker.io/scylladb/scylla:6.2.3"),
+			),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name: "upgrade to the unified releases isn't a mismatch",
+			statefulSets: newStatefulSets(
+				newStatefulSet("a", "docker.io/scylladb/scylla:6.2.3"),
+				newStatefulSet("b", "docker.io/scylladb/scylla:2025.1.0"),
+			),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name: "uniform enterprise editions",
+			statefulSets: newStatefulSets(
+				newStatefulSet("a", "docker.io/scylladb/scylla-enterprise:2024.1.12"),
+				newStatefulSet("b", "docker.io/scylladb/scylla-enterprise:2024.2.0"),
+			),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name: "racks with images of an unknown edition are ignored",
+			statefulSets: newStatefulSets(
+				newStatefulSet("a", "docker.io/scylladb/scylla:6.2.3"),
+				newStatefulSet("b", "registry.example.com/mirror/scylladb:latest"),
+			),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:              "missing StatefulSets are ignored",
+			statefulSets:      newStatefulSets(),
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name: "mixed editions are reported with the racks of each edition",
+			statefulSets: newStatefulSets(
+				newStatefulSet("a", "docker.io/scylladb/scylla:6.2.3"),
+				newStatefulSet("b", "docker.io/scylladb/scylla-enterprise:2024.1.12"),
+				newStatefulSet("c", "docker.io/scylladb/scylla:6.2.3"),
+			),
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.EditionMismatchCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "MixedEditions",
+				Message:            `Racks run different ScyllaDB editions: Enterprise in rack(s) "b"; OSS in rack(s) "a", "c".`,
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+			setEditionMismatchStatusCondition(sdc, status, tc.statefulSets)
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.EditionMismatchCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetDiskPressureStatus(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	newStatus := func(rackNames ...string) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+		for _, name := range rackNames {
+			status.Racks = append(status.Racks, scyllav1alpha1.RackStatus{
+				Name:              name,
+				DiskPressureNodes: pointer.Ptr(int32(42)),
+			})
+		}
+		return status
+	}
+
+	tt := []struct {
+		name              string
+		status            *scyllav1alpha1.ScyllaDBDatacenterStatus
+		rackLoads         map[string][]nodeLoad
+		capacities        map[string]int64
+		expectedNodes     map[string]*int32
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:   "usage below the watermark",
+			status: newStatus("a", "b"),
+			rackLoads: map[string][]nodeLoad{
+				"a": {{host: "10.0.0.1", bytes: 85}},
+				"b": {{host: "10.0.0.2", bytes: 10}},
+			},
+			capacities: map[string]int64{
+				"10.0.0.1": 100,
+				"10.0.0.2": 100,
+			},
+			expectedNodes: map[string]*int32{
+				"a": pointer.Ptr(int32(0)),
+				"b": pointer.Ptr(int32(0)),
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DiskPressureCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:   "nodes above the watermark are counted and named",
+			status: newStatus("a", "b"),
+			rackLoads: map[string][]nodeLoad{
+				"a": {{host: "10.0.0.1", bytes: 90}, {host: "10.0.0.3", bytes: 50}},
+				"b": {{host: "10.0.0.2", bytes: 1000}},
+			},
+			capacities: map[string]int64{
+				"10.0.0.1": 100,
+				"10.0.0.2": 1024,
+				"10.0.0.3": 100,
+			},
+			expectedNodes: map[string]*int32{
+				"a": pointer.Ptr(int32(1)),
+				"b": pointer.Ptr(int32(1)),
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DiskPressureCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "DataVolumesNearCapacity",
+				Message:            `Node "10.0.0.1" in rack "a" uses 90% of its data volume. Node "10.0.0.2" in rack "b" uses 97% of its data volume. Storage of nodes using more than 85% of their data volume should be expanded before they run out of disk space.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:   "nodes of the same rack are compared to capacities of their own volumes",
+			status: newStatus("a"),
+			rackLoads: map[string][]nodeLoad{
+				"a": {{host: "10.0.0.1", bytes: 90}, {host: "10.0.0.2", bytes: 90}},
+			},
+			capacities: map[string]int64{
+				"10.0.0.1": 100,
+				"10.0.0.2": 200,
+			},
+			expectedNodes: map[string]*int32{
+				"a": pointer.Ptr(int32(1)),
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DiskPressureCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "DataVolumesNearCapacity",
+				Message:            `Node "10.0.0.1" in rack "a" uses 90% of its data volume. Storage of nodes using more than 85% of their data volume should be expanded before they run out of disk space.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:   "racks without reported usage or known capacity are left unset",
+			status: newStatus("a", "b", "c"),
+			rackLoads: map[string][]nodeLoad{
+				"a": {{host: "10.0.0.1", bytes: 10}},
+				"b": {{host: "10.0.0.2", bytes: 10}},
+			},
+			capacities: map[string]int64{
+				"10.0.0.1": 100,
+				"10.0.0.3": 100,
+			},
+			expectedNodes: map[string]*int32{
+				"a": pointer.Ptr(int32(0)),
+				"b": nil,
+				"c": nil,
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DiskPressureCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             internalapi.AsExpectedReason,
+				Message:            "",
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:       "usage that can't be determined for any node is unknown",
+			status:     newStatus("a"),
+			rackLoads:  map[string][]nodeLoad{},
+			capacities: map[string]int64{"10.0.0.1": 100},
+			expectedNodes: map[string]*int32{
+				"a": nil,
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.DiskPressureCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "DiskUsageUnknown",
+				Message:            "Data volume usage couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			setDiskPressureStatus(sdc, tc.status, tc.rackLoads, tc.capacities, DefaultDiskPressureWatermarkPercent)
+
+			gotNodes := map[string]*int32{}
+			for _, rackStatus := range tc.status.Racks {
+				gotNodes[rackStatus.Name] = rackStatus.DiskPressureNodes
+			}
+			if !apiequality.Semantic.DeepEqual(gotNodes, tc.expectedNodes) {
+				t.Errorf("expected and got disk pressure nodes differ: %s", cmp.Diff(tc.expectedNodes, gotNodes))
+			}
+
+			gotCondition := apimeta.FindStatusCondition(tc.status.Conditions, scyllav1alpha1.DiskPressureCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
+
+func TestSetIntraRackVersionSkewStatus(t *testing.T) {
+	t.Parallel()
+
+	sdc := &scyllav1alpha1.ScyllaDBDatacenter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "basic",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	now := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	withinGrace := metav1.NewTime(now.Add(-5 * time.Minute))
+	beyondGrace := metav1.NewTime(now.Add(-time.Hour))
+
+	newStatus := func(skewSince map[string]*metav1.Time, rackNames ...string) *scyllav1alpha1.ScyllaDBDatacenterStatus {
+		status := &scyllav1alpha1.ScyllaDBDatacenterStatus{}
+		for _, name := range rackNames {
+			status.Racks = append(status.Racks, scyllav1alpha1.RackStatus{
+				Name:             name,
+				VersionSkewSince: skewSince[name],
+			})
+		}
+		return status
+	}
+
+	asExpectedCondition := &metav1.Condition{
+		Type:               scyllav1alpha1.IntraRackVersionSkewCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             internalapi.AsExpectedReason,
+		Message:            "",
+		ObservedGeneration: 2,
+	}
+
+	tt := []struct {
+		name              string
+		oldStatus         *scyllav1alpha1.ScyllaDBDatacenterStatus
+		rackVersions      map[string][]string
+		expectedSkewSince map[string]*metav1.Time
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name:      "converged racks",
+			oldStatus: newStatus(nil, "a", "b"),
+			rackVersions: map[string][]string{
+				"a": {"6.2.0", "6.2.0"},
+				"b": {"6.2.1"},
+			},
+			expectedSkewSince: map[string]*metav1.Time{
+				"a": nil,
+				"b": nil,
+			},
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:      "newly observed skew starts the grace period",
+			oldStatus: newStatus(nil, "a"),
+			rackVersions: map[string][]string{
+				"a": {"6.2.0", "6.2.1"},
+			},
+			expectedSkewSince: map[string]*metav1.Time{
+				"a": &now,
+			},
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:      "skew within the grace period isn't reported",
+			oldStatus: newStatus(map[string]*metav1.Time{"a": &withinGrace}, "a"),
+			rackVersions: map[string][]string{
+				"a": {"6.2.0", "6.2.1"},
+			},
+			expectedSkewSince: map[string]*metav1.Time{
+				"a": &withinGrace,
+			},
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:      "skew beyond the grace period is reported",
+			oldStatus: newStatus(map[string]*metav1.Time{"a": &beyondGrace, "b": &beyondGrace}, "a", "b"),
+			rackVersions: map[string][]string{
+				"a": {"6.2.1", "6.2.0", "6.2.1"},
+				"b": {"6.2.1", "6.2.1"},
+			},
+			expectedSkewSince: map[string]*metav1.Time{
+				"a": &beyondGrace,
+				"b": nil,
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.IntraRackVersionSkewCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             "VersionSkewPersists",
+				Message:            `Nodes in rack "a" have been running different versions "6.2.0", "6.2.1" since 2024-01-01T11:00:00Z. Nodes are expected to converge on a single version within 30m0s, the rack update may be stuck.`,
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name:      "racks without reported versions keep the skew time",
+			oldStatus: newStatus(map[string]*metav1.Time{"a": &withinGrace}, "a", "b"),
+			rackVersions: map[string][]string{
+				"b": {"6.2.1"},
+			},
+			expectedSkewSince: map[string]*metav1.Time{
+				"a": &withinGrace,
+				"b": nil,
+			},
+			expectedCondition: asExpectedCondition,
+		},
+		{
+			name:         "versions that can't be determined for any node are unknown",
+			oldStatus:    newStatus(nil, "a"),
+			rackVersions: map[string][]string{},
+			expectedSkewSince: map[string]*metav1.Time{
+				"a": nil,
+			},
+			expectedCondition: &metav1.Condition{
+				Type:               scyllav1alpha1.IntraRackVersionSkewCondition,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "VersionsUnknown",
+				Message:            "ScyllaDB versions couldn't be determined for any node.",
+				ObservedGeneration: 2,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := tc.oldStatus.DeepCopy()
+			for i := range status.Racks {
+				status.Racks[i].VersionSkewSince = nil
+			}
+
+			setIntraRackVersionSkewStatus(sdc, tc.oldStatus, status, tc.rackVersions, DefaultIntraRackVersionSkewGracePeriod, now)
+
+			gotSkewSince := map[string]*metav1.Time{}
+			for _, rackStatus := range status.Racks {
+				gotSkewSince[rackStatus.Name] = rackStatus.VersionSkewSince
+			}
+			if !apiequality.Semantic.DeepEqual(gotSkewSince, tc.expectedSkewSince) {
+				t.Errorf("expected and got version skew times differ: %s", cmp.Diff(tc.expectedSkewSince, gotSkewSince))
+			}
+
+			gotCondition := apimeta.FindStatusCondition(status.Conditions, scyllav1alpha1.IntraRackVersionSkewCondition)
+			if gotCondition != nil {
+				gotCondition.LastTransitionTime = metav1.Time{}
+			}
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
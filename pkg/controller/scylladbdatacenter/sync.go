@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -38,6 +39,7 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 	sdc, err := sdcc.scyllaDBDatacenterLister.ScyllaDBDatacenters(namespace).Get(name)
 	if errors.IsNotFound(err) {
 		klog.V(2).InfoS("ScyllaDBDatacenter has been deleted", "ScyllaDBDatacenter", klog.KObj(sdc))
+		sdcc.statusHistory.forget(types.NamespacedName{Namespace: namespace, Name: name})
 		return nil
 	}
 	if err != nil {
@@ -191,12 +193,26 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 		return objectErr
 	}
 
-	status := sdcc.calculateStatus(sdc, statefulSetMap)
+	status := sdcc.calculateStatus(sdc, statefulSetMap, serviceMap)
 
 	if sdc.DeletionTimestamp != nil {
 		return sdcc.updateStatus(ctx, sdc, status)
 	}
 
+	if controllerhelpers.IsScyllaDBDatacenterQuarantined(sdc) {
+		// Keep reporting the status, without taking any orchestration actions.
+		klog.V(2).InfoS("ScyllaDBDatacenter is quarantined, skipping orchestration", "ScyllaDBDatacenter", klog.KObj(sdc))
+		sdcc.setObservedStatus(ctx, sdc, status, serviceMap)
+
+		err = controllerhelpers.SetAggregatedWorkloadConditions(&status.Conditions, sdc.Generation)
+		if err != nil {
+			return fmt.Errorf("can't aggregate workload conditions: %w", err)
+		}
+
+		setReadyStatus(status)
+		return sdcc.updateStatus(ctx, sdc, status)
+	}
+
 	var errs []error
 
 	err = controllerhelpers.RunSync(
@@ -280,8 +296,7 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 	// StatefulSets, the rack status can change afterwards. Overtime we should consider adding a status.progressing
 	// field (to allow determining cluster status without conditions) and wait for the status to be updated
 	// in a single place, on the next resync.
-	sdcc.setStatefulSetsAvailableStatusCondition(sdc, status)
-	sdcc.setPrewarmedStatusCondition(sdc, status, serviceMap)
+	sdcc.setObservedStatus(ctx, sdc, status, serviceMap)
 
 	err = controllerhelpers.RunSync(
 		&status.Conditions,
@@ -340,9 +355,90 @@ func (sdcc *Controller) sync(ctx context.Context, key string) error {
 	if err != nil {
 		errs = append(errs, fmt.Errorf("can't aggregate workload conditions: %w", err))
 	} else {
+		setReadyStatus(status)
 		err = sdcc.updateStatus(ctx, sdc, status)
 		errs = append(errs, err)
 	}
 
 	return utilerrors.NewAggregate(errs)
 }
+
+// setObservedStatus sets the status fields and conditions that reflect the observed state of the datacenter.
+func (sdcc *Controller) setObservedStatus(ctx context.Context, sdc *scyllav1alpha1.ScyllaDBDatacenter, status *scyllav1alpha1.ScyllaDBDatacenterStatus, serviceMap map[string]*corev1.Service) {
+	sdcc.setStatefulSetsAvailableStatusCondition(sdc, status)
+	sdcc.setPrewarmedStatusCondition(sdc, status, serviceMap)
+	if sdcc.statusOptions.AlternatorReadiness {
+		sdcc.setAlternatorReadyNodes(ctx, sdc, status, serviceMap)
+	}
+	if sdcc.statusOptions.DetailedMembers {
+		sdcc.setMembers(sdc, status, serviceMap)
+	}
+	if sdcc.statusOptions.CleanupRecommendation {
+		setCleanupRecommendation(sdc, status, serviceMap)
+	}
+	if sdcc.statusOptions.ResourceUtilization {
+		sdcc.setResourceUtilization(ctx, sdc, status)
+	}
+
+	now := metav1.Now()
+	queries := &liveStatusQueries{}
+	if sdcc.statusOptions.SchemaVersion {
+		sdcc.addSchemaVersionsQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.SchemaMigrations {
+		sdcc.addSchemaMigratingNodesQuery(queries, status)
+	}
+	if sdcc.statusOptions.Compactions {
+		sdcc.addCompactedNodesQuery(queries, status)
+	}
+	if sdcc.statusOptions.ShardCount {
+		sdcc.addShardCountsQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.CQLConnections {
+		sdcc.addCQLConnectionsQuery(queries, status)
+	}
+	// Data usage and disk pressure are both derived from the load of nodes, which is queried only once.
+	if sdcc.statusOptions.DataUsage || sdcc.statusOptions.DiskPressure {
+		sdcc.addNodeLoadsQuery(queries, sdc, status, serviceMap)
+	}
+	if sdcc.statusOptions.NodeUptime {
+		sdcc.addNodeUptimesQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.TokenRangeOverlap {
+		sdcc.addTokenRangeOverlapQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.SeedReachability {
+		sdcc.addSeedReachabilityQuery(queries, sdc, status, serviceMap)
+	}
+	if sdcc.statusOptions.DataReplication {
+		sdcc.addDataReplicationQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.Topology {
+		sdcc.addTopologyQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.WorkloadPrioritization {
+		sdcc.addWorkloadPrioritizationQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.ConsistentTopology {
+		sdcc.addConsistentTopologyQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.LargePartitions {
+		sdcc.addLargePartitionsQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.ClockSkew {
+		sdcc.addClockSkewQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.Snapshots {
+		sdcc.addSnapshotsQuery(queries, sdc, status)
+	}
+	if sdcc.statusOptions.Latency {
+		sdcc.addLatencyQuery(queries, status, now)
+	}
+	if sdcc.statusOptions.IntraRackVersionSkew {
+		sdcc.addIntraRackVersionSkewQuery(queries, sdc, status, now)
+	}
+	if sdcc.statusOptions.SchemaOverview {
+		sdcc.addSchemaOverviewQuery(queries, sdc, status, now)
+	}
+	sdcc.runLiveStatusQueries(ctx, sdc, serviceMap, queries)
+}
@@ -1087,6 +1087,15 @@ func (sdcc *Controller) setStatefulSetsAvailableStatusCondition(
 	}
 
 	switch {
+	case len(sdc.Spec.Racks) == 0:
+		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               statefulSetControllerAvailableCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoRacksDefined",
+			Message:            "No racks are defined",
+			ObservedGeneration: sdc.Generation,
+		})
+
 	case len(racksInDifferentVersion) > 0:
 		apimeta.SetStatusCondition(&status.Conditions, metav1.Condition{
 			Type:               statefulSetControllerAvailableCondition,
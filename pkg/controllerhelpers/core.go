@@ -275,3 +275,9 @@ func IsDelayedVolumeMountContainerRunning(pod *corev1.Pod) bool {
 
 	return cs.State.Running != nil
 }
+
+// IsScyllaDBPodPrewarmed reports whether all the auxiliary containers of a ScyllaDB Pod are in the state
+// required for the node to serve traffic.
+func IsScyllaDBPodPrewarmed(pod *corev1.Pod) bool {
+	return IsScyllaDBIgnitionContainerReady(pod) && IsDelayedVolumeMountContainerRunning(pod)
+}
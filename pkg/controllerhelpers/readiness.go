@@ -0,0 +1,69 @@
+package controllerhelpers
+
+import (
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+)
+
+// NodeReadiness is the result of evaluating a readiness criterion of a node.
+// The criteria are evaluated by the readiness probe of the node, one after another, each one from the facts
+// it obtained about the node. Controllers don't evaluate them themselves, they observe the resulting readiness
+// of the node's Pod instead.
+type NodeReadiness struct {
+	// Ready is true if the node meets the criterion.
+	Ready bool
+
+	// Reason is a human-readable reason of why the node doesn't meet the criterion, or "ok" when it does.
+	Reason string
+
+	// Mode is the status and state of the node in the cluster, e.g. "UN", or empty when it isn't known.
+	Mode string
+}
+
+// EvaluateNodeUN determines whether the node with hostID is UN in the view of the cluster in nodeStatuses.
+// nativeTransportEnabled only refines the reason of a node that isn't UN. Nil means it is unknown.
+func EvaluateNodeUN(nodeStatuses []scyllaclient.NodeStatusInfo, hostID string, nativeTransportEnabled *bool) NodeReadiness {
+	var mode string
+	un := false
+	for _, s := range nodeStatuses {
+		if s.HostID == hostID {
+			mode = s.Status.String() + s.State.String()
+			un = s.IsUN()
+			break
+		}
+	}
+
+	if !un {
+		// Nodes that already enabled native transport are reported distinctly,
+		// as they are in a later phase of startup than nodes that haven't.
+		if nativeTransportEnabled != nil && *nativeTransportEnabled {
+			return NodeReadiness{Ready: false, Reason: "native transport is enabled, but node isn't UN yet", Mode: mode}
+		}
+
+		return NodeReadiness{Ready: false, Reason: "node is not UN", Mode: mode}
+	}
+
+	return NodeReadiness{Ready: true, Reason: "ok", Mode: mode}
+}
+
+// EvaluateNodeTokens determines whether the node owns any of the tokenCount tokens it is required to own.
+func EvaluateNodeTokens(tokenCount int) NodeReadiness {
+	if tokenCount == 0 {
+		return NodeReadiness{Ready: false, Reason: "node doesn't own any tokens"}
+	}
+
+	return NodeReadiness{Ready: true, Reason: "ok"}
+}
+
+// EvaluateNodeNativeTransport determines whether the node serves CQL clients. Nil nativeTransportEnabled means
+// it is unknown.
+func EvaluateNodeNativeTransport(nativeTransportEnabled *bool) NodeReadiness {
+	if nativeTransportEnabled == nil {
+		return NodeReadiness{Ready: false, Reason: "native transport state is unknown"}
+	}
+
+	if !*nativeTransportEnabled {
+		return NodeReadiness{Ready: false, Reason: "native transport is disabled"}
+	}
+
+	return NodeReadiness{Ready: true, Reason: "ok"}
+}
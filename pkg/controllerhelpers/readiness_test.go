@@ -0,0 +1,147 @@
+package controllerhelpers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+)
+
+func TestEvaluateNodeUN(t *testing.T) {
+	t.Parallel()
+
+	const hostID = "host-id"
+
+	nodeStatuses := func(status scyllaclient.NodeStatus, state scyllaclient.NodeState) []scyllaclient.NodeStatusInfo {
+		return []scyllaclient.NodeStatusInfo{
+			{
+				HostID: "other-host-id",
+				Addr:   "10.0.0.2",
+				Status: scyllaclient.NodeStatusUp,
+				State:  scyllaclient.NodeStateNormal,
+			},
+			{
+				HostID: hostID,
+				Addr:   "10.0.0.1",
+				Status: status,
+				State:  state,
+			},
+		}
+	}
+
+	tt := []struct {
+		name                   string
+		nodeStatuses           []scyllaclient.NodeStatusInfo
+		nativeTransportEnabled *bool
+		expected               NodeReadiness
+	}{
+		{
+			name:         "UN node is ready regardless of its native transport",
+			nodeStatuses: nodeStatuses(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal),
+			expected:     NodeReadiness{Ready: true, Reason: "ok", Mode: "UN"},
+		},
+		{
+			name:                   "joining node isn't ready",
+			nodeStatuses:           nodeStatuses(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining),
+			nativeTransportEnabled: pointer.Ptr(false),
+			expected:               NodeReadiness{Ready: false, Reason: "node is not UN", Mode: "UJ"},
+		},
+		{
+			name:                   "joining node with native transport enabled is reported distinctly",
+			nodeStatuses:           nodeStatuses(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining),
+			nativeTransportEnabled: pointer.Ptr(true),
+			expected:               NodeReadiness{Ready: false, Reason: "native transport is enabled, but node isn't UN yet", Mode: "UJ"},
+		},
+		{
+			name:         "down node with unknown native transport state isn't ready",
+			nodeStatuses: nodeStatuses(scyllaclient.NodeStatusDown, scyllaclient.NodeStateNormal),
+			expected:     NodeReadiness{Ready: false, Reason: "node is not UN", Mode: "DN"},
+		},
+		{
+			name:                   "node missing in node statuses isn't ready",
+			nodeStatuses:           nodeStatuses(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal)[:1],
+			nativeTransportEnabled: pointer.Ptr(true),
+			expected:               NodeReadiness{Ready: false, Reason: "native transport is enabled, but node isn't UN yet", Mode: ""},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := EvaluateNodeUN(tc.nodeStatuses, hostID, tc.nativeTransportEnabled)
+			if got != tc.expected {
+				t.Errorf("expected and got node readiness differ: %s", cmp.Diff(tc.expected, got))
+			}
+		})
+	}
+}
+
+func TestEvaluateNodeTokens(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		tokenCount int
+		expected   NodeReadiness
+	}{
+		{
+			name:       "node without tokens isn't ready",
+			tokenCount: 0,
+			expected:   NodeReadiness{Ready: false, Reason: "node doesn't own any tokens"},
+		},
+		{
+			name:       "node with tokens is ready",
+			tokenCount: 256,
+			expected:   NodeReadiness{Ready: true, Reason: "ok"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := EvaluateNodeTokens(tc.tokenCount)
+			if got != tc.expected {
+				t.Errorf("expected and got node readiness differ: %s", cmp.Diff(tc.expected, got))
+			}
+		})
+	}
+}
+
+func TestEvaluateNodeNativeTransport(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                   string
+		nativeTransportEnabled *bool
+		expected               NodeReadiness
+	}{
+		{
+			name:                   "node with native transport enabled is ready",
+			nativeTransportEnabled: pointer.Ptr(true),
+			expected:               NodeReadiness{Ready: true, Reason: "ok"},
+		},
+		{
+			name:                   "node with native transport disabled isn't ready",
+			nativeTransportEnabled: pointer.Ptr(false),
+			expected:               NodeReadiness{Ready: false, Reason: "native transport is disabled"},
+		},
+		{
+			name:     "node with unknown native transport state isn't ready",
+			expected: NodeReadiness{Ready: false, Reason: "native transport state is unknown"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := EvaluateNodeNativeTransport(tc.nativeTransportEnabled)
+			if got != tc.expected {
+				t.Errorf("expected and got node readiness differ: %s", cmp.Diff(tc.expected, got))
+			}
+		})
+	}
+}
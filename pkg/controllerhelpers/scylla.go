@@ -286,6 +286,11 @@ func GetRackNodeCount(sdc *scyllav1alpha1.ScyllaDBDatacenter, rackName string) (
 	return pointer.Ptr[int32](0), nil
 }
 
+// IsScyllaDBDatacenterQuarantined returns true if orchestration actions on the ScyllaDBDatacenter are suspended.
+func IsScyllaDBDatacenterQuarantined(sdc *scyllav1alpha1.ScyllaDBDatacenter) bool {
+	return HasAnnotation(sdc, naming.QuarantineAnnotation)
+}
+
 func IsScyllaDBDatacenterRolledOut(sdc *scyllav1alpha1.ScyllaDBDatacenter) (bool, error) {
 	if !helpers.IsStatusConditionPresentAndTrue(sdc.Status.Conditions, scyllav1alpha1.AvailableCondition, sdc.Generation) {
 		return false, nil
@@ -0,0 +1,140 @@
+package controllerhelpers
+
+import (
+	"fmt"
+	"strings"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+)
+
+// RackStatusFieldChange describes a change of a single RackStatus field.
+type RackStatusFieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+func (c RackStatusFieldChange) String() string {
+	return fmt.Sprintf("%s %s→%s", c.Field, c.Old, c.New)
+}
+
+// RackStatusDiff describes how a rack status changed between two observations.
+type RackStatusDiff struct {
+	Name    string
+	Added   bool
+	Removed bool
+	Changes []RackStatusFieldChange
+}
+
+// IsEmpty returns true if the diff doesn't contain any meaningful change.
+func (d *RackStatusDiff) IsEmpty() bool {
+	return d == nil || (!d.Added && !d.Removed && len(d.Changes) == 0)
+}
+
+func (d *RackStatusDiff) String() string {
+	switch {
+	case d.IsEmpty():
+		return ""
+	case d.Added:
+		return fmt.Sprintf("Rack %q added", d.Name)
+	case d.Removed:
+		return fmt.Sprintf("Rack %q removed", d.Name)
+	}
+
+	changes := make([]string, 0, len(d.Changes))
+	for _, c := range d.Changes {
+		changes = append(changes, c.String())
+	}
+
+	return fmt.Sprintf("Rack %q: %s", d.Name, strings.Join(changes, ", "))
+}
+
+func formatInt32Ptr(v *int32) string {
+	if v == nil {
+		return "<unknown>"
+	}
+
+	return fmt.Sprintf("%d", *v)
+}
+
+func formatString(v string) string {
+	if len(v) == 0 {
+		return "<unknown>"
+	}
+
+	return v
+}
+
+// DiffRackStatus returns a structured description of meaningful changes between the old and new rack status.
+// A nil old status means the rack was added, a nil new status means the rack was removed.
+// It returns nil when both statuses are nil.
+func DiffRackStatus(old, new *scyllav1alpha1.RackStatus) *RackStatusDiff {
+	switch {
+	case old == nil && new == nil:
+		return nil
+	case old == nil:
+		return &RackStatusDiff{
+			Name:  new.Name,
+			Added: true,
+		}
+	case new == nil:
+		return &RackStatusDiff{
+			Name:    old.Name,
+			Removed: true,
+		}
+	}
+
+	diff := &RackStatusDiff{
+		Name: new.Name,
+	}
+
+	int32Fields := []struct {
+		name     string
+		old, new *int32
+	}{
+		{name: "Nodes", old: old.Nodes, new: new.Nodes},
+		{name: "CurrentNodes", old: old.CurrentNodes, new: new.CurrentNodes},
+		{name: "UpdatedNodes", old: old.UpdatedNodes, new: new.UpdatedNodes},
+		{name: "ScalingTargetNodes", old: old.ScalingTargetNodes, new: new.ScalingTargetNodes},
+		{name: "ReadyNodes", old: old.ReadyNodes, new: new.ReadyNodes},
+		{name: "AvailableNodes", old: old.AvailableNodes, new: new.AvailableNodes},
+		{name: "AlternatorReadyNodes", old: old.AlternatorReadyNodes, new: new.AlternatorReadyNodes},
+		{name: "IgnitionPendingNodes", old: old.IgnitionPendingNodes, new: new.IgnitionPendingNodes},
+		{name: "StuckTerminatingNodes", old: old.StuckTerminatingNodes, new: new.StuckTerminatingNodes},
+		{name: "ShardCount", old: old.ShardCount, new: new.ShardCount},
+		{name: "SchemaMigratingNodes", old: old.SchemaMigratingNodes, new: new.SchemaMigratingNodes},
+		{name: "CompactedNodes", old: old.CompactedNodes, new: new.CompactedNodes},
+		{name: "ClockSkewedNodes", old: old.ClockSkewedNodes, new: new.ClockSkewedNodes},
+		{name: "RestartedNodes", old: old.RestartedNodes, new: new.RestartedNodes},
+	}
+	for _, f := range int32Fields {
+		oldValue, newValue := formatInt32Ptr(f.old), formatInt32Ptr(f.new)
+		if oldValue != newValue {
+			diff.Changes = append(diff.Changes, RackStatusFieldChange{
+				Field: f.name,
+				Old:   oldValue,
+				New:   newValue,
+			})
+		}
+	}
+
+	stringFields := []struct {
+		name     string
+		old, new string
+	}{
+		{name: "CurrentVersion", old: old.CurrentVersion, new: new.CurrentVersion},
+		{name: "UpdatedVersion", old: old.UpdatedVersion, new: new.UpdatedVersion},
+		{name: "SchemaVersion", old: old.SchemaVersion, new: new.SchemaVersion},
+	}
+	for _, f := range stringFields {
+		if f.old != f.new {
+			diff.Changes = append(diff.Changes, RackStatusFieldChange{
+				Field: f.name,
+				Old:   formatString(f.old),
+				New:   formatString(f.new),
+			})
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,139 @@
+package controllerhelpers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+)
+
+func TestDiffRackStatus(t *testing.T) {
+	t.Parallel()
+
+	newRackStatus := func() *scyllav1alpha1.RackStatus {
+		return &scyllav1alpha1.RackStatus{
+			Name:           "rack",
+			CurrentVersion: "6.2.0",
+			UpdatedVersion: "6.2.0",
+			Nodes:          pointer.Ptr(int32(3)),
+			CurrentNodes:   pointer.Ptr(int32(3)),
+			UpdatedNodes:   pointer.Ptr(int32(3)),
+			ReadyNodes:     pointer.Ptr(int32(2)),
+			AvailableNodes: pointer.Ptr(int32(2)),
+			Stale:          pointer.Ptr(false),
+		}
+	}
+
+	tt := []struct {
+		name           string
+		old            *scyllav1alpha1.RackStatus
+		new            *scyllav1alpha1.RackStatus
+		expected       *RackStatusDiff
+		expectedString string
+	}{
+		{
+			name:           "no statuses",
+			old:            nil,
+			new:            nil,
+			expected:       nil,
+			expectedString: "",
+		},
+		{
+			name: "added rack",
+			old:  nil,
+			new:  newRackStatus(),
+			expected: &RackStatusDiff{
+				Name:  "rack",
+				Added: true,
+			},
+			expectedString: `Rack "rack" added`,
+		},
+		{
+			name: "removed rack",
+			old:  newRackStatus(),
+			new:  nil,
+			expected: &RackStatusDiff{
+				Name:    "rack",
+				Removed: true,
+			},
+			expectedString: `Rack "rack" removed`,
+		},
+		{
+			name: "equal statuses",
+			old:  newRackStatus(),
+			new:  newRackStatus(),
+			expected: &RackStatusDiff{
+				Name: "rack",
+			},
+			expectedString: "",
+		},
+		{
+			name: "changes of fields that aren't meaningful are ignored",
+			old:  newRackStatus(),
+			new: func() *scyllav1alpha1.RackStatus {
+				rs := newRackStatus()
+				rs.Stale = pointer.Ptr(true)
+				rs.AppliedSpecHash = "hash"
+				return rs
+			}(),
+			expected: &RackStatusDiff{
+				Name: "rack",
+			},
+			expectedString: "",
+		},
+		{
+			name: "count changes",
+			old:  newRackStatus(),
+			new: func() *scyllav1alpha1.RackStatus {
+				rs := newRackStatus()
+				rs.ReadyNodes = pointer.Ptr(int32(3))
+				rs.AvailableNodes = pointer.Ptr(int32(3))
+				return rs
+			}(),
+			expected: &RackStatusDiff{
+				Name: "rack",
+				Changes: []RackStatusFieldChange{
+					{Field: "ReadyNodes", Old: "2", New: "3"},
+					{Field: "AvailableNodes", Old: "2", New: "3"},
+				},
+			},
+			expectedString: `Rack "rack": ReadyNodes 2→3, AvailableNodes 2→3`,
+		},
+		{
+			name: "count becoming unknown and version change",
+			old:  newRackStatus(),
+			new: func() *scyllav1alpha1.RackStatus {
+				rs := newRackStatus()
+				rs.UpdatedNodes = nil
+				rs.UpdatedVersion = "6.2.1"
+				return rs
+			}(),
+			expected: &RackStatusDiff{
+				Name: "rack",
+				Changes: []RackStatusFieldChange{
+					{Field: "UpdatedNodes", Old: "3", New: "<unknown>"},
+					{Field: "UpdatedVersion", Old: "6.2.0", New: "6.2.1"},
+				},
+			},
+			expectedString: `Rack "rack": UpdatedNodes 3→<unknown>, UpdatedVersion 6.2.0→6.2.1`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := DiffRackStatus(tc.old, tc.new)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected and got differ: %s", cmp.Diff(tc.expected, got))
+			}
+
+			gotString := got.String()
+			if gotString != tc.expectedString {
+				t.Errorf("expected string %q, got %q", tc.expectedString, gotString)
+			}
+		})
+	}
+}
@@ -26,6 +26,16 @@ const (
 	// Readiness check will always fail when this label is added to member service.
 	NodeMaintenanceLabel = "scylla/node-maintenance"
 
+	// NodePausedLabel means that node belongs to a paused cluster.
+	// Readiness check will always fail and liveness check will always succeed when this label is added to member service,
+	// without contacting ScyllaDB API.
+	NodePausedLabel = "internal.scylla-operator.scylladb.com/node-paused"
+
+	// NodeResumingLabel means that node belongs to a cluster that is being resumed from pause.
+	// Readiness check holds the node back until it warms up and its view of the cluster settles
+	// when this label is added to member service.
+	NodeResumingLabel = "internal.scylla-operator.scylladb.com/node-resuming"
+
 	// ForceIgnitionValueAnnotation allows to force ignition state. The value can be either "true" or "false".
 	ForceIgnitionValueAnnotation = "internal.scylla-operator.scylladb.com/force-ignition-value"
 
@@ -46,6 +56,16 @@ const (
 
 	// CleanupJobTokenRingHashAnnotation reflects which version of token ring cleanup Job is cleaning.
 	CleanupJobTokenRingHashAnnotation = "internal.scylla-operator.scylladb.com/cleanup-token-ring-hash"
+
+	// ExpectedNodesAnnotation reflects the number of nodes requested in the datacenter spec.
+	// It is set on member services, so probes can report cluster formation without restarting Pods on scaling.
+	ExpectedNodesAnnotation = "internal.scylla-operator.scylladb.com/expected-nodes"
+)
+
+// Pod condition types.
+const (
+	// NodeReadyPodCondition reflects the latest readiness probe outcome of the ScyllaDB node running in the Pod.
+	NodeReadyPodCondition = "scylla-operator.scylladb.com/NodeReady"
 )
 
 // Annotations used for feature backward compatibility between v1.ScyllaCluster and v1alpha1.ScyllaDBDatacenter
@@ -105,6 +125,22 @@ const (
 
 	ForceRedeploymentReasonAnnotation = "scylla-operator.scylladb.com/force-redeployment-reason"
 	InputsHashAnnotation              = "scylla-operator.scylladb.com/inputs-hash"
+
+	// QuarantineAnnotation stops controllers from taking orchestration actions on a ScyllaDBDatacenter, while its status
+	// keeps being reported. Its value can optionally describe the reason of the quarantine.
+	// It is honored by the ScyllaDBDatacenter controller and the orphaned PersistentVolume controller only;
+	// other controllers, e.g. the ScyllaCluster and ScyllaDBCluster controllers, keep reconciling the ScyllaDBDatacenter itself.
+	QuarantineAnnotation = "scylla-operator.scylladb.com/quarantine"
+
+	// SuppressStatusUpdatesUntilAnnotation suppresses status updates of a ScyllaDBDatacenter, except for the observed
+	// generation, until the RFC 3339 time in its value, to reduce status churn during scripted multi-step operations.
+	// Orchestration isn't affected. The window is bounded, so a forgotten annotation doesn't suppress updates forever.
+	// It uses IgnoreInternalKeyPrefix, so that adding or removing it doesn't propagate into managed objects and roll out Pods.
+	SuppressStatusUpdatesUntilAnnotation = IgnoreInternalKeyPrefix + "/suppress-status-updates-until"
+
+	// ExpectedServiceLevelsAnnotation lists the comma-separated names of service levels that are expected to be
+	// configured for workload prioritization on every node of a ScyllaDBDatacenter. It is only used for status reporting.
+	ExpectedServiceLevelsAnnotation = IgnoreInternalKeyPrefix + "/expected-service-levels"
 )
 
 const (
@@ -145,11 +181,22 @@ const (
 
 	ReadinessProbePath         = "/readyz"
 	LivenessProbePath          = "/healthz"
+	LivezProbePath             = "/livez"
+	PodReadinessProbePath      = "/readyz/pod"
+	DrainProbePath             = "/drainz"
+	DrainCompletePath          = "/draincomplete"
+	DrainPath                  = "/drain"
+	ConfigzPath                = "/configz"
+	NodesPath                  = "/nodes"
+	PathzPath                  = "/pathz"
 	ScyllaDBAPIStatusProbePort = 8080
 	ScyllaDBIgnitionProbePort  = 42081
 	ScyllaAPIPort              = 10000
 
 	OperatorEnvVarPrefix = "SCYLLA_OPERATOR_"
+
+	ScyllaDBDatacenterReadinessSummaryPath = "/scylladbdatacenters/readiness"
+	ScyllaDBDatacenterStatusHistoryPath    = "/scylladbdatacenters/status-history"
 )
 
 const (
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/blang/semver"
 	"github.com/containers/image/v5/docker/reference"
 	"github.com/pkg/errors"
 	scyllav1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1"
@@ -137,6 +138,22 @@ func ImageToVersion(image string) (string, error) {
 	return version, nil
 }
 
+// CompareVersions compares two ScyllaDB versions according to semantic versioning.
+// It returns -1, 0 or +1 when version a is lower than, equal to or greater than version b, respectively.
+func CompareVersions(a, b string) (int, error) {
+	av, err := semver.ParseTolerant(a)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse version %q: %w", a, err)
+	}
+
+	bv, err := semver.ParseTolerant(b)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse version %q: %w", b, err)
+	}
+
+	return av.Compare(bv), nil
+}
+
 func ImageToRepositoryVersion(image string) (string, string, error) {
 	named, err := reference.ParseNormalizedNamed(image)
 	if err != nil {
@@ -63,3 +63,60 @@ func Test_ImageToVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_CompareVersions(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name      string
+		a         string
+		b         string
+		expected  int
+		expectErr bool
+	}{
+		{
+			name:     "lower patch version",
+			a:        "6.2.0",
+			b:        "6.2.1",
+			expected: -1,
+		},
+		{
+			name:     "equal versions",
+			a:        "2024.1.10",
+			b:        "2024.1.10",
+			expected: 0,
+		},
+		{
+			name:     "greater minor version",
+			a:        "6.2.0",
+			b:        "6.1.3",
+			expected: 1,
+		},
+		{
+			name:     "short version is tolerated",
+			a:        "6.2",
+			b:        "6.2.0",
+			expected: 0,
+		},
+		{
+			name:      "non-semver version",
+			a:         "latest",
+			b:         "6.2.0",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := CompareVersions(tc.a, tc.b)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// apiPortCheckTimeout bounds the connection attempt to the local ScyllaDB API port,
+	// which is expected to be accepted immediately when ScyllaDB is running.
+	apiPortCheckTimeout = 1 * time.Second
+)
+
+// checkAPIPortOpen connects to the local ScyllaDB API port to cheaply detect that ScyllaDB isn't running.
+func (p *Prober) checkAPIPortOpen(ctx context.Context) error {
+	ctx, ctxCancel := context.WithTimeout(ctx, apiPortCheckTimeout)
+	defer ctxCancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", p.apiAddress)
+	if err != nil {
+		return err
+	}
+
+	err = conn.Close()
+	if err != nil {
+		klog.ErrorS(err, "can't close ScyllaDB API connection", "Address", p.apiAddress)
+	}
+
+	return nil
+}
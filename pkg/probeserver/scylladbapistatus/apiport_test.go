@@ -0,0 +1,82 @@
+package scylladbapistatus
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+// newTestAPIListener returns the address of a listener standing in for the local ScyllaDB API port.
+func newTestAPIListener(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	return listener.Addr().String()
+}
+
+func TestProber_HealthzAPIPortCheck(t *testing.T) {
+	t.Parallel()
+
+	newClosedAddress := func(t *testing.T) string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = listener.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return listener.Addr().String()
+	}
+
+	tt := []struct {
+		name                  string
+		newAPIAddress         func(t *testing.T) string
+		expectedHealthzStatus int
+	}{
+		{
+			// A nil client fails the probe with an internal error once it gets past the port check.
+			name:                  "open port proceeds to ScyllaDB API",
+			newAPIAddress:         newTestAPIListener,
+			expectedHealthzStatus: http.StatusInternalServerError,
+		},
+		{
+			name:                  "closed port fails fast",
+			newAPIAddress:         newClosedAddress,
+			expectedHealthzStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProber(t, newTestService(nil), nil)
+			p.apiAddress = tc.newAPIAddress(t)
+
+			start := time.Now()
+			status := probe(p.Healthz, naming.LivenessProbePath)
+			elapsed := time.Since(start)
+
+			if status != tc.expectedHealthzStatus {
+				t.Errorf("expected healthz status %d, got %d", tc.expectedHealthzStatus, status)
+			}
+
+			if elapsed > apiPortCheckTimeout {
+				t.Errorf("expected healthz to finish within %v, it took %v", apiPortCheckTimeout, elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	"k8s.io/klog/v2"
+)
+
+// readinessCheckQueryParameter is the name of the query parameter selecting a single named readiness check.
+const readinessCheckQueryParameter = "check"
+
+// namedReadinessCheck evaluates a single readiness criterion and returns the HTTP status code to respond with,
+// together with a human-readable reason.
+type namedReadinessCheck func(ctx context.Context) (int, string)
+
+// namedReadinessChecks returns the readiness checks that can be invoked individually, keyed by their names.
+func (p *Prober) namedReadinessChecks() map[string]namedReadinessCheck {
+	return map[string]namedReadinessCheck{
+		"paths": func(ctx context.Context) (int, string) {
+			return p.awaitPathsReadyz()
+		},
+		"maintenance": func(ctx context.Context) (int, string) {
+			statusCode, reason, _ := p.maintenanceReadyz()
+			return statusCode, reason
+		},
+		"un": func(ctx context.Context) (int, string) {
+			return p.withScyllaClient(ctx, func(ctx context.Context, scyllaClient ScyllaClient) (int, string) {
+				statusCode, reason, _, _ := p.unReadyz(ctx, scyllaClient)
+				return statusCode, reason
+			})
+		},
+		"transport": func(ctx context.Context) (int, string) {
+			return p.withScyllaClient(ctx, func(ctx context.Context, scyllaClient ScyllaClient) (int, string) {
+				statusCode, reason, _ := p.transportReadyz(ctx, scyllaClient)
+				return statusCode, reason
+			})
+		},
+	}
+}
+
+// namedReadinessCheckNames returns the sorted names of the readiness checks that can be invoked individually.
+func (p *Prober) namedReadinessCheckNames() []string {
+	var names []string
+	for name := range p.namedReadinessChecks() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// withScyllaClient runs check with a client of the local ScyllaDB node.
+func (p *Prober) withScyllaClient(ctx context.Context, check func(ctx context.Context, scyllaClient ScyllaClient) (int, string)) (int, string) {
+	scyllaClient, err := p.getScyllaClient()
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get scylla client", "Service", p.serviceRef())
+		return http.StatusInternalServerError, fmt.Sprintf("can't get scylla client: %v", err)
+	}
+	defer scyllaClient.Close()
+
+	return check(ctx, scyllaClient)
+}
+
+// localNodeView is the view of the cluster obtained from the local node.
+type localNodeView struct {
+	nodeStatuses scyllaclient.NodeStatusInfoSlice
+
+	// local is the status of the local node in the view, or nil when it isn't known.
+	local *scyllaclient.NodeStatusInfo
+}
+
+// unReadyz determines readiness based on whether the local node is UN in its view of the cluster, and whether
+// the outcome is transient, as the state couldn't be obtained from ScyllaDB API.
+// The view is returned too, unless it couldn't be obtained, so that callers can track the state of the other nodes.
+func (p *Prober) unReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool, *localNodeView) {
+	nodeStatuses, err := scyllaClient.Status(ctx, localhost)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get scylla node status", "Service", p.serviceRef())
+		return http.StatusInternalServerError, fmt.Sprintf("can't get scylla node status: %v", err), true, nil
+	}
+	view := &localNodeView{
+		nodeStatuses: nodeStatuses,
+	}
+
+	hostID, err := scyllaClient.GetLocalHostId(ctx, localhost, false)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get host id")
+		return http.StatusInternalServerError, fmt.Sprintf("can't get host id: %v", err), true, view
+	}
+
+	for i, s := range nodeStatuses {
+		klog.V(4).InfoS("readyz probe: node state", "Node", s.Addr, "Status", s.Status, "State", s.State)
+
+		if s.HostID == hostID {
+			view.local = &nodeStatuses[i]
+		}
+	}
+
+	var transportEnabled *bool
+	if (view.local == nil || !view.local.IsUN()) && !p.options.SkipNativeTransportCheck {
+		// Native transport state only refines the reason of a node that isn't UN, so it can stay unknown.
+		enabled, err := scyllaClient.IsNativeTransportEnabled(ctx, localhost)
+		if err != nil {
+			klog.V(4).InfoS("readyz probe: can't get scylla native transport of a node that isn't UN", "Service", p.serviceRef(), "Error", err)
+		} else {
+			transportEnabled = &enabled
+		}
+	}
+
+	readiness := controllerhelpers.EvaluateNodeUN(nodeStatuses, hostID, transportEnabled)
+	if !readiness.Ready {
+		p.logFailure("readyz probe: node isn't UN", "Service", p.serviceRef(), "Mode", readiness.Mode, "Reason", readiness.Reason)
+		return http.StatusServiceUnavailable, readiness.Reason, false, view
+	}
+
+	return http.StatusOK, "ok", false, view
+}
+
+// tokensReadyz determines readiness based on whether the local node with the address owns any tokens,
+// and whether the outcome is transient.
+func (p *Prober) tokensReadyz(ctx context.Context, scyllaClient ScyllaClient, address string) (int, string, bool) {
+	tokens, err := scyllaClient.GetNodeTokens(ctx, localhost, address)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get node tokens", "Service", p.serviceRef(), "Node", address)
+		return http.StatusInternalServerError, fmt.Sprintf("can't get node tokens: %v", err), true
+	}
+
+	readiness := controllerhelpers.EvaluateNodeTokens(len(tokens))
+	if !readiness.Ready {
+		p.logFailure("readyz probe: node doesn't own any tokens", "Service", p.serviceRef(), "Node", address)
+		return http.StatusServiceUnavailable, readiness.Reason, false
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// transportReadyz determines readiness based on whether native transport of the local node is enabled,
+// and whether the outcome is transient.
+func (p *Prober) transportReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	transportEnabled, err := scyllaClient.IsNativeTransportEnabled(ctx, localhost)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get scylla native transport", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get scylla native transport: %v", err), true
+	}
+	klog.V(4).InfoS("readyz probe: node state", "NativeTransportEnabled", transportEnabled)
+
+	readiness := controllerhelpers.EvaluateNodeNativeTransport(&transportEnabled)
+	if !readiness.Ready {
+		p.logFailure("readyz probe: native transport is disabled", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, readiness.Reason, false
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// namedReadyz runs the single readiness check named in the request.
+// Unknown check names are rejected with 400. Paused nodes fail every check without contacting ScyllaDB API.
+func (p *Prober) namedReadyz(ctx context.Context, name string) (int, string) {
+	check, ok := p.namedReadinessChecks()[name]
+	if !ok {
+		return http.StatusBadRequest, fmt.Sprintf("unknown check %q, known checks are: %s", name, strings.Join(p.namedReadinessCheckNames(), ", "))
+	}
+
+	statusCode, reason, _ := p.pausedReadyz()
+	if statusCode != http.StatusOK {
+		return statusCode, reason
+	}
+
+	return check(ctx)
+}
@@ -0,0 +1,257 @@
+package scylladbapistatus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+)
+
+func TestProber_NamedReadinessChecks(t *testing.T) {
+	t.Parallel()
+
+	notUNClient := func() *fakeScyllaClient {
+		client := newUNScyllaClient()
+		client.nodeStatuses[0].State = scyllaclient.NodeStateJoining
+		return client
+	}
+
+	transportDisabledClient := func() *fakeScyllaClient {
+		client := newUNScyllaClient()
+		client.transportEnabled = false
+		return client
+	}
+
+	tt := []struct {
+		name               string
+		check              string
+		serviceLabels      map[string]string
+		missingAwaitPath   bool
+		client             *fakeScyllaClient
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "paths check passes when awaited paths exist",
+			check:              "paths",
+			client:             notUNClient(),
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ok\n",
+		},
+		{
+			name:               "paths check fails when an awaited path doesn't exist",
+			check:              "paths",
+			missingAwaitPath:   true,
+			client:             newUNScyllaClient(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "node is awaiting required paths' existence\n",
+		},
+		{
+			name:               "maintenance check passes when node isn't under maintenance",
+			check:              "maintenance",
+			missingAwaitPath:   true,
+			client:             notUNClient(),
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ok\n",
+		},
+		{
+			name:               "maintenance check fails when node is under maintenance",
+			check:              "maintenance",
+			serviceLabels:      map[string]string{naming.NodeMaintenanceLabel: ""},
+			client:             newUNScyllaClient(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "node is under maintenance\n",
+		},
+		{
+			name:               "un check passes when node is UN regardless of its native transport",
+			check:              "un",
+			client:             transportDisabledClient(),
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ok\n",
+		},
+		{
+			name:  "un check fails when node isn't UN",
+			check: "un",
+			client: func() *fakeScyllaClient {
+				client := notUNClient()
+				client.transportEnabled = false
+				return client
+			}(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "node is not UN\n",
+		},
+		{
+			name:               "un check reports nodes that aren't UN with native transport enabled distinctly",
+			check:              "un",
+			client:             notUNClient(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "native transport is enabled, but node isn't UN yet\n",
+		},
+		{
+			name:               "un check fails when node is paused",
+			check:              "un",
+			serviceLabels:      map[string]string{naming.NodePausedLabel: ""},
+			client:             newUNScyllaClient(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "node is paused\n",
+		},
+		{
+			name:               "transport check fails when node is paused",
+			check:              "transport",
+			serviceLabels:      map[string]string{naming.NodePausedLabel: ""},
+			client:             newUNScyllaClient(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "node is paused\n",
+		},
+		{
+			name:               "transport check passes when native transport is enabled regardless of node state",
+			check:              "transport",
+			client:             notUNClient(),
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ok\n",
+		},
+		{
+			name:               "transport check fails when native transport is disabled",
+			check:              "transport",
+			client:             transportDisabledClient(),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "native transport is disabled\n",
+		},
+		{
+			name:               "unknown check is rejected",
+			check:              "unknown",
+			client:             newUNScyllaClient(),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "unknown check \"unknown\", known checks are: maintenance, paths, transport, un\n",
+		},
+		{
+			name:               "empty check is rejected",
+			check:              "",
+			client:             newUNScyllaClient(),
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "unknown check \"\", known checks are: maintenance, paths, transport, un\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProber(t, newTestService(tc.serviceLabels), tc.client)
+			if tc.missingAwaitPath {
+				p.awaitPaths = []AwaitPath{{Path: filepath.Join(t.TempDir(), "missing")}}
+			}
+
+			req := httptest.NewRequest(http.MethodGet, naming.ReadinessProbePath+"?verbose&check="+tc.check, nil)
+			w := httptest.NewRecorder()
+			p.Readyz(w, req)
+
+			if w.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestProber_NamedReadinessCheckDoesntAffectMaintenanceDrain(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProberWithOptions(t, newTestService(map[string]string{naming.NodeMaintenanceLabel: ""}), newUNScyllaClient(), ProberOptions{})
+
+	statusCode := probe(p.Readyz, naming.ReadinessProbePath+"?check=maintenance")
+	if statusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status code %d, got %d", http.StatusServiceUnavailable, statusCode)
+	}
+
+	if got := p.maintenanceUnreadyProbes.Load(); got != 0 {
+		t.Errorf("expected no maintenance unready probes to be counted, got %d", got)
+	}
+}
+
+func TestProber_NamedReadinessCheckOfPausedNodeDoesntContactAPI(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProber(t, newTestService(map[string]string{naming.NodePausedLabel: ""}), newUNScyllaClient())
+	p.newScyllaClient = func() (ScyllaClient, error) {
+		t.Error("unexpected ScyllaDB API client creation")
+		return nil, errors.New("unexpected ScyllaDB API client creation")
+	}
+
+	for _, check := range []string{"un", "transport"} {
+		statusCode := probe(p.Readyz, naming.ReadinessProbePath+"?check="+check)
+		if statusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected status code %d of %q check, got %d", http.StatusServiceUnavailable, check, statusCode)
+		}
+	}
+}
+
+func TestProber_NamedReadinessChecksMatchReadyz(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(status scyllaclient.NodeStatus, state scyllaclient.NodeState, transportEnabled bool) *fakeScyllaClient {
+		client := newUNScyllaClient()
+		client.nodeStatuses[0].Status = status
+		client.nodeStatuses[0].State = state
+		client.transportEnabled = transportEnabled
+		return client
+	}
+
+	tt := []struct {
+		name   string
+		client *fakeScyllaClient
+	}{
+		{
+			name:   "UN node with native transport enabled",
+			client: newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal, true),
+		},
+		{
+			name:   "UN node with native transport disabled",
+			client: newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal, false),
+		},
+		{
+			name:   "joining node with native transport enabled",
+			client: newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining, true),
+		},
+		{
+			name:   "down node with native transport disabled",
+			client: newClient(scyllaclient.NodeStatusDown, scyllaclient.NodeStateNormal, false),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProber(t, newTestService(nil), tc.client)
+
+			probeCheck := func(check string) (int, string) {
+				req := httptest.NewRequest(http.MethodGet, naming.ReadinessProbePath+"?verbose&check="+check, nil)
+				w := httptest.NewRecorder()
+				p.Readyz(w, req)
+				return w.Code, w.Body.String()
+			}
+
+			_, readyzBody := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			unStatusCode, unBody := probeCheck("un")
+			_, transportBody := probeCheck("transport")
+
+			// The checks run in sequence, so the first failing one determines the outcome.
+			expectedBody := transportBody
+			if unStatusCode != http.StatusOK {
+				expectedBody = unBody
+			}
+
+			if readyzBody != expectedBody {
+				t.Errorf("expected readyz body %q to match the first failing named check, got %q", expectedBody, readyzBody)
+			}
+		})
+	}
+}
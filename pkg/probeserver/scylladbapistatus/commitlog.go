@@ -0,0 +1,25 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// commitlogReplayReadyz determines readiness based on whether the node finished starting up.
+// ScyllaDB replays its commitlog while starting up, so a node that is still starting may be missing recent writes.
+// It also returns whether the outcome is transient, as the state couldn't be obtained from ScyllaDB API.
+func (p *Prober) commitlogReplayReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	starting, err := scyllaClient.IsStarting(ctx, localhost)
+	if err != nil {
+		p.logFailure("readyz probe: can't get whether node is starting", "Service", p.serviceRef(), "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get whether node is starting: %v", err), true
+	}
+
+	if starting {
+		p.logFailure("readyz probe: node is starting up and replaying commitlog", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, "node is starting up and replaying commitlog", false
+	}
+
+	return http.StatusOK, "ok", false
+}
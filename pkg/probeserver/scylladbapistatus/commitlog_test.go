@@ -0,0 +1,93 @@
+package scylladbapistatus
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_CommitlogReplayCheck(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                 string
+		commitlogReplayCheck bool
+		starting             bool
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "check is skipped when disabled",
+			commitlogReplayCheck: false,
+			starting:             true,
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node isn't ready while replaying commitlog",
+			commitlogReplayCheck: true,
+			starting:             true,
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "node is starting up and replaying commitlog\n",
+		},
+		{
+			name:                 "node is ready once it finished starting up",
+			commitlogReplayCheck: true,
+			starting:             false,
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUNScyllaClient()
+			client.starting = tc.starting
+
+			p := newTestProberWithOptions(t, newTestService(nil), client, ProberOptions{
+				CommitlogReplayCheck: tc.commitlogReplayCheck,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestProber_CommitlogReplayCheckFollowsReplayProgress(t *testing.T) {
+	t.Parallel()
+
+	client := newUNScyllaClient()
+	p := newTestProberWithOptions(t, newTestService(nil), client, ProberOptions{
+		CommitlogReplayCheck: true,
+	})
+
+	for i, s := range []struct {
+		starting             bool
+		err                  error
+		expectedReadyzStatus int
+	}{
+		{starting: false, err: errors.New("connection refused"), expectedReadyzStatus: http.StatusServiceUnavailable},
+		{starting: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+		{starting: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+		{starting: false, expectedReadyzStatus: http.StatusOK},
+	} {
+		client.starting = s.starting
+		client.err = s.err
+
+		readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+		if readyzStatus != s.expectedReadyzStatus {
+			t.Errorf("step %d: expected readyz status %d, got %d", i, s.expectedReadyzStatus, readyzStatus)
+		}
+	}
+}
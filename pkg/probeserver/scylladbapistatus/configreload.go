@@ -0,0 +1,42 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/klog/v2"
+)
+
+// configReloadReadyz checks the config reload status endpoint of the sidecar, which responds with 200
+// when no reload is in progress and with 503 while ScyllaDB is reloading its configuration.
+func (p *Prober) configReloadReadyz(ctx context.Context) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.options.ConfigReloadStatusURL, nil)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't create config reload status request", "URL", p.options.ConfigReloadStatusURL)
+		return http.StatusInternalServerError, fmt.Sprintf("can't create config reload status request: %v", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get config reload status", "URL", p.options.ConfigReloadStatusURL)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get config reload status: %v", err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return http.StatusOK, "ok"
+
+	case http.StatusServiceUnavailable:
+		p.logFailure("readyz probe: config reload is in progress", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, "config reload is in progress"
+
+	default:
+		klog.ErrorS(nil, "readyz probe: unexpected config reload status response", "URL", p.options.ConfigReloadStatusURL, "StatusCode", resp.StatusCode)
+		return http.StatusInternalServerError, fmt.Sprintf("unexpected config reload status response code %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,100 @@
+package scylladbapistatus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_ConfigReloadCheck(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                 string
+		reloadStatusCode     int
+		expectedReadyzStatus int
+		expectedReason       string
+	}{
+		{
+			name:                 "node is ready when no reload is in progress",
+			reloadStatusCode:     http.StatusOK,
+			expectedReadyzStatus: http.StatusOK,
+			expectedReason:       "ok",
+		},
+		{
+			name:                 "node isn't ready while a reload is in progress",
+			reloadStatusCode:     http.StatusServiceUnavailable,
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedReason:       "config reload is in progress",
+		},
+		{
+			name:                 "unexpected response is an error",
+			reloadStatusCode:     http.StatusNotFound,
+			expectedReadyzStatus: http.StatusInternalServerError,
+			expectedReason:       "unexpected config reload status response code 404",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(tc.reloadStatusCode)
+			}))
+			defer server.Close()
+
+			p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+				ConfigReloadStatusURL: server.URL,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if !strings.Contains(body, tc.expectedReason) {
+				t.Errorf("expected body to contain %q, got %q", tc.expectedReason, body)
+			}
+		})
+	}
+}
+
+func TestProber_ConfigReloadCheckFollowsReloadProgress(t *testing.T) {
+	t.Parallel()
+
+	var reloading atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if reloading.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+		ConfigReloadStatusURL: server.URL,
+	})
+
+	for i, s := range []struct {
+		reloading            bool
+		expectedReadyzStatus int
+	}{
+		{reloading: false, expectedReadyzStatus: http.StatusOK},
+		{reloading: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+		{reloading: false, expectedReadyzStatus: http.StatusOK},
+	} {
+		reloading.Store(s.reloading)
+
+		readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+		if readyzStatus != s.expectedReadyzStatus {
+			t.Errorf("step %d: expected readyz status %d, got %d", i, s.expectedReadyzStatus, readyzStatus)
+		}
+	}
+}
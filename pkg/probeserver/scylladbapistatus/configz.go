@@ -0,0 +1,135 @@
+package scylladbapistatus
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/klog/v2"
+)
+
+// proberConfig is the effective configuration of a Prober, as exposed by Configz.
+// It must not contain any secrets.
+type proberConfig struct {
+	Namespace   string `json:"namespace"`
+	ServiceName string `json:"serviceName"`
+	PodName     string `json:"podName"`
+
+	ReadyzTimeout  string `json:"readyzTimeout"`
+	HealthzTimeout string `json:"healthzTimeout"`
+
+	AwaitPaths            []AwaitPath `json:"awaitPaths"`
+	AwaitPathsManifestDir string      `json:"awaitPathsManifestDir,omitempty"`
+	MaxBootstrapDuration  string      `json:"maxBootstrapDuration"`
+
+	SkipNativeTransportCheck bool     `json:"skipNativeTransportCheck"`
+	AlternatorPort           int      `json:"alternatorPort,omitempty"`
+	RequireTokens            bool     `json:"requireTokens"`
+	CommitlogReplayCheck     bool     `json:"commitlogReplayCheck,omitempty"`
+	MinUNNodes               int      `json:"minUNNodes,omitempty"`
+	ListenAddressCheck       bool     `json:"listenAddressCheck"`
+	PodIP                    string   `json:"podIP,omitempty"`
+	PlacementCheck           bool     `json:"placementCheck,omitempty"`
+	WarmupHold               string   `json:"warmupHold"`
+	GossipSettleWindow       string   `json:"gossipSettleWindow"`
+	ResumeWarmupHold         string   `json:"resumeWarmupHold"`
+	ResumeGossipSettleWindow string   `json:"resumeGossipSettleWindow"`
+	CQLAuthCheck             bool     `json:"cqlAuthCheck"`
+	CQLQueryCheck            bool     `json:"cqlQueryCheck,omitempty"`
+	CQLCredentialsPath       string   `json:"cqlCredentialsPath,omitempty"`
+	RequiredKeyspace         string   `json:"requiredKeyspace,omitempty"`
+	ExpectedVersion          string   `json:"expectedVersion,omitempty"`
+	RequiredFeatures         []string `json:"requiredFeatures,omitempty"`
+	ConfigReloadStatusURL    string   `json:"configReloadStatusURL,omitempty"`
+	MetricsCheck             bool     `json:"metricsCheck,omitempty"`
+	MetricsPort              int      `json:"metricsPort,omitempty"`
+	ReadinessChecks          int      `json:"readinessChecks"`
+
+	ReadyzCacheRefreshInterval string `json:"readyzCacheRefreshInterval"`
+	ReadyzCacheMaxStaleness    string `json:"readyzCacheMaxStaleness"`
+	ReadyzFallbackFreshness    string `json:"readyzFallbackFreshness"`
+
+	ExtraMaintenanceLabels     []string `json:"extraMaintenanceLabels,omitempty"`
+	MaintenanceDrainProbeCount int      `json:"maintenanceDrainProbeCount"`
+
+	SuccessLogLevel klog.Level `json:"successLogLevel"`
+	FailureLogLevel klog.Level `json:"failureLogLevel"`
+
+	// DrainAuthTokenConfigured reports whether a drain auth token is set, without revealing it.
+	DrainAuthTokenConfigured bool `json:"drainAuthTokenConfigured"`
+
+	ProbeMethods []string `json:"probeMethods"`
+
+	ReportPodCondition bool `json:"reportPodCondition,omitempty"`
+	PrimeClient        bool `json:"primeClient,omitempty"`
+}
+
+func (p *Prober) getConfig() *proberConfig {
+	return &proberConfig{
+		Namespace:                  p.namespace,
+		ServiceName:                p.serviceName,
+		PodName:                    p.podName,
+		ReadyzTimeout:              p.readyzTimeout.String(),
+		HealthzTimeout:             p.healthzTimeout.String(),
+		AwaitPaths:                 p.awaitPaths,
+		AwaitPathsManifestDir:      p.options.AwaitPathsManifestDir,
+		MaxBootstrapDuration:       p.options.MaxBootstrapDuration.String(),
+		SkipNativeTransportCheck:   p.options.SkipNativeTransportCheck,
+		AlternatorPort:             p.options.AlternatorPort,
+		RequireTokens:              p.options.RequireTokens,
+		CommitlogReplayCheck:       p.options.CommitlogReplayCheck,
+		MinUNNodes:                 p.options.MinUNNodes,
+		ListenAddressCheck:         p.options.ListenAddressCheck,
+		PlacementCheck:             p.options.PlacementCheck,
+		PodIP:                      p.options.PodIP,
+		WarmupHold:                 p.options.WarmupHold.String(),
+		GossipSettleWindow:         p.options.GossipSettleWindow.String(),
+		ResumeWarmupHold:           p.options.ResumeWarmupHold.String(),
+		ResumeGossipSettleWindow:   p.options.ResumeGossipSettleWindow.String(),
+		CQLAuthCheck:               p.options.CQLAuthCheck,
+		CQLQueryCheck:              p.options.CQLQueryCheck,
+		CQLCredentialsPath:         p.options.CQLCredentialsPath,
+		RequiredKeyspace:           p.options.RequiredKeyspace,
+		ExpectedVersion:            p.options.ExpectedVersion,
+		RequiredFeatures:           p.options.RequiredFeatures,
+		ConfigReloadStatusURL:      p.options.ConfigReloadStatusURL,
+		MetricsCheck:               p.options.MetricsCheck,
+		MetricsPort:                p.options.MetricsPort,
+		ReadinessChecks:            len(p.options.ReadinessChecks),
+		ReadyzCacheRefreshInterval: p.options.ReadyzCacheRefreshInterval.String(),
+		ReadyzCacheMaxStaleness:    p.options.ReadyzCacheMaxStaleness.String(),
+		ReadyzFallbackFreshness:    p.options.ReadyzFallbackFreshness.String(),
+		ExtraMaintenanceLabels:     p.options.ExtraMaintenanceLabels,
+		MaintenanceDrainProbeCount: p.options.MaintenanceDrainProbeCount,
+		SuccessLogLevel:            p.successLogLevel,
+		FailureLogLevel:            p.failureLogLevel,
+		DrainAuthTokenConfigured:   len(p.options.DrainAuthToken) != 0,
+		ProbeMethods:               p.probeMethods,
+		ReportPodCondition:         p.options.ReportPodCondition,
+		PrimeClient:                p.options.PrimeClient,
+	}
+}
+
+// Configz responds with the effective configuration of the Prober as JSON. Only callers on the loopback interface
+// are allowed.
+func (p *Prober) Configz(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
+		return
+	}
+
+	if !isLoopbackRequest(req) {
+		writeProbeResponse(w, req, http.StatusForbidden, "configuration is only available to local callers")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	err := json.NewEncoder(w).Encode(p.getConfig())
+	if err != nil {
+		klog.ErrorS(err, "configz: can't write configuration")
+	}
+}
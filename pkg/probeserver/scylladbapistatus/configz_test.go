@@ -0,0 +1,75 @@
+package scylladbapistatus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_Configz(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+		RequireTokens:              true,
+		CQLAuthCheck:               true,
+		CQLCredentialsPath:         "/var/run/secrets/cql",
+		ReadyzTimeout:              10 * time.Second,
+		ReadyzCacheRefreshInterval: 5 * time.Second,
+		ReadyzCacheMaxStaleness:    30 * time.Second,
+		MaintenanceDrainProbeCount: 3,
+		DrainAuthToken:             "very-secret-token",
+	})
+	p.awaitPaths = []AwaitPath{
+		{Path: "/mnt/shared/ignition.done"},
+		{Path: "/mnt/shared/marker", MinSize: 1},
+	}
+
+	tt := []struct {
+		name               string
+		remoteAddr         string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "non-local caller is rejected",
+			remoteAddr:         "10.0.0.2:4242",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "",
+		},
+		{
+			name:               "local caller gets the configuration without secrets",
+			remoteAddr:         "127.0.0.1:4242",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"namespace":"scylla","serviceName":"basic-dc-rack-0","podName":"basic-dc-rack-0",` +
+				`"readyzTimeout":"10s","healthzTimeout":"1m0s",` +
+				`"awaitPaths":[{"path":"/mnt/shared/ignition.done"},{"path":"/mnt/shared/marker","minSize":1}],"maxBootstrapDuration":"0s",` +
+				`"skipNativeTransportCheck":false,"requireTokens":true,"listenAddressCheck":false,"warmupHold":"0s","gossipSettleWindow":"0s","resumeWarmupHold":"0s","resumeGossipSettleWindow":"0s","cqlAuthCheck":true,"cqlCredentialsPath":"/var/run/secrets/cql","readinessChecks":0,` +
+				`"readyzCacheRefreshInterval":"5s","readyzCacheMaxStaleness":"30s","readyzFallbackFreshness":"0s",` +
+				`"maintenanceDrainProbeCount":3,"successLogLevel":4,"failureLogLevel":2,"drainAuthTokenConfigured":true,` +
+				`"probeMethods":["GET","HEAD"]}` + "\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, naming.ConfigzPath, nil)
+			req.RemoteAddr = tc.remoteAddr
+			w := httptest.NewRecorder()
+			p.Configz(w, req)
+
+			if w.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected and got bodies differ: %s", cmp.Diff(tc.expectedBody, w.Body.String()))
+			}
+		})
+	}
+}
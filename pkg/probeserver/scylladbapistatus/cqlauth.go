@@ -0,0 +1,100 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	cqlPort = 9042
+)
+
+// readCQLCredentials reads the username and password from a directory with a mounted basic-auth Secret.
+func readCQLCredentials(path string) (string, string, error) {
+	readKey := func(key string) (string, error) {
+		keyPath := filepath.Join(path, key)
+		data, err := os.ReadFile(keyPath)
+		if err != nil {
+			return "", fmt.Errorf("can't read %q: %w", keyPath, err)
+		}
+
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	username, err := readKey(corev1.BasicAuthUsernameKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	password, err := readKey(corev1.BasicAuthPasswordKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return username, password, nil
+}
+
+// localCQLLogin performs an authenticated CQL handshake with the local node followed by a trivial query.
+func localCQLLogin(ctx context.Context, username, password string) error {
+	return localCQLQuery(ctx, username, password, "SELECT key FROM system.local")
+}
+
+// localCQLQuery executes the query in a CQL session with the local node.
+// The session is authenticated unless the username is empty.
+func localCQLQuery(ctx context.Context, username, password, query string) error {
+	cluster := gocql.NewCluster(localhost)
+	cluster.Port = cqlPort
+	cluster.NumConns = 1
+	cluster.DisableInitialHostLookup = true
+	if len(username) != 0 {
+		cluster.Authenticator = gocql.PasswordAuthenticator{
+			Username: username,
+			Password: password,
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if ok {
+		cluster.ConnectTimeout = time.Until(deadline)
+		cluster.Timeout = time.Until(deadline)
+	}
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return fmt.Errorf("can't create CQL session: %w", err)
+	}
+	defer session.Close()
+
+	err = session.Query(query).WithContext(ctx).Exec()
+	if err != nil {
+		return fmt.Errorf("can't query local node: %w", err)
+	}
+
+	return nil
+}
+
+// cqlAuthReadyz checks that the local node accepts authenticated CQL sessions.
+func (p *Prober) cqlAuthReadyz(ctx context.Context) (int, string) {
+	username, password, err := readCQLCredentials(p.options.CQLCredentialsPath)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't read CQL credentials", "Path", p.options.CQLCredentialsPath)
+		return http.StatusInternalServerError, fmt.Sprintf("can't read CQL credentials: %v", err)
+	}
+
+	err = p.cqlLogin(ctx, username, password)
+	if err != nil {
+		p.logFailure("readyz probe: can't log in over CQL", "Service", p.serviceRef(), "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't log in over CQL: %v", err)
+	}
+
+	return http.StatusOK, "ok"
+}
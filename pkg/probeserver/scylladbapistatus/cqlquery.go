@@ -0,0 +1,35 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// cqlReadinessQuery is a trivial query that only succeeds when the node can serve queries.
+	cqlReadinessQuery = "SELECT now() FROM system.local"
+)
+
+// cqlQueryReadyz checks that the local node executes a trivial query over CQL.
+func (p *Prober) cqlQueryReadyz(ctx context.Context) (int, string) {
+	var username, password string
+	if len(p.options.CQLCredentialsPath) != 0 {
+		var err error
+		username, password, err = readCQLCredentials(p.options.CQLCredentialsPath)
+		if err != nil {
+			klog.ErrorS(err, "readyz probe: can't read CQL credentials", "Path", p.options.CQLCredentialsPath)
+			return http.StatusInternalServerError, fmt.Sprintf("can't read CQL credentials: %v", err)
+		}
+	}
+
+	err := p.cqlQuery(ctx, username, password, cqlReadinessQuery)
+	if err != nil {
+		p.logFailure("readyz probe: can't execute CQL query", "Service", p.serviceRef(), "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't execute CQL query: %v", err)
+	}
+
+	return http.StatusOK, "ok"
+}
@@ -0,0 +1,128 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestProber_CQLQueryCheck(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                 string
+		cqlQueryCheck        bool
+		credentials          map[string]string
+		queryErr             error
+		expectedReadyzStatus int
+		expectedBody         string
+		expectedQuery        string
+		expectedUsername     string
+	}{
+		{
+			name:                 "check isn't run when it's disabled",
+			cqlQueryCheck:        false,
+			credentials:          nil,
+			queryErr:             fmt.Errorf("query failed"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+			expectedQuery:        "",
+			expectedUsername:     "",
+		},
+		{
+			name:                 "node is ready when the query succeeds",
+			cqlQueryCheck:        true,
+			credentials:          nil,
+			queryErr:             nil,
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+			expectedQuery:        "SELECT now() FROM system.local",
+			expectedUsername:     "",
+		},
+		{
+			name:          "query is authenticated with the configured credentials",
+			cqlQueryCheck: true,
+			credentials: map[string]string{
+				corev1.BasicAuthUsernameKey: "cassandra\n",
+				corev1.BasicAuthPasswordKey: "secret\n",
+			},
+			queryErr:             nil,
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+			expectedQuery:        "SELECT now() FROM system.local",
+			expectedUsername:     "cassandra",
+		},
+		{
+			name:                 "node is unready when the query fails",
+			cqlQueryCheck:        true,
+			credentials:          nil,
+			queryErr:             fmt.Errorf("query failed"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "can't execute CQL query: query failed\n",
+			expectedQuery:        "SELECT now() FROM system.local",
+			expectedUsername:     "",
+		},
+		{
+			name:          "unreadable credentials result in an internal error",
+			cqlQueryCheck: true,
+			credentials: map[string]string{
+				corev1.BasicAuthUsernameKey: "cassandra",
+			},
+			queryErr:             nil,
+			expectedReadyzStatus: http.StatusInternalServerError,
+			expectedQuery:        "",
+			expectedUsername:     "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var credentialsPath string
+			if tc.credentials != nil {
+				credentialsPath = t.TempDir()
+				for k, v := range tc.credentials {
+					err := os.WriteFile(filepath.Join(credentialsPath, k), []byte(v), 0600)
+					if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+				CQLQueryCheck:      tc.cqlQueryCheck,
+				CQLCredentialsPath: credentialsPath,
+			})
+
+			var gotQuery, gotUsername string
+			p.cqlQuery = func(ctx context.Context, username, password, query string) error {
+				gotQuery, gotUsername = query, username
+				return tc.queryErr
+			}
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if len(tc.expectedBody) != 0 && body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+
+			if gotQuery != tc.expectedQuery {
+				t.Errorf("expected query %q, got %q", tc.expectedQuery, gotQuery)
+			}
+
+			if gotUsername != tc.expectedUsername {
+				t.Errorf("expected username %q, got %q", tc.expectedUsername, gotUsername)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package scylladbapistatus
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// isAuthorizedCaller returns true for callers on the loopback interface and callers presenting the drain auth token.
+func (p *Prober) isAuthorizedCaller(req *http.Request) bool {
+	if len(p.options.DrainAuthToken) != 0 {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(p.options.DrainAuthToken)) == 1 {
+			return true
+		}
+	}
+
+	return isLoopbackRequest(req)
+}
+
+// isLoopbackRequest returns true if the request comes from the loopback interface.
+func isLoopbackRequest(req *http.Request) bool {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Drain drains the local ScyllaDB node, after which it stops serving clients and Readyz reports it as unready.
+// Only POST requests of authorized callers are accepted.
+func (p *Prober) Drain(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeProbeResponse(w, req, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", req.Method))
+		return
+	}
+
+	if !p.isAuthorizedCaller(req) {
+		klog.InfoS("drain: rejecting unauthorized caller", "Service", p.serviceRef(), "RemoteAddr", req.RemoteAddr)
+		writeProbeResponse(w, req, http.StatusForbidden, "caller isn't authorized to drain the node")
+		return
+	}
+
+	scyllaClient, err := p.getScyllaClient()
+	if err != nil {
+		klog.ErrorS(err, "drain: can't get scylla client", "Service", p.serviceRef())
+		writeProbeResponse(w, req, http.StatusInternalServerError, fmt.Sprintf("can't get scylla client: %v", err))
+		return
+	}
+	defer scyllaClient.Close()
+
+	klog.InfoS("drain: draining node", "Service", p.serviceRef())
+	err = scyllaClient.Drain(req.Context(), localhost)
+	if err != nil {
+		klog.ErrorS(err, "drain: can't drain node", "Service", p.serviceRef())
+		writeProbeResponse(w, req, http.StatusInternalServerError, fmt.Sprintf("can't drain node: %v", err))
+		return
+	}
+
+	klog.InfoS("drain: node drained", "Service", p.serviceRef())
+	writeProbeResponse(w, req, http.StatusAccepted, "node drained")
+}
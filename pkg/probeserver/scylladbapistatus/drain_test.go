@@ -0,0 +1,128 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_Drain(t *testing.T) {
+	t.Parallel()
+
+	const token = "secret"
+
+	tt := []struct {
+		name                 string
+		method               string
+		remoteAddr           string
+		authorization        string
+		client               *fakeScyllaClient
+		expectedStatus       int
+		expectedDrainCalls   int
+		expectedReadyzStatus int
+	}{
+		{
+			name:                 "local caller drains the node",
+			method:               http.MethodPost,
+			remoteAddr:           "127.0.0.1:41000",
+			client:               newUNScyllaClient(),
+			expectedStatus:       http.StatusAccepted,
+			expectedDrainCalls:   1,
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:                 "local IPv6 caller drains the node",
+			method:               http.MethodPost,
+			remoteAddr:           "[::1]:41000",
+			client:               newUNScyllaClient(),
+			expectedStatus:       http.StatusAccepted,
+			expectedDrainCalls:   1,
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:                 "remote caller with the token drains the node",
+			method:               http.MethodPost,
+			remoteAddr:           "10.0.0.2:41000",
+			authorization:        "Bearer " + token,
+			client:               newUNScyllaClient(),
+			expectedStatus:       http.StatusAccepted,
+			expectedDrainCalls:   1,
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:                 "remote caller without the token is rejected",
+			method:               http.MethodPost,
+			remoteAddr:           "10.0.0.2:41000",
+			client:               newUNScyllaClient(),
+			expectedStatus:       http.StatusForbidden,
+			expectedDrainCalls:   0,
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name:                 "remote caller with a wrong token is rejected",
+			method:               http.MethodPost,
+			remoteAddr:           "10.0.0.2:41000",
+			authorization:        "Bearer wrong",
+			client:               newUNScyllaClient(),
+			expectedStatus:       http.StatusForbidden,
+			expectedDrainCalls:   0,
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name:                 "non-POST request is rejected",
+			method:               http.MethodGet,
+			remoteAddr:           "127.0.0.1:41000",
+			client:               newUNScyllaClient(),
+			expectedStatus:       http.StatusMethodNotAllowed,
+			expectedDrainCalls:   0,
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name:       "drain failure is reported",
+			method:     http.MethodPost,
+			remoteAddr: "127.0.0.1:41000",
+			client: func() *fakeScyllaClient {
+				c := newUNScyllaClient()
+				c.err = fmt.Errorf("drain failed")
+				return c
+			}(),
+			expectedStatus:       http.StatusInternalServerError,
+			expectedDrainCalls:   1,
+			expectedReadyzStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				DrainAuthToken: token,
+			})
+
+			req := httptest.NewRequest(tc.method, naming.DrainPath, nil)
+			req.RemoteAddr = tc.remoteAddr
+			if len(tc.authorization) != 0 {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+			p.Drain(w, req)
+
+			if w.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, w.Code)
+			}
+
+			if tc.client.drainCalls != tc.expectedDrainCalls {
+				t.Errorf("expected %d drain call(s), got %d", tc.expectedDrainCalls, tc.client.drainCalls)
+			}
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+		})
+	}
+}
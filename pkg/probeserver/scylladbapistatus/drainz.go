@@ -0,0 +1,76 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Drainz reports whether the node under maintenance has served enough consecutive unready readiness probe responses
+// for it to be removed from load balancers. It is meant to be queried before a maintenance action proceeds.
+func (p *Prober) Drainz(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
+		return
+	}
+
+	statusCode, reason := p.drainz()
+	writeProbeResponse(w, req, statusCode, reason)
+}
+
+func (p *Prober) drainz() (int, string) {
+	underMaintenance, err := p.isNodeUnderMaintenance()
+	if err != nil {
+		return http.StatusServiceUnavailable, p.handleServiceLabelLookupError("drainz probe", "maintenance", err)
+	}
+
+	if !underMaintenance {
+		return http.StatusServiceUnavailable, "node isn't under maintenance"
+	}
+
+	served := p.maintenanceUnreadyProbes.Load()
+	if served < int64(p.options.MaintenanceDrainProbeCount) {
+		p.logFailure("drainz probe: node isn't drained yet", "Service", p.serviceRef(), "Served", served, "Required", p.options.MaintenanceDrainProbeCount)
+		return http.StatusServiceUnavailable, fmt.Sprintf("served %d out of %d unready probe(s) during maintenance", served, p.options.MaintenanceDrainProbeCount)
+	}
+
+	return http.StatusOK, "ok"
+}
+
+// DrainComplete reports whether the node under maintenance has no CQL client connections left open,
+// so in-flight queries are done and the maintenance action can proceed without affecting clients.
+// It is meant to be queried after Drainz reports the node as drained.
+func (p *Prober) DrainComplete(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
+		return
+	}
+
+	ctx, ctxCancel := context.WithTimeout(req.Context(), p.readyzTimeout)
+	defer ctxCancel()
+
+	statusCode, reason := p.drainComplete(ctx)
+	writeProbeResponse(w, req, statusCode, reason)
+}
+
+func (p *Prober) drainComplete(ctx context.Context) (int, string) {
+	underMaintenance, err := p.isNodeUnderMaintenance()
+	if err != nil {
+		return http.StatusServiceUnavailable, p.handleServiceLabelLookupError("drain complete probe", "maintenance", err)
+	}
+
+	if !underMaintenance {
+		return http.StatusServiceUnavailable, "node isn't under maintenance"
+	}
+
+	connections, err := p.cqlConnections(ctx)
+	if err != nil {
+		p.logFailure("drain complete probe: can't get CQL connections", "Service", p.serviceRef(), "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get CQL connections: %v", err)
+	}
+
+	if connections > 0 {
+		p.logFailure("drain complete probe: CQL connections are still open", "Service", p.serviceRef(), "Connections", connections)
+		return http.StatusServiceUnavailable, fmt.Sprintf("%d CQL connection(s) are still open", connections)
+	}
+
+	return http.StatusOK, "ok"
+}
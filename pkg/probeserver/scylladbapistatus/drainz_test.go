@@ -0,0 +1,144 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_Drainz(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(nil)
+	p := newTestProberWithOptions(t, svc, newUNScyllaClient(), ProberOptions{
+		MaintenanceDrainProbeCount: 2,
+	})
+
+	expectStatuses := func(expectedReadyzStatus, expectedDrainzStatus int) {
+		t.Helper()
+
+		readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+		if readyzStatus != expectedReadyzStatus {
+			t.Errorf("expected readyz status %d, got %d", expectedReadyzStatus, readyzStatus)
+		}
+
+		drainzStatus := probe(p.Drainz, naming.DrainProbePath)
+		if drainzStatus != expectedDrainzStatus {
+			t.Errorf("expected drainz status %d, got %d", expectedDrainzStatus, drainzStatus)
+		}
+	}
+
+	// Node isn't under maintenance, so it's never drained.
+	expectStatuses(http.StatusOK, http.StatusServiceUnavailable)
+
+	svc.Labels = map[string]string{
+		naming.NodeMaintenanceLabel: "",
+	}
+	expectStatuses(http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	expectStatuses(http.StatusServiceUnavailable, http.StatusOK)
+	expectStatuses(http.StatusServiceUnavailable, http.StatusOK)
+
+	// Leaving maintenance resets the count.
+	svc.Labels = nil
+	expectStatuses(http.StatusOK, http.StatusServiceUnavailable)
+
+	svc.Labels = map[string]string{
+		naming.NodeMaintenanceLabel: "",
+	}
+	expectStatuses(http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	drainzStatus, reason := probeVerbose(p.Drainz, naming.DrainProbePath)
+	if drainzStatus != http.StatusServiceUnavailable {
+		t.Errorf("expected drainz status %d, got %d", http.StatusServiceUnavailable, drainzStatus)
+	}
+	expectedReason := "served 1 out of 2 unready probe(s) during maintenance\n"
+	if reason != expectedReason {
+		t.Errorf("expected reason %q, got %q", expectedReason, reason)
+	}
+}
+
+func TestProber_DrainComplete(t *testing.T) {
+	t.Parallel()
+
+	var metricsStatusCode atomic.Int32
+	var cqlConnections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(int(metricsStatusCode.Load()))
+		// Connections are split between shards, the same way ScyllaDB exposes them.
+		connections := cqlConnections.Load()
+		_, _ = fmt.Fprintf(w, `# HELP scylla_transport_current_connections Holds the current number of client connections
+# TYPE scylla_transport_current_connections gauge
+scylla_transport_current_connections{shard="0"} %d
+scylla_transport_current_connections{shard="1"} %d
+# HELP scylla_transport_requests_served Counts a number of served requests.
+# TYPE scylla_transport_requests_served counter
+scylla_transport_requests_served{shard="0"} 42
+scylla_transport_requests_served{shard="1"} 7
+`, connections-connections/2, connections/2)
+	}))
+	defer server.Close()
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metricsPort, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metricsStatusCode.Store(http.StatusOK)
+	cqlConnections.Store(3)
+
+	svc := newTestService(nil)
+	p := newTestProberWithOptions(t, svc, newUNScyllaClient(), ProberOptions{
+		MetricsPort: metricsPort,
+	})
+
+	expectStatus := func(expectedStatus int, expectedReason string) {
+		t.Helper()
+
+		status, reason := probeVerbose(p.DrainComplete, naming.DrainCompletePath)
+		if status != expectedStatus {
+			t.Errorf("expected drain complete status %d, got %d", expectedStatus, status)
+		}
+
+		if reason != expectedReason {
+			t.Errorf("expected reason %q, got %q", expectedReason, reason)
+		}
+	}
+
+	// Node isn't under maintenance, so draining never completes.
+	expectStatus(http.StatusServiceUnavailable, "node isn't under maintenance\n")
+
+	svc.Labels = map[string]string{
+		naming.NodeMaintenanceLabel: "",
+	}
+	expectStatus(http.StatusServiceUnavailable, "3 CQL connection(s) are still open\n")
+
+	cqlConnections.Store(1)
+	expectStatus(http.StatusServiceUnavailable, "1 CQL connection(s) are still open\n")
+
+	cqlConnections.Store(0)
+	expectStatus(http.StatusOK, "ok\n")
+
+	readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+	if readyzStatus != http.StatusServiceUnavailable {
+		t.Errorf("expected readyz status %d, got %d", http.StatusServiceUnavailable, readyzStatus)
+	}
+
+	metricsStatusCode.Store(http.StatusInternalServerError)
+	expectStatus(http.StatusServiceUnavailable, "can't get CQL connections: metrics endpoint responded with status code 500\n")
+}
@@ -0,0 +1,59 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	"k8s.io/klog/v2"
+)
+
+// getExpectedNodes returns the number of nodes the operator expects in the datacenter, as annotated
+// on the member service. It returns false if it isn't known.
+func (p *Prober) getExpectedNodes() (int, bool) {
+	svc, err := p.serviceLister.Services(p.namespace).Get(p.serviceName)
+	if err != nil {
+		klog.V(4).InfoS("can't get service to read the expected node count", "Service", p.serviceRef(), "Error", err)
+		return 0, false
+	}
+
+	v, ok := svc.Annotations[naming.ExpectedNodesAnnotation]
+	if !ok {
+		return 0, false
+	}
+
+	expectedNodes, err := strconv.Atoi(v)
+	if err != nil {
+		klog.V(4).InfoS("can't parse expected node count", "Service", p.serviceRef(), "Value", v, "Error", err)
+		return 0, false
+	}
+
+	return expectedNodes, true
+}
+
+// recordUNNodes records the number of UN nodes the local node observes in the cluster.
+func (p *Prober) recordUNNodes(nodeStatuses scyllaclient.NodeStatusInfoSlice) {
+	unNodes := len(nodeStatuses.LiveHosts())
+	p.unNodes.Store(&unNodes)
+}
+
+// clusterFormationDetails describes the expected node count of the datacenter and the number of UN nodes
+// last observed by the local node, one "key=value" pair per line, for tooling parsing verbose probe responses.
+// It is empty when the expected node count isn't known.
+func (p *Prober) clusterFormationDetails() string {
+	expectedNodes, ok := p.getExpectedNodes()
+	if !ok {
+		return ""
+	}
+
+	details := []string{fmt.Sprintf("expectedNodes=%d", expectedNodes)}
+
+	unNodes := p.unNodes.Load()
+	if unNodes != nil {
+		details = append(details, fmt.Sprintf("unNodes=%d", *unNodes))
+	}
+
+	return strings.Join(details, "\n")
+}
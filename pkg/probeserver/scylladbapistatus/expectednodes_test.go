@@ -0,0 +1,94 @@
+package scylladbapistatus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+)
+
+func TestProber_ReadyzReportsClusterFormation(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(nodeStatuses ...scyllaclient.NodeStatus) *fakeScyllaClient {
+		client := newUNScyllaClient()
+		for i, s := range nodeStatuses {
+			client.nodeStatuses = append(client.nodeStatuses, scyllaclient.NodeStatusInfo{
+				HostID: "other-host-id",
+				Addr:   fmt.Sprintf("10.0.1.%d", i+1),
+				Status: s,
+				State:  scyllaclient.NodeStateNormal,
+			})
+		}
+		return client
+	}
+
+	tt := []struct {
+		name                 string
+		annotations          map[string]string
+		client               *fakeScyllaClient
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "expected node count isn't reported without the annotation",
+			annotations:          nil,
+			client:               newClient(scyllaclient.NodeStatusUp),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name: "malformed expected node count isn't reported",
+			annotations: map[string]string{
+				naming.ExpectedNodesAnnotation: "three",
+			},
+			client:               newClient(scyllaclient.NodeStatusUp),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name: "expected and UN node counts are reported",
+			annotations: map[string]string{
+				naming.ExpectedNodesAnnotation: "3",
+			},
+			client:               newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStatusDown),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\nexpectedNodes=3\nunNodes=2\n",
+		},
+		{
+			name: "UN node count isn't reported when the node status is unknown",
+			annotations: map[string]string{
+				naming.ExpectedNodesAnnotation: "3",
+			},
+			client: func() *fakeScyllaClient {
+				client := newClient()
+				client.err = errors.New("test error")
+				return client
+			}(),
+			expectedReadyzStatus: http.StatusInternalServerError,
+			expectedBody:         "can't get scylla node status: test error\nexpectedNodes=3\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newTestService(nil)
+			svc.Annotations = tc.annotations
+			p := newTestProber(t, svc, tc.client)
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
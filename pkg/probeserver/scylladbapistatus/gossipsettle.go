@@ -0,0 +1,59 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	"k8s.io/klog/v2"
+)
+
+// gossipStateFingerprint identifies the endpoint states in the view of the cluster of the local node,
+// regardless of their order.
+func gossipStateFingerprint(nodeStatuses scyllaclient.NodeStatusInfoSlice) string {
+	states := make([]string, 0, len(nodeStatuses))
+	for _, s := range nodeStatuses {
+		states = append(states, fmt.Sprintf("%s/%s/%s%s", s.HostID, s.Addr, s.Status, s.State))
+	}
+	slices.Sort(states)
+
+	return strings.Join(states, ",")
+}
+
+// getGossipStableSince records the observed endpoint states and returns the time since when they haven't changed.
+func (p *Prober) getGossipStableSince(nodeStatuses scyllaclient.NodeStatusInfoSlice) time.Time {
+	fingerprint := gossipStateFingerprint(nodeStatuses)
+
+	p.gossipStateLock.Lock()
+	defer p.gossipStateLock.Unlock()
+
+	if p.gossipStableSince.IsZero() || fingerprint != p.gossipStateFingerprint {
+		p.gossipStateFingerprint = fingerprint
+		p.gossipStableSince = p.now()
+	}
+
+	return p.gossipStableSince
+}
+
+// gossipSettleReadyz holds back the readiness of the node until the endpoint states in its view of the cluster
+// stay unchanged for the window, so a rejoining node doesn't receive traffic while its view is converging.
+// It also returns whether the outcome is transient, as the view couldn't be obtained from ScyllaDB API.
+func (p *Prober) gossipSettleReadyz(ctx context.Context, scyllaClient ScyllaClient, window time.Duration) (int, string, bool) {
+	nodeStatuses, err := scyllaClient.Status(ctx, localhost)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get scylla node status", "Service", p.serviceRef())
+		return http.StatusInternalServerError, fmt.Sprintf("can't get scylla node status: %v", err), true
+	}
+
+	remaining := window - p.now().Sub(p.getGossipStableSince(nodeStatuses))
+	if remaining > 0 {
+		p.logFailure("readyz probe: gossip is settling", "Service", p.serviceRef(), "Remaining", remaining)
+		return http.StatusServiceUnavailable, fmt.Sprintf("gossip is settling, %v remaining", remaining), false
+	}
+
+	return http.StatusOK, "ok", false
+}
@@ -0,0 +1,102 @@
+package scylladbapistatus
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+)
+
+func TestProber_GossipSettleWindow(t *testing.T) {
+	t.Parallel()
+
+	const gossipSettleWindow = 30 * time.Second
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	otherNode := func(status scyllaclient.NodeStatus, state scyllaclient.NodeState) scyllaclient.NodeStatusInfo {
+		return scyllaclient.NodeStatusInfo{
+			HostID: "host-id-2",
+			Addr:   "10.0.0.2",
+			Status: status,
+			State:  state,
+		}
+	}
+
+	type step struct {
+		elapsed              time.Duration
+		otherNodes           []scyllaclient.NodeStatusInfo
+		expectedReadyzStatus int
+	}
+
+	tt := []struct {
+		name               string
+		gossipSettleWindow time.Duration
+		steps              []step
+	}{
+		{
+			name:               "disabled check makes a UN node ready immediately",
+			gossipSettleWindow: 0,
+			steps: []step{
+				{elapsed: 0, otherNodes: nil, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:               "node is held unready until the endpoint states are stable for the window",
+			gossipSettleWindow: gossipSettleWindow,
+			steps: []step{
+				{elapsed: 0, otherNodes: nil, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: gossipSettleWindow - time.Second, otherNodes: nil, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Second, otherNodes: nil, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:               "converging endpoint states restart the window",
+			gossipSettleWindow: gossipSettleWindow,
+			steps: []step{
+				{elapsed: 0, otherNodes: []scyllaclient.NodeStatusInfo{otherNode(scyllaclient.NodeStatusDown, scyllaclient.NodeStateNormal)}, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: 20 * time.Second, otherNodes: []scyllaclient.NodeStatusInfo{otherNode(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining)}, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: 20 * time.Second, otherNodes: []scyllaclient.NodeStatusInfo{otherNode(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal)}, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: 20 * time.Second, otherNodes: []scyllaclient.NodeStatusInfo{otherNode(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal)}, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: 10 * time.Second, otherNodes: []scyllaclient.NodeStatusInfo{otherNode(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal)}, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:               "change of settled endpoint states makes the node unready again",
+			gossipSettleWindow: gossipSettleWindow,
+			steps: []step{
+				{elapsed: 0, otherNodes: nil, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: gossipSettleWindow, otherNodes: nil, expectedReadyzStatus: http.StatusOK},
+				{elapsed: time.Second, otherNodes: []scyllaclient.NodeStatusInfo{otherNode(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining)}, expectedReadyzStatus: http.StatusServiceUnavailable},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUNScyllaClient()
+			localNodeStatuses := client.nodeStatuses
+			p := newTestProberWithOptions(t, newTestService(nil), client, ProberOptions{
+				GossipSettleWindow: tc.gossipSettleWindow,
+			})
+			now := start
+			p.now = func() time.Time {
+				return now
+			}
+
+			for i, s := range tc.steps {
+				now = now.Add(s.elapsed)
+				client.nodeStatuses = append(append(scyllaclient.NodeStatusInfoSlice{}, localNodeStatuses...), s.otherNodes...)
+
+				readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+				if readyzStatus != s.expectedReadyzStatus {
+					t.Errorf("step %d: expected readyz status %d, got %d", i, s.expectedReadyzStatus, readyzStatus)
+				}
+			}
+		})
+	}
+}
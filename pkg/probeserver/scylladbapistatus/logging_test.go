@@ -0,0 +1,99 @@
+package scylladbapistatus
+
+import (
+	"bytes"
+	"flag"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/pointer"
+	"k8s.io/klog/v2"
+)
+
+// TestProber_LogLevels isn't parallel because it changes the global klog configuration.
+func TestProber_LogLevels(t *testing.T) {
+	defer klog.CaptureState().Restore()
+
+	var logs bytes.Buffer
+	fs := flag.NewFlagSet(t.Name(), flag.PanicOnError)
+	klog.InitFlags(fs)
+	for k, v := range map[string]string{
+		"v":               "2",
+		"logtostderr":     "false",
+		"alsologtostderr": "false",
+		"stderrthreshold": "FATAL",
+	} {
+		err := fs.Set(k, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	klog.SetOutput(&logs)
+
+	const (
+		successMessage = "readyz probe: node is ready"
+		failureMessage = "readyz probe: node is paused"
+	)
+
+	tt := []struct {
+		name          string
+		options       ProberOptions
+		expectSuccess bool
+		expectFailure bool
+	}{
+		{
+			name:          "default levels log failures but not successes",
+			options:       ProberOptions{},
+			expectSuccess: false,
+			expectFailure: true,
+		},
+		{
+			name: "successes are logged at an enabled level",
+			options: ProberOptions{
+				SuccessLogLevel: pointer.Ptr(klog.Level(0)),
+			},
+			expectSuccess: true,
+			expectFailure: true,
+		},
+		{
+			name: "failures are silenced above the configured verbosity",
+			options: ProberOptions{
+				SuccessLogLevel: pointer.Ptr(klog.Level(0)),
+				FailureLogLevel: pointer.Ptr(klog.Level(3)),
+			},
+			expectSuccess: true,
+			expectFailure: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := newTestService(nil)
+			p := newTestProberWithOptions(t, svc, newUNScyllaClient(), tc.options)
+
+			logs.Reset()
+			probe(p.Readyz, naming.ReadinessProbePath)
+			klog.Flush()
+			gotSuccess := strings.Contains(logs.String(), successMessage)
+			if gotSuccess != tc.expectSuccess {
+				t.Errorf("expected success message logged to be %t, got %t: %q", tc.expectSuccess, gotSuccess, logs.String())
+			}
+
+			svc.Labels = map[string]string{
+				naming.NodePausedLabel: "",
+			}
+			logs.Reset()
+			status := probe(p.Readyz, naming.ReadinessProbePath)
+			if status != http.StatusServiceUnavailable {
+				t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, status)
+			}
+			klog.Flush()
+			gotFailure := strings.Contains(logs.String(), failureMessage)
+			if gotFailure != tc.expectFailure {
+				t.Errorf("expected failure message logged to be %t, got %t: %q", tc.expectFailure, gotFailure, logs.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// DefaultProbeMethods are the HTTP methods accepted by probe handlers when they aren't configured.
+var DefaultProbeMethods = []string{http.MethodGet, http.MethodHead}
+
+// allowProbeMethod responds with 405 and returns false if the request method isn't accepted by probe handlers.
+func (p *Prober) allowProbeMethod(w http.ResponseWriter, req *http.Request) bool {
+	if slices.Contains(p.probeMethods, req.Method) {
+		return true
+	}
+
+	w.Header().Set("Allow", strings.Join(p.probeMethods, ", "))
+	writeProbeResponse(w, req, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", req.Method))
+	return false
+}
@@ -0,0 +1,102 @@
+package scylladbapistatus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_ProbeMethods(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                 string
+		probeMethods         []string
+		method               string
+		expectedStatusCode   int
+		expectedBody         string
+		expectedAllowHeader  string
+		expectedStatusCalled bool
+	}{
+		{
+			name:                 "GET runs the checks and writes the body",
+			probeMethods:         nil,
+			method:               http.MethodGet,
+			expectedStatusCode:   http.StatusOK,
+			expectedBody:         "ok\n",
+			expectedAllowHeader:  "",
+			expectedStatusCalled: true,
+		},
+		{
+			name:                 "HEAD runs the checks and omits the body",
+			probeMethods:         nil,
+			method:               http.MethodHead,
+			expectedStatusCode:   http.StatusOK,
+			expectedBody:         "",
+			expectedAllowHeader:  "",
+			expectedStatusCalled: true,
+		},
+		{
+			name:                 "POST is rejected by default without running the checks",
+			probeMethods:         nil,
+			method:               http.MethodPost,
+			expectedStatusCode:   http.StatusMethodNotAllowed,
+			expectedBody:         "method POST is not allowed\n",
+			expectedAllowHeader:  "GET, HEAD",
+			expectedStatusCalled: false,
+		},
+		{
+			name:                 "POST is accepted when configured",
+			probeMethods:         []string{http.MethodGet, http.MethodPost},
+			method:               http.MethodPost,
+			expectedStatusCode:   http.StatusOK,
+			expectedBody:         "ok\n",
+			expectedAllowHeader:  "",
+			expectedStatusCalled: true,
+		},
+		{
+			name:                 "HEAD is rejected when not configured",
+			probeMethods:         []string{http.MethodGet},
+			method:               http.MethodHead,
+			expectedStatusCode:   http.StatusMethodNotAllowed,
+			expectedBody:         "",
+			expectedAllowHeader:  "GET",
+			expectedStatusCalled: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUNScyllaClient()
+			p := newTestProberWithOptions(t, newTestService(nil), client, ProberOptions{
+				ProbeMethods: tc.probeMethods,
+			})
+
+			req := httptest.NewRequest(tc.method, naming.ReadinessProbePath+"?verbose", nil)
+			w := httptest.NewRecorder()
+			p.Readyz(w, req)
+
+			if w.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, w.Body.String())
+			}
+
+			allowHeader := w.Header().Get("Allow")
+			if allowHeader != tc.expectedAllowHeader {
+				t.Errorf("expected Allow header %q, got %q", tc.expectedAllowHeader, allowHeader)
+			}
+
+			statusCalled := client.statusCalls != 0
+			if statusCalled != tc.expectedStatusCalled {
+				t.Errorf("expected status called %t, got %t", tc.expectedStatusCalled, statusCalled)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	"k8s.io/klog/v2"
+)
+
+// DefaultMetricsPort is the port of ScyllaDB Prometheus metrics endpoint used when it isn't configured.
+const DefaultMetricsPort = 9180
+
+// metricsURL returns the URL of the local ScyllaDB Prometheus metrics endpoint.
+func (p *Prober) metricsURL() string {
+	port := p.options.MetricsPort
+	if port == 0 {
+		port = DefaultMetricsPort
+	}
+
+	return fmt.Sprintf("http://%s/metrics", net.JoinHostPort(localhost, strconv.Itoa(port)))
+}
+
+// metricsWarning checks that the local ScyllaDB Prometheus metrics endpoint responds.
+// It returns a warning describing why it doesn't, or an empty string when it does.
+// The endpoint being down doesn't affect serving traffic, so callers don't fail probes on it.
+func (p *Prober) metricsWarning(ctx context.Context, probeName string) string {
+	url := p.metricsURL()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		klog.ErrorS(err, fmt.Sprintf("%s: can't create metrics request", probeName), "URL", url)
+		return fmt.Sprintf("warning: can't create metrics request: %v", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		klog.InfoS(fmt.Sprintf("%s: metrics endpoint is down", probeName), "Service", p.serviceRef(), "URL", url, "Error", err)
+		return fmt.Sprintf("warning: metrics endpoint is down: %v", err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		klog.InfoS(fmt.Sprintf("%s: metrics endpoint responded with an unexpected status code", probeName), "Service", p.serviceRef(), "URL", url, "StatusCode", resp.StatusCode)
+		return fmt.Sprintf("warning: metrics endpoint responded with status code %d", resp.StatusCode)
+	}
+
+	return ""
+}
+
+// cqlConnections returns the number of CQL client connections open to the local node, read from its Prometheus
+// metrics endpoint. The ScyllaDB API doesn't serve metrics, so they can't be obtained through the ScyllaDB client.
+func (p *Prober) cqlConnections(ctx context.Context) (int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.metricsURL(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("can't create metrics request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("metrics endpoint is down: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return 0, fmt.Errorf("metrics endpoint responded with status code %d", resp.StatusCode)
+	}
+
+	return scyllaclient.ParseCQLConnections(resp.Body)
+}
@@ -0,0 +1,101 @@
+package scylladbapistatus
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_MetricsCheck(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name              string
+		metricsCheck      bool
+		metricsStatusCode int
+		metricsDown       bool
+		expectedWarning   string
+	}{
+		{
+			name:         "check is skipped when disabled",
+			metricsCheck: false,
+			metricsDown:  true,
+		},
+		{
+			name:              "node is ready without a warning when metrics endpoint responds",
+			metricsCheck:      true,
+			metricsStatusCode: http.StatusOK,
+		},
+		{
+			name:              "node is ready with a warning when metrics endpoint responds with an error",
+			metricsCheck:      true,
+			metricsStatusCode: http.StatusInternalServerError,
+			expectedWarning:   "warning: metrics endpoint responded with status code 500\n",
+		},
+		{
+			name:         "node is ready with a warning when metrics endpoint is down",
+			metricsCheck: true,
+			metricsDown:  true,
+			// The rest of the warning is the connection error, which depends on the platform.
+			expectedWarning: "warning: metrics endpoint is down: ",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/metrics" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.WriteHeader(tc.metricsStatusCode)
+			}))
+			defer server.Close()
+
+			_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			metricsPort, err := strconv.Atoi(port)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.metricsDown {
+				server.Close()
+			}
+
+			p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+				MetricsCheck: tc.metricsCheck,
+				MetricsPort:  metricsPort,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != http.StatusOK {
+				t.Errorf("expected readyz status %d, got %d", http.StatusOK, readyzStatus)
+			}
+
+			if len(tc.expectedWarning) == 0 && body != "ok\n" {
+				t.Errorf("expected body %q, got %q", "ok\n", body)
+			}
+
+			if len(tc.expectedWarning) != 0 && !strings.HasPrefix(body, "ok\n"+tc.expectedWarning) {
+				t.Errorf("expected body to start with %q, got %q", "ok\n"+tc.expectedWarning, body)
+			}
+
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			if healthzStatus != http.StatusOK {
+				t.Errorf("expected healthz status %d, got %d", http.StatusOK, healthzStatus)
+			}
+		})
+	}
+}
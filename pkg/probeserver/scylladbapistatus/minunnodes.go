@@ -0,0 +1,60 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"k8s.io/klog/v2"
+)
+
+// isInitialSeed returns true if the local node is the first node of the first rack, which bootstraps the datacenter.
+func (p *Prober) isInitialSeed() (bool, error) {
+	pod, err := p.podLister.Pods(p.namespace).Get(p.podName)
+	if err != nil {
+		return false, fmt.Errorf("can't get pod: %w", err)
+	}
+
+	rackOrdinal, ok := pod.Labels[naming.RackOrdinalLabel]
+	if !ok {
+		return false, fmt.Errorf("pod is missing %q label", naming.RackOrdinalLabel)
+	}
+
+	if rackOrdinal != strconv.Itoa(0) {
+		return false, nil
+	}
+
+	podOrdinal, err := naming.IndexFromName(pod.Name)
+	if err != nil {
+		return false, fmt.Errorf("can't get pod ordinal: %w", err)
+	}
+
+	return podOrdinal == 0, nil
+}
+
+// minUNNodesReadyz determines readiness based on the number of UN nodes last observed by the local node.
+func (p *Prober) minUNNodesReadyz() (int, string) {
+	unNodes := p.unNodes.Load()
+	if unNodes != nil && *unNodes >= p.options.MinUNNodes {
+		return http.StatusOK, "ok"
+	}
+
+	initialSeed, err := p.isInitialSeed()
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't determine whether the node is the initial seed", "Pod", p.podRef())
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't determine whether the node is the initial seed: %v", err)
+	}
+
+	if initialSeed {
+		return http.StatusOK, "ok"
+	}
+
+	observedUNNodes := 0
+	if unNodes != nil {
+		observedUNNodes = *unNodes
+	}
+
+	p.logFailure("readyz probe: not enough nodes are UN", "Service", p.serviceRef(), "UNNodes", observedUNNodes, "MinUNNodes", p.options.MinUNNodes)
+	return http.StatusServiceUnavailable, fmt.Sprintf("only %d out of the minimum of %d nodes are UN", observedUNNodes, p.options.MinUNNodes)
+}
@@ -0,0 +1,131 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestProber_MinUNNodes(t *testing.T) {
+	t.Parallel()
+
+	clientWithUNNodes := func(unNodes int) *fakeScyllaClient {
+		client := newUNScyllaClient()
+		for i := 1; i < unNodes; i++ {
+			client.nodeStatuses = append(client.nodeStatuses, scyllaclient.NodeStatusInfo{
+				HostID: fmt.Sprintf("other-host-id-%d", i),
+				Addr:   fmt.Sprintf("10.0.1.%d", i),
+				Status: scyllaclient.NodeStatusUp,
+				State:  scyllaclient.NodeStateNormal,
+			})
+		}
+		return client
+	}
+
+	newPod := func(name, rackOrdinal string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					naming.RackOrdinalLabel: rackOrdinal,
+				},
+			},
+		}
+	}
+
+	tt := []struct {
+		name                 string
+		minUNNodes           int
+		pod                  *corev1.Pod
+		client               ScyllaClient
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "check is skipped when no minimum is set",
+			minUNNodes:           0,
+			pod:                  newPod("basic-dc-rack-1", "0"),
+			client:               clientWithUNNodes(1),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node is ready when enough nodes are UN",
+			minUNNodes:           3,
+			pod:                  newPod("basic-dc-rack-1", "0"),
+			client:               clientWithUNNodes(3),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node isn't ready when not enough nodes are UN",
+			minUNNodes:           3,
+			pod:                  newPod("basic-dc-rack-1", "0"),
+			client:               clientWithUNNodes(2),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "only 2 out of the minimum of 3 nodes are UN\n",
+		},
+		{
+			name:                 "first node of a later rack isn't exempt",
+			minUNNodes:           3,
+			pod:                  newPod("basic-dc-rack2-0", "1"),
+			client:               clientWithUNNodes(2),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "only 2 out of the minimum of 3 nodes are UN\n",
+		},
+		{
+			name:                 "initial seed is exempt from the minimum",
+			minUNNodes:           3,
+			pod:                  newPod("basic-dc-rack-0", "0"),
+			client:               clientWithUNNodes(1),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node isn't ready when it can't be determined whether it is the initial seed",
+			minUNNodes:           3,
+			pod:                  nil,
+			client:               clientWithUNNodes(1),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "can't determine whether the node is the initial seed: can't get pod: pod \"basic-dc-rack-0\" not found\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				MinUNNodes: tc.minUNNodes,
+			})
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			p.podName = testServiceName
+			if tc.pod != nil {
+				p.podName = tc.pod.Name
+				err := podCache.Add(tc.pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			p.podLister = corev1listers.NewPodLister(podCache)
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+)
+
+// nodeStatus is a node in the view of the cluster of the local node, as exposed by Nodes.
+type nodeStatus struct {
+	HostID  string `json:"hostID"`
+	Address string `json:"address"`
+	// Status is "U" for nodes that are up and "D" for nodes that are down.
+	Status string `json:"status"`
+	// State is "N", "L", "J" or "M" for nodes that are normal, leaving, joining or moving, respectively.
+	State string `json:"state"`
+}
+
+// Nodes responds with the status of nodes in the view of the cluster of the local node as JSON,
+// the same way nodetool status does. Only authorized callers are allowed.
+func (p *Prober) Nodes(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
+		return
+	}
+
+	if !p.isAuthorizedCaller(req) {
+		klog.InfoS("nodes: rejecting unauthorized caller", "Service", p.serviceRef(), "RemoteAddr", req.RemoteAddr)
+		writeProbeResponse(w, req, http.StatusForbidden, "caller isn't authorized to query nodes")
+		return
+	}
+
+	ctx, ctxCancel := context.WithTimeout(req.Context(), p.readyzTimeout)
+	defer ctxCancel()
+
+	scyllaClient, err := p.getScyllaClient()
+	if err != nil {
+		klog.ErrorS(err, "nodes: can't get scylla client", "Service", p.serviceRef())
+		writeProbeResponse(w, req, http.StatusInternalServerError, fmt.Sprintf("can't get scylla client: %v", err))
+		return
+	}
+	defer scyllaClient.Close()
+
+	nodeStatuses, err := scyllaClient.Status(ctx, localhost)
+	if err != nil {
+		klog.ErrorS(err, "nodes: can't get scylla node status", "Service", p.serviceRef())
+		writeProbeResponse(w, req, http.StatusServiceUnavailable, fmt.Sprintf("can't get scylla node status: %v", err))
+		return
+	}
+
+	nodes := make([]nodeStatus, 0, len(nodeStatuses))
+	for _, s := range nodeStatuses {
+		nodes = append(nodes, nodeStatus{
+			HostID:  s.HostID,
+			Address: s.Addr,
+			Status:  s.Status.String(),
+			State:   s.State.String(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(nodes)
+	if err != nil {
+		klog.ErrorS(err, "nodes: can't write node statuses")
+	}
+}
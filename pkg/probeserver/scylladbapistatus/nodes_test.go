@@ -0,0 +1,114 @@
+package scylladbapistatus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+)
+
+func TestProber_Nodes(t *testing.T) {
+	t.Parallel()
+
+	newClient := func() *fakeScyllaClient {
+		client := newUNScyllaClient()
+		client.nodeStatuses = append(client.nodeStatuses,
+			scyllaclient.NodeStatusInfo{
+				HostID: "host-id-2",
+				Addr:   "10.0.0.2",
+				Status: scyllaclient.NodeStatusUp,
+				State:  scyllaclient.NodeStateJoining,
+			},
+			scyllaclient.NodeStatusInfo{
+				HostID: "host-id-3",
+				Addr:   "10.0.0.3",
+				Status: scyllaclient.NodeStatusDown,
+				State:  scyllaclient.NodeStateNormal,
+			},
+		)
+		return client
+	}
+
+	tt := []struct {
+		name               string
+		client             *fakeScyllaClient
+		drainAuthToken     string
+		remoteAddr         string
+		authorization      string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "non-local caller is rejected",
+			client:             newClient(),
+			remoteAddr:         "10.0.0.2:4242",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       "",
+		},
+		{
+			name:               "local caller gets the status of all nodes",
+			client:             newClient(),
+			remoteAddr:         "127.0.0.1:4242",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `[{"hostID":"` + testHostID + `","address":"10.0.0.1","status":"U","state":"N"},` +
+				`{"hostID":"host-id-2","address":"10.0.0.2","status":"U","state":"J"},` +
+				`{"hostID":"host-id-3","address":"10.0.0.3","status":"D","state":"N"}]` + "\n",
+		},
+		{
+			name:               "non-local caller presenting the auth token gets the status of all nodes",
+			client:             newClient(),
+			drainAuthToken:     "secret",
+			remoteAddr:         "10.0.0.2:4242",
+			authorization:      "Bearer secret",
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `[{"hostID":"` + testHostID + `","address":"10.0.0.1","status":"U","state":"N"},` +
+				`{"hostID":"host-id-2","address":"10.0.0.2","status":"U","state":"J"},` +
+				`{"hostID":"host-id-3","address":"10.0.0.3","status":"D","state":"N"}]` + "\n",
+		},
+		{
+			name: "node status that can't be determined is an error",
+			client: func() *fakeScyllaClient {
+				client := newClient()
+				client.err = errors.New("test error")
+				return client
+			}(),
+			remoteAddr:         "127.0.0.1:4242",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				DrainAuthToken: tc.drainAuthToken,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, naming.NodesPath, nil)
+			req.RemoteAddr = tc.remoteAddr
+			if len(tc.authorization) != 0 {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			w := httptest.NewRecorder()
+			p.Nodes(w, req)
+
+			if w.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected and got bodies differ: %s", cmp.Diff(tc.expectedBody, w.Body.String()))
+			}
+
+			if tc.expectedStatusCode != http.StatusForbidden && tc.client.lastDeadline.IsZero() {
+				t.Errorf("expected node status to be queried with a deadline")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package scylladbapistatus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// Pathz responds with the state of each await path as JSON. With the "format=text" query parameter,
+// it responds with the missing paths as plain text instead, one per line, which suits shell-based init scripts.
+// It responds with 503 while some paths are missing.
+func (p *Prober) Pathz(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
+		return
+	}
+
+	results, err := p.getAwaitPathResults()
+	if err != nil {
+		klog.ErrorS(err, "pathz: can't check required paths' existence")
+		writeProbeResponse(w, req, http.StatusInternalServerError, fmt.Sprintf("can't check required paths' existence: %v", err))
+		return
+	}
+
+	var missing []string
+	for _, r := range results {
+		if !r.Present {
+			missing = append(missing, r.Path)
+		}
+	}
+
+	statusCode := http.StatusOK
+	if len(missing) != 0 {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	format := req.URL.Query().Get("format")
+	switch format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	default:
+		writeProbeResponse(w, req, http.StatusBadRequest, fmt.Sprintf("unsupported format %q", format))
+		return
+	}
+
+	w.WriteHeader(statusCode)
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	if format == "text" {
+		if len(missing) == 0 {
+			return
+		}
+
+		_, err = fmt.Fprintln(w, strings.Join(missing, "\n"))
+		if err != nil {
+			klog.ErrorS(err, "pathz: can't write missing paths")
+		}
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(results)
+	if err != nil {
+		klog.ErrorS(err, "pathz: can't write path states")
+	}
+}
@@ -0,0 +1,97 @@
+package scylladbapistatus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_Pathz(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	presentPath := filepath.Join(tmpDir, "present")
+	err := os.WriteFile(presentPath, []byte("done\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	emptyPath := filepath.Join(tmpDir, "empty")
+	err = os.WriteFile(emptyPath, []byte{}, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missingPath := filepath.Join(tmpDir, "missing")
+
+	tt := []struct {
+		name               string
+		awaitPaths         []AwaitPath
+		query              string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "text output lists only missing paths",
+			awaitPaths: []AwaitPath{
+				{Path: presentPath},
+				{Path: missingPath},
+				{Path: emptyPath, MinSize: 1},
+			},
+			query:              "?format=text",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       missingPath + "\n" + emptyPath + "\n",
+		},
+		{
+			name: "text output is empty when all paths exist",
+			awaitPaths: []AwaitPath{
+				{Path: presentPath},
+				{Path: emptyPath},
+			},
+			query:              "?format=text",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "",
+		},
+		{
+			name: "JSON output lists all paths with their state",
+			awaitPaths: []AwaitPath{
+				{Path: presentPath},
+				{Path: emptyPath, MinSize: 1},
+			},
+			query:              "",
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedBody:       `[{"path":"` + presentPath + `","present":true},{"path":"` + emptyPath + `","minSize":1,"present":false}]` + "\n",
+		},
+		{
+			name:               "unsupported format is rejected",
+			awaitPaths:         nil,
+			query:              "?format=yaml",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProber(t, newTestService(nil), newUNScyllaClient())
+			p.awaitPaths = tc.awaitPaths
+
+			req := httptest.NewRequest(http.MethodGet, naming.PathzPath+tc.query, nil)
+			w := httptest.NewRecorder()
+			p.Pathz(w, req)
+
+			if w.Code != tc.expectedStatusCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != tc.expectedBody {
+				t.Errorf("expected and got bodies differ: %s", cmp.Diff(tc.expectedBody, w.Body.String()))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"k8s.io/klog/v2"
+)
+
+// getPodPlacement returns the datacenter and rack of the local Pod, as labeled by the operator.
+func (p *Prober) getPodPlacement() (string, string, error) {
+	pod, err := p.podLister.Pods(p.namespace).Get(p.podName)
+	if err != nil {
+		return "", "", fmt.Errorf("can't get pod: %w", err)
+	}
+
+	datacenter, ok := pod.Labels[naming.DatacenterNameLabel]
+	if !ok {
+		return "", "", fmt.Errorf("pod is missing %q label", naming.DatacenterNameLabel)
+	}
+
+	rack, ok := pod.Labels[naming.RackNameLabel]
+	if !ok {
+		return "", "", fmt.Errorf("pod is missing %q label", naming.RackNameLabel)
+	}
+
+	return datacenter, rack, nil
+}
+
+// getLocalNodeAddress returns the address of the local node in its view of the cluster.
+func getLocalNodeAddress(ctx context.Context, scyllaClient ScyllaClient) (string, error) {
+	nodeStatuses, err := scyllaClient.Status(ctx, localhost)
+	if err != nil {
+		return "", fmt.Errorf("can't get scylla node status: %w", err)
+	}
+
+	hostID, err := scyllaClient.GetLocalHostId(ctx, localhost, false)
+	if err != nil {
+		return "", fmt.Errorf("can't get host id: %w", err)
+	}
+
+	for _, s := range nodeStatuses {
+		if s.HostID == hostID {
+			return s.Addr, nil
+		}
+	}
+
+	return "", fmt.Errorf("local node with host id %q isn't in the node status", hostID)
+}
+
+// placementReadyz determines readiness based on whether the datacenter and rack of the local node
+// in ScyllaDB topology match the ones of its Pod, and whether the outcome is transient, as the topology couldn't be
+// obtained from ScyllaDB API.
+func (p *Prober) placementReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	expectedDatacenter, expectedRack, err := p.getPodPlacement()
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get placement of the pod", "Pod", p.podRef())
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get placement of the pod: %v", err), false
+	}
+
+	address, err := getLocalNodeAddress(ctx, scyllaClient)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get local node address", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get local node address: %v", err), true
+	}
+
+	datacenter, err := scyllaClient.GetSnitchDatacenter(ctx, address)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get datacenter of the node", "Service", p.serviceRef(), "Node", address)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get datacenter of the node: %v", err), true
+	}
+
+	rack, err := scyllaClient.GetSnitchRack(ctx, address)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get rack of the node", "Service", p.serviceRef(), "Node", address)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get rack of the node: %v", err), true
+	}
+
+	if datacenter != expectedDatacenter || rack != expectedRack {
+		p.logFailure("readyz probe: node placement doesn't match the pod", "Service", p.serviceRef(), "Datacenter", datacenter, "Rack", rack, "ExpectedDatacenter", expectedDatacenter, "ExpectedRack", expectedRack)
+		return http.StatusServiceUnavailable, fmt.Sprintf("node is in datacenter %q and rack %q, but its pod is placed in datacenter %q and rack %q", datacenter, rack, expectedDatacenter, expectedRack), false
+	}
+
+	return http.StatusOK, "ok", false
+}
@@ -0,0 +1,124 @@
+package scylladbapistatus
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestProber_PlacementCheck(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(datacenter, rack string) *fakeScyllaClient {
+		client := newUNScyllaClient()
+		client.datacenter = datacenter
+		client.rack = rack
+		return client
+	}
+
+	newPod := func(labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testServiceName,
+				Namespace: testNamespace,
+				Labels:    labels,
+			},
+		}
+	}
+
+	podLabels := map[string]string{
+		naming.DatacenterNameLabel: "dc",
+		naming.RackNameLabel:       "rack",
+	}
+
+	tt := []struct {
+		name                 string
+		placementCheck       bool
+		pod                  *corev1.Pod
+		client               ScyllaClient
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "check is skipped when disabled",
+			placementCheck:       false,
+			pod:                  newPod(podLabels),
+			client:               newClient("other-dc", "other-rack"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node is ready when its placement matches the pod",
+			placementCheck:       true,
+			pod:                  newPod(podLabels),
+			client:               newClient("dc", "rack"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node isn't ready when its rack doesn't match the pod",
+			placementCheck:       true,
+			pod:                  newPod(podLabels),
+			client:               newClient("dc", "other-rack"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "node is in datacenter \"dc\" and rack \"other-rack\", but its pod is placed in datacenter \"dc\" and rack \"rack\"\n",
+		},
+		{
+			name:                 "node isn't ready when its datacenter doesn't match the pod",
+			placementCheck:       true,
+			pod:                  newPod(podLabels),
+			client:               newClient("other-dc", "rack"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "node is in datacenter \"other-dc\" and rack \"rack\", but its pod is placed in datacenter \"dc\" and rack \"rack\"\n",
+		},
+		{
+			name:                 "node isn't ready when the pod isn't labeled with its placement",
+			placementCheck:       true,
+			pod:                  newPod(map[string]string{naming.DatacenterNameLabel: "dc"}),
+			client:               newClient("dc", "rack"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "can't get placement of the pod: pod is missing \"scylla/rack\" label\n",
+		},
+		{
+			name:                 "node isn't ready when the pod can't be found",
+			placementCheck:       true,
+			pod:                  nil,
+			client:               newClient("dc", "rack"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "can't get placement of the pod: can't get pod: pod \"basic-dc-rack-0\" not found\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				PlacementCheck: tc.placementCheck,
+			})
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if tc.pod != nil {
+				err := podCache.Add(tc.pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			p.podLister = corev1listers.NewPodLister(podCache)
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
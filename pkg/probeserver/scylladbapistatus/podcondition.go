@@ -0,0 +1,131 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultPodConditionReportInterval is the interval of reporting the pod condition when it isn't configured.
+	DefaultPodConditionReportInterval = 10 * time.Second
+
+	nodeReadyPodConditionReason    = "NodeReady"
+	nodeNotReadyPodConditionReason = "NodeNotReady"
+)
+
+type readyzOutcome struct {
+	statusCode int
+	reason     string
+}
+
+func (p *Prober) recordReadyzOutcome(statusCode int, reason string) {
+	p.lastReadyzOutcome.Store(&readyzOutcome{
+		statusCode: statusCode,
+		reason:     reason,
+	})
+}
+
+// getNodeReadyPodCondition returns the pod condition reflecting the latest Readyz outcome,
+// or nil if Readyz wasn't served yet.
+func (p *Prober) getNodeReadyPodCondition() *corev1.PodCondition {
+	outcome := p.lastReadyzOutcome.Load()
+	if outcome == nil {
+		return nil
+	}
+
+	if outcome.statusCode == http.StatusOK {
+		return &corev1.PodCondition{
+			Type:    naming.NodeReadyPodCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  nodeReadyPodConditionReason,
+			Message: "",
+		}
+	}
+
+	return &corev1.PodCondition{
+		Type:    naming.NodeReadyPodCondition,
+		Status:  corev1.ConditionFalse,
+		Reason:  nodeNotReadyPodConditionReason,
+		Message: strings.TrimSpace(outcome.reason),
+	}
+}
+
+// reportPodCondition patches the local Pod with the condition reflecting the latest Readyz outcome,
+// unless the Pod already has it.
+func (p *Prober) reportPodCondition(ctx context.Context, podClient corev1client.PodInterface) error {
+	condition := p.getNodeReadyPodCondition()
+	if condition == nil {
+		return nil
+	}
+
+	pod, err := p.podLister.Pods(p.namespace).Get(p.podName)
+	if err != nil {
+		return fmt.Errorf("can't get pod %q: %w", p.podRef(), err)
+	}
+
+	condition.LastTransitionTime = metav1.NewTime(p.now())
+	existingCondition := controllerhelpers.GetPodCondition(pod.Status.Conditions, condition.Type)
+	if existingCondition != nil && existingCondition.Status == condition.Status {
+		if existingCondition.Reason == condition.Reason && existingCondition.Message == condition.Message {
+			return nil
+		}
+
+		condition.LastTransitionTime = existingCondition.LastTransitionTime
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"conditions": []*corev1.PodCondition{condition},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("can't marshal pod condition patch: %w", err)
+	}
+
+	_, err = podClient.Patch(ctx, p.podName, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		if isPermissionError(err) {
+			return fmt.Errorf("missing RBAC to patch pod status, make sure the ServiceAccount of the Pod can patch pods/status in its namespace: %w", err)
+		}
+
+		return fmt.Errorf("can't patch pod %q: %w", p.podRef(), err)
+	}
+
+	klog.V(4).InfoS("Reported pod condition", "Pod", p.podRef(), "Condition", condition.Type, "Status", condition.Status)
+
+	return nil
+}
+
+// RunPodConditionReporter periodically reflects the latest Readyz outcome in a condition of the local Pod,
+// so node readiness can be observed on the Pod object. It returns when the context is cancelled.
+// It returns immediately if reporting the pod condition is disabled.
+func (p *Prober) RunPodConditionReporter(ctx context.Context, podClient corev1client.PodInterface) {
+	if !p.options.ReportPodCondition {
+		return
+	}
+
+	interval := p.options.PodConditionReportInterval
+	if interval == 0 {
+		interval = DefaultPodConditionReportInterval
+	}
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		err := p.reportPodCondition(ctx, podClient)
+		if err != nil {
+			klog.ErrorS(err, "Can't report pod condition", "Pod", p.podRef())
+		}
+	}, interval)
+}
@@ -0,0 +1,169 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestProber_reportPodCondition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+
+	newPod := func(conditions ...corev1.PodCondition) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testServiceName,
+				Namespace: testNamespace,
+			},
+			Status: corev1.PodStatus{
+				Conditions: conditions,
+			},
+		}
+	}
+
+	tt := []struct {
+		name              string
+		service           *corev1.Service
+		pod               *corev1.Pod
+		serveReadyz       bool
+		expectedPatch     bool
+		expectedCondition *corev1.PodCondition
+	}{
+		{
+			name:              "nothing is reported before readyz is served",
+			service:           newTestService(nil),
+			pod:               newPod(),
+			serveReadyz:       false,
+			expectedPatch:     false,
+			expectedCondition: nil,
+		},
+		{
+			name:          "ready node is reported",
+			service:       newTestService(nil),
+			pod:           newPod(),
+			serveReadyz:   true,
+			expectedPatch: true,
+			expectedCondition: &corev1.PodCondition{
+				Type:               naming.NodeReadyPodCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             "NodeReady",
+				Message:            "",
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:          "unready node is reported with the reason",
+			service:       newTestService(map[string]string{naming.NodeMaintenanceLabel: ""}),
+			pod:           newPod(),
+			serveReadyz:   true,
+			expectedPatch: true,
+			expectedCondition: &corev1.PodCondition{
+				Type:               naming.NodeReadyPodCondition,
+				Status:             corev1.ConditionFalse,
+				Reason:             "NodeNotReady",
+				Message:            "node is under maintenance",
+				LastTransitionTime: metav1.NewTime(now),
+			},
+		},
+		{
+			name:    "transition time is kept when the status doesn't change",
+			service: newTestService(map[string]string{naming.NodeMaintenanceLabel: ""}),
+			pod: newPod(corev1.PodCondition{
+				Type:               naming.NodeReadyPodCondition,
+				Status:             corev1.ConditionFalse,
+				Reason:             "NodeNotReady",
+				Message:            "node is awaiting required paths' existence",
+				LastTransitionTime: earlier,
+			}),
+			serveReadyz:   true,
+			expectedPatch: true,
+			expectedCondition: &corev1.PodCondition{
+				Type:               naming.NodeReadyPodCondition,
+				Status:             corev1.ConditionFalse,
+				Reason:             "NodeNotReady",
+				Message:            "node is under maintenance",
+				LastTransitionTime: earlier,
+			},
+		},
+		{
+			name:    "pod already having the condition isn't patched",
+			service: newTestService(nil),
+			pod: newPod(corev1.PodCondition{
+				Type:               naming.NodeReadyPodCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             "NodeReady",
+				LastTransitionTime: earlier,
+			}),
+			serveReadyz:   true,
+			expectedPatch: false,
+			expectedCondition: &corev1.PodCondition{
+				Type:               naming.NodeReadyPodCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             "NodeReady",
+				LastTransitionTime: earlier,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, tc.service, newUNScyllaClient(), ProberOptions{
+				ReportPodCondition: true,
+			})
+			p.now = func() time.Time { return now }
+
+			podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			err := podCache.Add(tc.pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.podLister = corev1listers.NewPodLister(podCache)
+
+			kubeClient := fake.NewSimpleClientset(tc.pod)
+
+			if tc.serveReadyz {
+				probe(p.Readyz, naming.ReadinessProbePath)
+			}
+
+			err = p.reportPodCondition(context.Background(), kubeClient.CoreV1().Pods(testNamespace))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			patched := false
+			for _, action := range kubeClient.Actions() {
+				if action.GetVerb() == "patch" && action.GetSubresource() == "status" {
+					patched = true
+				}
+			}
+			if patched != tc.expectedPatch {
+				t.Errorf("expected patch %t, got %t", tc.expectedPatch, patched)
+			}
+
+			pod, err := kubeClient.CoreV1().Pods(testNamespace).Get(context.Background(), testServiceName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotCondition := controllerhelpers.GetPodCondition(pod.Status.Conditions, naming.NodeReadyPodCondition)
+			if !apiequality.Semantic.DeepEqual(gotCondition, tc.expectedCondition) {
+				t.Errorf("expected and got conditions differ: %s", cmp.Diff(tc.expectedCondition, gotCondition))
+			}
+		})
+	}
+}
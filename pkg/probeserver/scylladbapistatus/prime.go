@@ -0,0 +1,53 @@
+package scylladbapistatus
+
+import (
+	"context"
+
+	"k8s.io/klog/v2"
+)
+
+// RunClientPrimer creates a ScyllaDB API client and warms up its connection, keeping it for the first probe
+// that needs a client. It returns once the client is primed or the context is cancelled.
+// It returns immediately if priming the client is disabled.
+func (p *Prober) RunClientPrimer(ctx context.Context) {
+	if !p.options.PrimeClient {
+		return
+	}
+
+	scyllaClient, err := p.newScyllaClient()
+	if err != nil {
+		klog.ErrorS(err, "can't prime scylla client", "Service", p.serviceRef())
+		return
+	}
+
+	// The ping only establishes the connection, ScyllaDB may not be serving its API yet.
+	_, err = scyllaClient.Ping(ctx, localhost)
+	if err != nil {
+		klog.V(4).InfoS("Can't warm up primed scylla client", "Service", p.serviceRef(), "Error", err)
+	}
+
+	if ctx.Err() != nil {
+		scyllaClient.Close()
+		return
+	}
+
+	p.primedClientLock.Lock()
+	defer p.primedClientLock.Unlock()
+
+	p.primedClient = scyllaClient
+}
+
+// getScyllaClient returns the primed client, if it wasn't handed over yet, or a new client.
+// The caller owns the returned client and is responsible for closing it.
+func (p *Prober) getScyllaClient() (ScyllaClient, error) {
+	p.primedClientLock.Lock()
+	scyllaClient := p.primedClient
+	p.primedClient = nil
+	p.primedClientLock.Unlock()
+
+	if scyllaClient != nil {
+		return scyllaClient, nil
+	}
+
+	return p.newScyllaClient()
+}
@@ -0,0 +1,74 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_RunClientPrimer(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                       string
+		primeClient                bool
+		expectedClientCreations    int
+		expectedFirstReadyzStatus  int
+		expectedSecondReadyzStatus int
+	}{
+		{
+			name:                       "client isn't primed when priming is disabled",
+			primeClient:                false,
+			expectedClientCreations:    0,
+			expectedFirstReadyzStatus:  http.StatusInternalServerError,
+			expectedSecondReadyzStatus: http.StatusInternalServerError,
+		},
+		{
+			name:                       "first probe after start uses the primed client",
+			primeClient:                true,
+			expectedClientCreations:    1,
+			expectedFirstReadyzStatus:  http.StatusOK,
+			expectedSecondReadyzStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), nil, ProberOptions{
+				PrimeClient: tc.primeClient,
+			})
+
+			clientCreations := 0
+			p.newScyllaClient = func() (ScyllaClient, error) {
+				clientCreations++
+				return newUNScyllaClient(), nil
+			}
+
+			p.RunClientPrimer(context.Background())
+
+			if clientCreations != tc.expectedClientCreations {
+				t.Errorf("expected %d client creations, got %d", tc.expectedClientCreations, clientCreations)
+			}
+
+			// Probes can only succeed with the primed client from now on.
+			p.newScyllaClient = func() (ScyllaClient, error) {
+				return nil, fmt.Errorf("unexpected ScyllaDB API client creation")
+			}
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedFirstReadyzStatus {
+				t.Errorf("expected first readyz status %d, got %d", tc.expectedFirstReadyzStatus, readyzStatus)
+			}
+
+			readyzStatus = probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedSecondReadyzStatus {
+				t.Errorf("expected second readyz status %d, got %d", tc.expectedSecondReadyzStatus, readyzStatus)
+			}
+		})
+	}
+}
@@ -5,12 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/scylladb/scylla-operator/pkg/controllerhelpers"
 	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	corev1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -18,30 +27,338 @@ import (
 
 const (
 	localhost = "localhost"
+
+	// DefaultProbeTimeout is the timeout used by probe handlers that don't have it configured.
+	DefaultProbeTimeout = 60 * time.Second
+
+	// DefaultSuccessLogLevel is the verbosity at which successful probe outcomes are logged when it isn't configured.
+	DefaultSuccessLogLevel klog.Level = 4
+
+	// DefaultFailureLogLevel is the verbosity at which failed probe outcomes are logged when it isn't configured.
+	DefaultFailureLogLevel klog.Level = 2
 )
 
+// ScyllaClient is the subset of ScyllaDB API the Prober depends on.
+type ScyllaClient interface {
+	Status(ctx context.Context, host string) (scyllaclient.NodeStatusInfoSlice, error)
+	GetLocalHostId(ctx context.Context, host string, retry bool) (string, error)
+	IsNativeTransportEnabled(ctx context.Context, host string) (bool, error)
+	IsStarting(ctx context.Context, host string) (bool, error)
+	GetNodeTokens(ctx context.Context, host, endpoint string) ([]string, error)
+	Ping(ctx context.Context, host string) (time.Duration, error)
+	Keyspaces(ctx context.Context) ([]string, error)
+	ListenAddress(ctx context.Context, host string) (string, error)
+	ScyllaVersion(ctx context.Context) (string, error)
+	EnabledFeatures(ctx context.Context, host string) ([]string, error)
+	Drain(ctx context.Context, host string) error
+	GetSnitchDatacenter(ctx context.Context, host string) (string, error)
+	GetSnitchRack(ctx context.Context, host string) (string, error)
+	Close()
+}
+
+// ReadinessCheck is a custom readiness criterion evaluated against the local ScyllaDB node.
+// It returns whether the node is ready and, if it isn't, the reason why.
+type ReadinessCheck func(ctx context.Context, client ScyllaClient) (bool, string, error)
+
+func newLocalhostScyllaClient() (ScyllaClient, error) {
+	client, err := controllerhelpers.NewScyllaClientForLocalhost()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// ProberOptions holds the optional behaviour of a Prober. The zero value keeps the default behaviour.
+type ProberOptions struct {
+	// SkipNativeTransportCheck makes a UN node ready regardless of whether its native transport is enabled.
+	// It is meant for deployments that use Alternator exclusively.
+	SkipNativeTransportCheck bool
+
+	// AlternatorPort replaces the skipped native transport check with a TCP check of the local Alternator port.
+	// It only takes effect with SkipNativeTransportCheck. Zero disables the check.
+	AlternatorPort int
+
+	// RequireTokens makes a UN node ready only if it owns at least one token.
+	// It is off by default to support zero-token nodes.
+	RequireTokens bool
+
+	// CommitlogReplayCheck makes a node ready only once it finished starting up, which includes replaying its
+	// commitlog after a restart, so traffic isn't routed to a node that can't serve it consistently yet.
+	CommitlogReplayCheck bool
+
+	// MinUNNodes makes a node ready only once at least this many nodes are UN in its view of the cluster,
+	// so clients aren't routed to a cluster that is still forming. The initial seed, i.e. the first node
+	// of the first rack, is exempt, as it has to bootstrap alone. Zero disables the check.
+	MinUNNodes int
+
+	// ListenAddressCheck makes a node ready only if its listen address matches PodIP,
+	// to catch nodes listening on a wrong interface, which causes gossip issues.
+	ListenAddressCheck bool
+
+	// PodIP is the IP of the local Pod, as provided by the downward API, used by ListenAddressCheck.
+	PodIP string
+
+	// PlacementCheck makes a node ready only if the datacenter and rack it has in ScyllaDB topology match the ones
+	// of its Pod, to catch nodes that joined with a topology that doesn't match their Kubernetes placement.
+	PlacementCheck bool
+
+	// WarmupHold keeps a node unready for this long after it becomes UN with native transport enabled,
+	// so it can warm its caches before receiving traffic. The hold applies again whenever the node stops being UN
+	// or serving clients. Zero disables the hold.
+	WarmupHold time.Duration
+
+	// GossipSettleWindow keeps a node unready until the endpoint states in its view of the cluster stay unchanged
+	// for this long, so a rejoining node doesn't receive traffic while its view is still converging.
+	// Zero disables the check.
+	GossipSettleWindow time.Duration
+
+	// ResumeWarmupHold keeps a node of a cluster that is being resumed from pause unready for this long after
+	// it is first observed UN during the resume. Zero means DefaultResumeWarmupHold.
+	ResumeWarmupHold time.Duration
+
+	// ResumeGossipSettleWindow keeps a node of a cluster that is being resumed from pause unready until the endpoint
+	// states in its view of the cluster stay unchanged for this long. Zero means DefaultResumeGossipSettleWindow.
+	ResumeGossipSettleWindow time.Duration
+
+	// CQLAuthCheck makes a node ready only if it accepts an authenticated CQL session on the local CQL port,
+	// which isn't the case until authentication data is available on clusters with authentication enabled.
+	CQLAuthCheck bool
+
+	// CQLQueryCheck makes a node ready only if it executes a trivial query over CQL on the local CQL port,
+	// to catch nodes that report being healthy but can't serve queries. The session is authenticated
+	// with the credentials in CQLCredentialsPath, if it's set.
+	CQLQueryCheck bool
+
+	// CQLCredentialsPath is a directory with a mounted basic-auth Secret holding credentials used by CQLAuthCheck
+	// and CQLQueryCheck.
+	CQLCredentialsPath string
+
+	// RequiredKeyspace makes a node ready only once the named keyspace exists.
+	// It is meant for applications that can't start before their keyspace is created. Empty disables the check.
+	RequiredKeyspace string
+
+	// ExpectedVersion makes a node ready only if it runs this ScyllaDB version, so orchestration of rolling upgrades
+	// can wait for nodes to converge on the target version. Empty disables the check.
+	ExpectedVersion string
+
+	// RequiredFeatures makes a node ready only once all of the listed ScyllaDB features are enabled in the cluster,
+	// so routing waits for feature convergence during upgrades that enable new features. Empty disables the check.
+	RequiredFeatures []string
+
+	// ConfigReloadStatusURL is the URL of the sidecar endpoint reporting whether a config reload is in progress.
+	// While it is, the node is unready, so traffic isn't routed to it mid-reload. Empty disables the check.
+	ConfigReloadStatusURL string
+
+	// MetricsCheck makes probes verify that the local ScyllaDB Prometheus metrics endpoint responds,
+	// to catch monitoring gaps early. The endpoint being down is only reported as a warning and doesn't fail probes.
+	MetricsCheck bool
+
+	// MetricsPort is the port of the metrics endpoint checked by MetricsCheck and read by DrainComplete. Zero means DefaultMetricsPort.
+	MetricsPort int
+
+	// AwaitPathsManifestDir is a directory of manifest files, each listing additional paths to await existence of,
+	// one per line. Empty lines and lines starting with '#' are ignored, as are files starting with '.'.
+	// The directory is re-read on every probe so the set of awaited paths can change at runtime.
+	// A missing directory contributes no paths. Empty disables the manifest.
+	AwaitPathsManifestDir string
+
+	// MaxBootstrapDuration bounds how long liveness probes succeed while awaited paths don't exist, counting from
+	// the start of the Prober, so a bootstrap that never finishes eventually gets the container restarted.
+	// Zero keeps liveness probes succeeding indefinitely.
+	MaxBootstrapDuration time.Duration
+
+	// ReadyzTimeout bounds the evaluation of readiness probes. Zero means DefaultProbeTimeout.
+	ReadyzTimeout time.Duration
+
+	// HealthzTimeout bounds the evaluation of liveness probes. Zero means DefaultProbeTimeout.
+	HealthzTimeout time.Duration
+
+	// ReadinessChecks are run in order after all built-in readiness checks pass.
+	// The node is only ready when all of them pass.
+	ReadinessChecks []ReadinessCheck
+
+	// ReadyzCacheRefreshInterval enables caching the readiness checks that contact ScyllaDB API, refreshing them
+	// in the background at this interval, as long as Run is called. Zero disables the cache.
+	ReadyzCacheRefreshInterval time.Duration
+
+	// ReadyzCacheMaxStaleness is the maximum age of a cached result that readiness probes still use.
+	// Older results are replaced by a live call. It only takes effect with ReadyzCacheRefreshInterval.
+	ReadyzCacheMaxStaleness time.Duration
+
+	// ReadyzFallbackFreshness is the maximum age of the last definitive readiness outcome of the checks contacting
+	// ScyllaDB API that is returned when a call to ScyllaDB API fails, so brief API outages don't flip readiness.
+	// Failures caused by the local configuration are never replaced. Zero disables the fallback.
+	ReadyzFallbackFreshness time.Duration
+
+	// ExtraMaintenanceLabels are keys of labels that mark the node as under maintenance when present on its service,
+	// in addition to the built-in maintenance label, which is always checked.
+	ExtraMaintenanceLabels []string
+
+	// MaintenanceDrainProbeCount is the number of consecutive unready responses that readiness probes have to serve
+	// while the node is under maintenance before Drainz reports the node as drained.
+	// It lets load balancers that require several failed probes remove the endpoint before maintenance proceeds.
+	MaintenanceDrainProbeCount int
+
+	// SuccessLogLevel is the verbosity at which successful probe outcomes are logged.
+	// A level above the configured verbosity silences them. Nil means DefaultSuccessLogLevel.
+	SuccessLogLevel *klog.Level
+
+	// FailureLogLevel is the verbosity at which failed probe outcomes are logged. Unexpected errors are always logged.
+	// Nil means DefaultFailureLogLevel.
+	FailureLogLevel *klog.Level
+
+	// DrainAuthToken authorizes callers of the Drain and Nodes handlers that aren't on the loopback interface
+	// when it is sent as a bearer token. Empty restricts them to loopback callers.
+	DrainAuthToken string
+
+	// ProbeMethods are the HTTP methods accepted by probe handlers. Requests using other methods are rejected with 405.
+	// Empty means DefaultProbeMethods.
+	ProbeMethods []string
+
+	// ReportPodCondition makes RunPodConditionReporter reflect the latest Readyz outcome
+	// in the NodeReady condition of the local Pod.
+	ReportPodCondition bool
+
+	// PodConditionReportInterval is the interval of reporting the pod condition.
+	// Zero means DefaultPodConditionReportInterval.
+	PodConditionReportInterval time.Duration
+
+	// PrimeClient makes RunClientPrimer create a ScyllaDB API client and warm it up at startup,
+	// so the first probe uses it instead of paying for the client creation within its timeout.
+	PrimeClient bool
+}
+
 type Prober struct {
 	namespace     string
 	serviceName   string
 	serviceLister corev1.ServiceLister
-	timeout       time.Duration
+	podName       string
+	podLister     corev1.PodLister
+
+	readyzTimeout  time.Duration
+	healthzTimeout time.Duration
+
+	successLogLevel klog.Level
+	failureLogLevel klog.Level
+
+	probeMethods []string
+
+	awaitPaths []AwaitPath
+	options    ProberOptions
+
+	newScyllaClient func() (ScyllaClient, error)
+	cqlLogin        func(ctx context.Context, username, password string) error
+	cqlQuery        func(ctx context.Context, username, password, query string) error
+	httpClient      *http.Client
+	now             func() time.Time
+
+	// apiAddress is the address of the local ScyllaDB API, which healthz connects to before using the API.
+	apiAddress string
+
+	// startTime is the time when the Prober was created.
+	startTime time.Time
+
+	readyzCacheLock sync.Mutex
+	readyzCache     *readyzCacheEntry
+
+	// lastDefinitiveReadyz is the last outcome of the readiness checks contacting ScyllaDB API
+	// that reflected the state of the node.
+	lastDefinitiveReadyzLock sync.Mutex
+	lastDefinitiveReadyz     *readyzCacheEntry
+
+	// firstUNTime is the time since when the node has been observed UN with native transport enabled.
+	// It's cleared whenever the node is observed otherwise.
+	firstUNTimeLock sync.Mutex
+	firstUNTime     time.Time
+
+	// primedClient is the client prepared by RunClientPrimer, which is handed over to the first probe that needs one.
+	primedClientLock sync.Mutex
+	primedClient     ScyllaClient
+
+	// gossipStateFingerprint identifies the endpoint states last observed by the local node,
+	// which haven't changed since gossipStableSince.
+	gossipStateLock        sync.Mutex
+	gossipStateFingerprint string
+	gossipStableSince      time.Time
+
+	// resumeFirstUNTime is the time when the node was first observed UN while resuming from pause.
+	// resumeComplete is set once the node passes the resume checks and is cleared when the node is paused again.
+	resumeLock        sync.Mutex
+	resumeFirstUNTime time.Time
+	resumeComplete    bool
 
-	awaitPaths []string
+	// unNodes is the number of UN nodes in the cluster last observed by the local node.
+	unNodes atomic.Pointer[int]
+
+	// maintenanceUnreadyProbes counts the consecutive unready responses served due to maintenance.
+	maintenanceUnreadyProbes atomic.Int64
+
+	// lastReadyzOutcome is the outcome of the last readiness probe served by Readyz.
+	lastReadyzOutcome atomic.Pointer[readyzOutcome]
 }
 
 func NewProber(
 	namespace string,
 	serviceName string,
 	serviceLister corev1.ServiceLister,
-	awaitPaths []string,
+	podName string,
+	podLister corev1.PodLister,
+	awaitPaths []AwaitPath,
+	options ProberOptions,
 ) *Prober {
+	readyzTimeout := options.ReadyzTimeout
+	if readyzTimeout == 0 {
+		readyzTimeout = DefaultProbeTimeout
+	}
+
+	healthzTimeout := options.HealthzTimeout
+	if healthzTimeout == 0 {
+		healthzTimeout = DefaultProbeTimeout
+	}
+
+	successLogLevel := DefaultSuccessLogLevel
+	if options.SuccessLogLevel != nil {
+		successLogLevel = *options.SuccessLogLevel
+	}
+
+	failureLogLevel := DefaultFailureLogLevel
+	if options.FailureLogLevel != nil {
+		failureLogLevel = *options.FailureLogLevel
+	}
+
+	probeMethods := options.ProbeMethods
+	if len(probeMethods) == 0 {
+		probeMethods = DefaultProbeMethods
+	}
+
 	return &Prober{
 		namespace:     namespace,
 		serviceName:   serviceName,
 		serviceLister: serviceLister,
-		timeout:       60 * time.Second,
+		podName:       podName,
+		podLister:     podLister,
+
+		readyzTimeout:  readyzTimeout,
+		healthzTimeout: healthzTimeout,
+
+		successLogLevel: successLogLevel,
+		failureLogLevel: failureLogLevel,
+
+		probeMethods: probeMethods,
 
 		awaitPaths: awaitPaths,
+		options:    options,
+
+		newScyllaClient: newLocalhostScyllaClient,
+		cqlLogin:        localCQLLogin,
+		cqlQuery:        localCQLQuery,
+		httpClient:      http.DefaultClient,
+		now:             time.Now,
+
+		apiAddress: net.JoinHostPort(localhost, strconv.Itoa(naming.ScyllaAPIPort)),
+
+		startTime: time.Now(),
 	}
 }
 
@@ -49,153 +366,651 @@ func (p *Prober) serviceRef() string {
 	return fmt.Sprintf("%s/%s", p.namespace, p.serviceName)
 }
 
-func (p *Prober) isNodeUnderMaintenance() (bool, error) {
+func (p *Prober) podRef() string {
+	return fmt.Sprintf("%s/%s", p.namespace, p.podName)
+}
+
+func (p *Prober) logSuccess(msg string, keysAndValues ...any) {
+	klog.V(p.successLogLevel).InfoS(msg, keysAndValues...)
+}
+
+func (p *Prober) logFailure(msg string, keysAndValues ...any) {
+	klog.V(p.failureLogLevel).InfoS(msg, keysAndValues...)
+}
+
+// serviceHasAnyLabel returns true if the service has at least one of the labels.
+func (p *Prober) serviceHasAnyLabel(labels ...string) (bool, error) {
 	svc, err := p.serviceLister.Services(p.namespace).Get(p.serviceName)
 	if err != nil {
 		return false, err
 	}
 
-	_, hasLabel := svc.Labels[naming.NodeMaintenanceLabel]
-	return hasLabel, nil
+	for _, label := range labels {
+		_, hasLabel := svc.Labels[label]
+		if hasLabel {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
-func (p *Prober) awaitPathsExist() (bool, error) {
-	var err error
-	var errs []error
+// isPermissionError returns true if the error is caused by insufficient permissions of the prober.
+func isPermissionError(err error) bool {
+	return apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err)
+}
 
-	ready := true
-	for _, path := range p.awaitPaths {
-		_, err = os.Stat(path)
+// handleServiceLabelLookupError logs the failed lookup of the service label and returns the reason to respond with.
+// Permission errors are reported distinctly, as they are caused by missing RBAC rather than by the node.
+func (p *Prober) handleServiceLabelLookupError(probeName string, labelName string, err error) string {
+	if isPermissionError(err) {
+		klog.ErrorS(err, fmt.Sprintf("%s: missing RBAC to read services, make sure the ServiceAccount of the Pod can get, list and watch Services in its namespace", probeName), "Service", p.serviceRef())
+		return fmt.Sprintf("missing RBAC to read services: %v", err)
+	}
+
+	klog.ErrorS(err, fmt.Sprintf("%s: can't look up service %s label", probeName, labelName), "Service", p.serviceRef())
+	return fmt.Sprintf("can't look up service %s label: %v", labelName, err)
+}
+
+func (p *Prober) isNodeUnderMaintenance() (bool, error) {
+	return p.serviceHasAnyLabel(append([]string{naming.NodeMaintenanceLabel}, p.options.ExtraMaintenanceLabels...)...)
+}
+
+func (p *Prober) isNodePaused() (bool, error) {
+	return p.serviceHasAnyLabel(naming.NodePausedLabel)
+}
+
+// AwaitPath is a path that has to exist before the node is considered ready.
+type AwaitPath struct {
+	Path string `json:"path"`
+
+	// MinSize is the minimum size in bytes the path has to have to be considered present.
+	// It lets markers that are created empty and populated later be awaited. Zero only requires existence.
+	MinSize int64 `json:"minSize,omitempty"`
+}
+
+func (ap AwaitPath) String() string {
+	if ap.MinSize == 0 {
+		return ap.Path
+	}
+
+	return fmt.Sprintf("%s (min size %d)", ap.Path, ap.MinSize)
+}
+
+func readAwaitPathsManifestDir(dir string) ([]AwaitPath, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("can't read await paths manifest directory %q: %w", dir, err)
+	}
+
+	var paths []AwaitPath
+	for _, entry := range entries {
+		// Skip hidden entries, like the ones created by Kubernetes for projected volumes.
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		manifestPath := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(manifestPath)
 		if err != nil {
-			if !errors.Is(err, fs.ErrNotExist) {
-				errs = append(errs, fmt.Errorf("can't stat path %q: %w", path, err))
+			return nil, fmt.Errorf("can't read await paths manifest %q: %w", manifestPath, err)
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
 				continue
 			}
 
+			paths = append(paths, AwaitPath{Path: line})
+		}
+	}
+
+	return paths, nil
+}
+
+// getAwaitPaths returns the explicit await paths extended with the ones currently listed in the manifest directory.
+func (p *Prober) getAwaitPaths() ([]AwaitPath, error) {
+	if len(p.options.AwaitPathsManifestDir) == 0 {
+		return p.awaitPaths, nil
+	}
+
+	manifestPaths, err := readAwaitPathsManifestDir(p.options.AwaitPathsManifestDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(slices.Clone(p.awaitPaths), manifestPaths...), nil
+}
+
+// awaitPathResult is the state of a single await path.
+type awaitPathResult struct {
+	AwaitPath
+
+	// Present is true when the path exists and has at least the minimum size.
+	Present bool `json:"present"`
+}
+
+// getAwaitPathResults checks each await path. Paths that can't be checked are reported as missing
+// and their errors are aggregated.
+func (p *Prober) getAwaitPathResults() ([]awaitPathResult, error) {
+	awaitPaths, err := p.getAwaitPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+
+	results := make([]awaitPathResult, 0, len(awaitPaths))
+	for _, ap := range awaitPaths {
+		fi, err := os.Stat(ap.Path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("can't stat path %q: %w", ap.Path, err))
+		}
+
+		results = append(results, awaitPathResult{
+			AwaitPath: ap,
+			Present:   err == nil && fi.Size() >= ap.MinSize,
+		})
+	}
+
+	return results, utilerrors.NewAggregate(errs)
+}
+
+func (p *Prober) awaitPathsExist() ([]AwaitPath, bool, error) {
+	results, err := p.getAwaitPathResults()
+	if results == nil && err != nil {
+		return nil, false, err
+	}
+
+	awaitPaths := make([]AwaitPath, 0, len(results))
+	ready := true
+	for _, r := range results {
+		awaitPaths = append(awaitPaths, r.AwaitPath)
+		if !r.Present {
 			ready = false
 		}
 	}
 
-	err = utilerrors.NewAggregate(errs)
 	if err != nil {
-		return false, err
+		return awaitPaths, false, err
 	}
 
-	return ready, nil
+	return awaitPaths, ready, nil
 }
 
-func (p *Prober) Readyz(w http.ResponseWriter, req *http.Request) {
-	ctx, ctxCancel := context.WithTimeout(req.Context(), p.timeout)
-	defer ctxCancel()
+// readyz evaluates the readiness of the local ScyllaDB node and returns the HTTP status code to respond with,
+// together with a human-readable reason.
+func (p *Prober) readyz(ctx context.Context) (int, string) {
+	statusCode, reason, paused := p.pausedReadyz()
+	if paused {
+		p.resetResume()
+	}
+	if statusCode != http.StatusOK {
+		return statusCode, reason
+	}
 
-	awaitPathsExist, err := p.awaitPathsExist()
+	statusCode, reason = p.awaitPathsReadyz()
+	if statusCode != http.StatusOK {
+		return statusCode, reason
+	}
+
+	statusCode, reason, underMaintenance := p.maintenanceReadyz()
+	if underMaintenance {
+		p.maintenanceUnreadyProbes.Add(1)
+	}
+	if statusCode != http.StatusOK {
+		return statusCode, reason
+	}
+	p.maintenanceUnreadyProbes.Store(0)
+
+	if len(p.options.ConfigReloadStatusURL) != 0 {
+		// Reloads are short-lived, so their status is never cached.
+		statusCode, reason = p.configReloadReadyz(ctx)
+		if statusCode != http.StatusOK {
+			return statusCode, reason
+		}
+	}
+
+	statusCode, reason = p.cachedAPIReadyz(ctx)
+	if statusCode == http.StatusOK && p.options.MetricsCheck {
+		warning := p.metricsWarning(ctx, "readyz probe")
+		if len(warning) != 0 {
+			reason = reason + "\n" + warning
+		}
+	}
+
+	return statusCode, reason
+}
+
+// pausedReadyz determines readiness based on whether the node is paused, which is also returned so that callers
+// can track it. It has to pass before any check that contacts ScyllaDB API runs, as paused nodes are removed
+// from service without generating any load on ScyllaDB API.
+func (p *Prober) pausedReadyz() (int, string, bool) {
+	paused, err := p.isNodePaused()
+	if err != nil {
+		return http.StatusServiceUnavailable, p.handleServiceLabelLookupError("readyz probe", "paused", err), false
+	}
+
+	if paused {
+		p.logFailure("readyz probe: node is paused", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, "node is paused", true
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// awaitPathsReadyz determines readiness based on the existence of the awaited paths.
+func (p *Prober) awaitPathsReadyz() (int, string) {
+	awaitPaths, awaitPathsExist, err := p.awaitPathsExist()
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
 		klog.ErrorS(err, "readyz probe: can't check required paths' existence")
-		return
+		return http.StatusInternalServerError, fmt.Sprintf("can't check required paths' existence: %v", err)
 	}
 
 	if !awaitPathsExist {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		klog.V(2).InfoS("readyz probe: node is awaiting required paths' existence", "AwaitPaths", p.awaitPaths)
-		return
+		p.logFailure("readyz probe: node is awaiting required paths' existence", "AwaitPaths", awaitPaths)
+		return http.StatusServiceUnavailable, "node is awaiting required paths' existence"
 	}
 
+	return http.StatusOK, "ok"
+}
+
+// maintenanceReadyz determines readiness based on whether the node is under maintenance,
+// which is also returned so that callers can track it.
+func (p *Prober) maintenanceReadyz() (int, string, bool) {
 	underMaintenance, err := p.isNodeUnderMaintenance()
 	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		klog.ErrorS(err, "readyz probe: can't look up service maintenance label", "Service", p.serviceRef())
-		return
+		return http.StatusServiceUnavailable, p.handleServiceLabelLookupError("readyz probe", "maintenance", err), false
 	}
 
 	if underMaintenance {
 		// During maintenance Pod shouldn't be declare to be ready.
-		w.WriteHeader(http.StatusServiceUnavailable)
-		klog.V(2).InfoS("readyz probe: node is under maintenance", "Service", p.serviceRef())
-		return
+		p.logFailure("readyz probe: node is under maintenance", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, "node is under maintenance", true
 	}
 
-	scyllaClient, err := controllerhelpers.NewScyllaClientForLocalhost()
+	return http.StatusOK, "ok", false
+}
+
+// apiReadyz evaluates the readiness checks that require contacting ScyllaDB API.
+// It also returns whether the outcome is transient, i.e. whether it's caused by a failed call to ScyllaDB API
+// rather than by the state of the node or the local configuration.
+func (p *Prober) apiReadyz(ctx context.Context) (int, string, bool) {
+	scyllaClient, err := p.getScyllaClient()
 	if err != nil {
 		klog.ErrorS(err, "readyz probe: can't get scylla client", "Service", p.serviceRef())
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return http.StatusInternalServerError, fmt.Sprintf("can't get scylla client: %v", err), false
 	}
 	defer scyllaClient.Close()
 
-	// Contact Scylla to learn about the status of the member
-	nodeStatuses, err := scyllaClient.Status(ctx, localhost)
+	if p.options.CommitlogReplayCheck {
+		statusCode, reason, transient := p.commitlogReplayReadyz(ctx, scyllaClient)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	statusCode, reason, transient := p.nodeReadyz(ctx, scyllaClient)
+	if statusCode != http.StatusOK {
+		if !transient {
+			p.resetFirstUNTime()
+		}
+		return statusCode, reason, transient
+	}
+
+	if p.options.MinUNNodes > 0 {
+		statusCode, reason = p.minUNNodesReadyz()
+		if statusCode != http.StatusOK {
+			return statusCode, reason, false
+		}
+	}
+
+	if p.options.ListenAddressCheck {
+		statusCode, reason, transient = p.listenAddressReadyz(ctx, scyllaClient)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	if p.options.PlacementCheck {
+		statusCode, reason, transient = p.placementReadyz(ctx, scyllaClient)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	statusCode, reason, transient = p.resumeReadyz(ctx, scyllaClient)
+	if statusCode != http.StatusOK {
+		return statusCode, reason, transient
+	}
+
+	if p.options.GossipSettleWindow > 0 {
+		statusCode, reason, transient = p.gossipSettleReadyz(ctx, scyllaClient, p.options.GossipSettleWindow)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	statusCode, reason = p.warmupReadyz()
+	if statusCode != http.StatusOK {
+		return statusCode, reason, false
+	}
+
+	if p.options.CQLAuthCheck {
+		statusCode, reason = p.cqlAuthReadyz(ctx)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, false
+		}
+	}
+
+	if p.options.CQLQueryCheck {
+		statusCode, reason = p.cqlQueryReadyz(ctx)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, false
+		}
+	}
+
+	if len(p.options.RequiredKeyspace) != 0 {
+		statusCode, reason, transient = p.requiredKeyspaceReadyz(ctx, scyllaClient)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	if len(p.options.ExpectedVersion) != 0 {
+		statusCode, reason, transient = p.versionReadyz(ctx, scyllaClient)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	if len(p.options.RequiredFeatures) != 0 {
+		statusCode, reason, transient = p.requiredFeaturesReadyz(ctx, scyllaClient)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	for i, check := range p.options.ReadinessChecks {
+		ready, reason, err := check(ctx, scyllaClient)
+		if err != nil {
+			klog.ErrorS(err, "readyz probe: can't run readiness check", "Service", p.serviceRef(), "Check", i)
+			return http.StatusInternalServerError, fmt.Sprintf("can't run readiness check %d: %v", i, err), false
+		}
+
+		if !ready {
+			p.logFailure("readyz probe: readiness check failed", "Service", p.serviceRef(), "Check", i, "Reason", reason)
+			return http.StatusServiceUnavailable, reason, false
+		}
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// nodeReadyz runs the built-in readiness checks of the local ScyllaDB node one after another.
+// The checks are the same units that can be invoked individually, so their results never disagree.
+func (p *Prober) nodeReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	statusCode, reason, transient, view := p.unReadyz(ctx, scyllaClient)
+	if view != nil {
+		p.recordUNNodes(view.nodeStatuses)
+	}
+	if statusCode != http.StatusOK {
+		return statusCode, reason, transient
+	}
+
+	if p.options.RequireTokens {
+		statusCode, reason, transient = p.tokensReadyz(ctx, scyllaClient, view.local.Addr)
+		if statusCode != http.StatusOK {
+			return statusCode, reason, transient
+		}
+	}
+
+	if p.options.SkipNativeTransportCheck {
+		statusCode, reason = p.alternatorReadyz(ctx)
+		return statusCode, reason, false
+	}
+
+	return p.transportReadyz(ctx, scyllaClient)
+}
+
+// listenAddressReadyz determines readiness based on whether the node listens on the IP of the local Pod.
+// Unspecified listen addresses match any IP, as the node listens on all interfaces.
+func (p *Prober) listenAddressReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	listenAddress, err := scyllaClient.ListenAddress(ctx, localhost)
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't get listen address", "Service", p.serviceRef())
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get listen address: %v", err), true
+	}
+
+	listenIP, podIP := net.ParseIP(listenAddress), net.ParseIP(p.options.PodIP)
+	if listenIP == nil || podIP == nil || (!listenIP.IsUnspecified() && !listenIP.Equal(podIP)) {
+		p.logFailure("readyz probe: listen address doesn't match Pod IP", "Service", p.serviceRef(), "ListenAddress", listenAddress, "PodIP", p.options.PodIP)
+		return http.StatusServiceUnavailable, fmt.Sprintf("listen address %q doesn't match Pod IP %q", listenAddress, p.options.PodIP), false
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// requiredKeyspaceReadyz determines readiness based on the existence of the required keyspace.
+// Failing to list keyspaces makes the node unready, as it is expected while the node is starting up.
+func (p *Prober) requiredKeyspaceReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	keyspaces, err := scyllaClient.Keyspaces(ctx)
+	if err != nil {
+		p.logFailure("readyz probe: can't list keyspaces", "Service", p.serviceRef(), "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't list keyspaces: %v", err), true
+	}
+
+	if !slices.Contains(keyspaces, p.options.RequiredKeyspace) {
+		p.logFailure("readyz probe: required keyspace doesn't exist", "Service", p.serviceRef(), "Keyspace", p.options.RequiredKeyspace)
+		return http.StatusServiceUnavailable, fmt.Sprintf("required keyspace %q doesn't exist", p.options.RequiredKeyspace), false
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// versionReadyz determines readiness based on whether the node runs the expected version.
+func (p *Prober) versionReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	version, err := scyllaClient.ScyllaVersion(ctx)
 	if err != nil {
-		klog.ErrorS(err, "readyz probe: can't get scylla node status", "Service", p.serviceRef())
-		w.WriteHeader(http.StatusInternalServerError)
+		p.logFailure("readyz probe: can't get version", "Service", p.serviceRef(), "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get version: %v", err), true
+	}
+
+	if !isVersionMatching(version, p.options.ExpectedVersion) {
+		p.logFailure("readyz probe: node doesn't run the expected version", "Service", p.serviceRef(), "Version", version, "ExpectedVersion", p.options.ExpectedVersion)
+		return http.StatusServiceUnavailable, fmt.Sprintf("node runs version %q instead of the expected %q", version, p.options.ExpectedVersion), false
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// requiredFeaturesReadyz determines readiness based on whether the required features are enabled in the cluster.
+func (p *Prober) requiredFeaturesReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	enabledFeatures, err := scyllaClient.EnabledFeatures(ctx, localhost)
+	if err != nil {
+		p.logFailure("readyz probe: can't get enabled features", "Service", p.serviceRef(), "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't get enabled features: %v", err), true
+	}
+
+	var missingFeatures []string
+	for _, f := range p.options.RequiredFeatures {
+		if !slices.Contains(enabledFeatures, f) {
+			missingFeatures = append(missingFeatures, f)
+		}
+	}
+
+	if len(missingFeatures) != 0 {
+		p.logFailure("readyz probe: required features aren't enabled", "Service", p.serviceRef(), "Features", missingFeatures)
+		return http.StatusServiceUnavailable, fmt.Sprintf("required feature(s) %s aren't enabled yet", strings.Join(missingFeatures, ", ")), false
+	}
+
+	return http.StatusOK, "ok", false
+}
+
+// isVersionMatching returns true if the version reported by ScyllaDB matches the expected version.
+// ScyllaDB reports release versions with a build suffix, e.g. "6.2.0-0.20241010.abcdef",
+// which matches the expected version "6.2.0" as used in image tags.
+func isVersionMatching(version, expected string) bool {
+	return version == expected || strings.HasPrefix(version, expected+"-")
+}
+
+// alternatorReadyz determines readiness of a UN node that doesn't rely on native transport.
+func (p *Prober) alternatorReadyz(ctx context.Context) (int, string) {
+	if p.options.AlternatorPort == 0 {
+		return http.StatusOK, "ok"
+	}
+
+	address := net.JoinHostPort(localhost, strconv.Itoa(p.options.AlternatorPort))
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		p.logFailure("readyz probe: can't connect to alternator port", "Service", p.serviceRef(), "Address", address, "Error", err)
+		return http.StatusServiceUnavailable, fmt.Sprintf("can't connect to alternator port: %v", err)
+	}
+
+	err = conn.Close()
+	if err != nil {
+		klog.ErrorS(err, "readyz probe: can't close alternator connection", "Address", address)
+	}
+
+	return http.StatusOK, "ok"
+}
+
+// writeProbeResponse writes the probe status code. The reason is written to the body
+// only when the request has the "verbose" query parameter and isn't a HEAD request.
+func writeProbeResponse(w http.ResponseWriter, req *http.Request, statusCode int, reason string) {
+	w.WriteHeader(statusCode)
+
+	if req.Method == http.MethodHead || !req.URL.Query().Has("verbose") {
 		return
 	}
 
-	hostID, err := scyllaClient.GetLocalHostId(ctx, localhost, false)
+	_, err := fmt.Fprintln(w, reason)
 	if err != nil {
-		klog.ErrorS(err, "readyz probe: can't get host id")
-		w.WriteHeader(http.StatusInternalServerError)
+		klog.ErrorS(err, "can't write probe response body")
+	}
+}
+
+func (p *Prober) Readyz(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
 		return
 	}
 
-	for _, s := range nodeStatuses {
-		klog.V(4).InfoS("readyz probe: node state", "Node", s.Addr, "Status", s.Status, "State", s.State)
+	ctx, ctxCancel := context.WithTimeout(req.Context(), p.readyzTimeout)
+	defer ctxCancel()
 
-		if s.HostID == hostID && s.IsUN() {
-			transportEnabled, err := scyllaClient.IsNativeTransportEnabled(ctx, localhost)
-			if err != nil {
-				w.WriteHeader(http.StatusServiceUnavailable)
-				klog.ErrorS(err, "readyz probe: can't get scylla native transport", "Service", p.serviceRef(), "Node", s.Addr)
-				return
-			}
+	if req.URL.Query().Has(readinessCheckQueryParameter) {
+		// Single checks are meant for monitoring, so they don't affect the state kept by the readiness probes.
+		statusCode, reason := p.namedReadyz(ctx, req.URL.Query().Get(readinessCheckQueryParameter))
+		writeProbeResponse(w, req, statusCode, reason)
+		return
+	}
 
-			klog.V(4).InfoS("readyz probe: node state", "Node", s.Addr, "NativeTransportEnabled", transportEnabled)
-			if transportEnabled {
-				w.WriteHeader(http.StatusOK)
-				return
-			}
-		}
+	statusCode, reason := p.readyz(ctx)
+	if statusCode == http.StatusOK {
+		p.logSuccess("readyz probe: node is ready", "Service", p.serviceRef())
 	}
+	p.recordReadyzOutcome(statusCode, reason)
 
-	klog.V(2).InfoS("readyz probe: node is not ready", "Service", p.serviceRef())
-	w.WriteHeader(http.StatusServiceUnavailable)
+	details := p.clusterFormationDetails()
+	if len(details) != 0 {
+		reason = reason + "\n" + details
+	}
+	writeProbeResponse(w, req, statusCode, reason)
 }
 
-func (p *Prober) Healthz(w http.ResponseWriter, req *http.Request) {
-	ctx, ctxCancel := context.WithTimeout(req.Context(), p.timeout)
+// PodReadyz extends Readyz with the readiness of the auxiliary containers of the local Pod,
+// so the aggregated result matches the definition of a prewarmed node.
+func (p *Prober) PodReadyz(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
+		return
+	}
+
+	ctx, ctxCancel := context.WithTimeout(req.Context(), p.readyzTimeout)
 	defer ctxCancel()
 
-	awaitPathsExist, err := p.awaitPathsExist()
+	statusCode, reason := p.readyz(ctx)
+	if statusCode != http.StatusOK {
+		writeProbeResponse(w, req, statusCode, reason)
+		return
+	}
+
+	pod, err := p.podLister.Pods(p.namespace).Get(p.podName)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		klog.ErrorS(err, "halthz probe: can't check required paths' existence")
+		klog.ErrorS(err, "pod readyz probe: can't get pod", "Pod", p.podRef())
+		writeProbeResponse(w, req, http.StatusServiceUnavailable, fmt.Sprintf("can't get pod: %v", err))
 		return
 	}
 
-	if !awaitPathsExist {
-		w.WriteHeader(http.StatusOK)
-		klog.V(2).InfoS("healthz probe: node is awaiting required paths' existence", "AwaitPaths", p.awaitPaths)
+	if !controllerhelpers.IsScyllaDBPodPrewarmed(pod) {
+		p.logFailure("pod readyz probe: pod containers are not ready", "Pod", p.podRef())
+		writeProbeResponse(w, req, http.StatusServiceUnavailable, "pod containers are not ready")
 		return
 	}
 
+	p.logSuccess("pod readyz probe: pod is ready", "Pod", p.podRef())
+	writeProbeResponse(w, req, http.StatusOK, "ok")
+}
+
+// healthz evaluates the liveness of the local ScyllaDB node and returns the HTTP status code to respond with.
+func (p *Prober) healthz(ctx context.Context) int {
+	paused, err := p.isNodePaused()
+	if err != nil {
+		p.handleServiceLabelLookupError("healthz probe", "paused", err)
+		return http.StatusServiceUnavailable
+	}
+
+	if paused {
+		// Paused nodes are kept alive without generating any load on ScyllaDB API.
+		p.logSuccess("healthz probe: node is paused", "Service", p.serviceRef())
+		return http.StatusOK
+	}
+
+	awaitPaths, awaitPathsExist, err := p.awaitPathsExist()
+	if err != nil {
+		klog.ErrorS(err, "halthz probe: can't check required paths' existence")
+		return http.StatusInternalServerError
+	}
+
+	if !awaitPathsExist {
+		if p.options.MaxBootstrapDuration > 0 {
+			elapsed := p.now().Sub(p.startTime)
+			if elapsed > p.options.MaxBootstrapDuration {
+				p.logFailure("healthz probe: required paths don't exist after max bootstrap duration", "AwaitPaths", awaitPaths, "Elapsed", elapsed, "MaxBootstrapDuration", p.options.MaxBootstrapDuration)
+				return http.StatusServiceUnavailable
+			}
+		}
+
+		p.logSuccess("healthz probe: node is awaiting required paths' existence", "AwaitPaths", awaitPaths)
+		return http.StatusOK
+	}
+
 	underMaintenance, err := p.isNodeUnderMaintenance()
 	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		klog.ErrorS(err, "healthz probe: can't look up service maintenance label", "Service", p.serviceRef())
-		return
+		p.handleServiceLabelLookupError("healthz probe", "maintenance", err)
+		return http.StatusServiceUnavailable
 	}
 
 	if underMaintenance {
-		w.WriteHeader(http.StatusOK)
-		klog.V(2).InfoS("healthz probe: node is under maintenance", "Service", p.serviceRef())
-		return
+		p.logSuccess("healthz probe: node is under maintenance", "Service", p.serviceRef())
+		return http.StatusOK
 	}
 
-	scyllaClient, err := controllerhelpers.NewScyllaClientForLocalhost()
+	// Fail fast when ScyllaDB isn't running, instead of waiting for the API calls to time out.
+	err = p.checkAPIPortOpen(ctx)
+	if err != nil {
+		p.logFailure("healthz probe: ScyllaDB API port isn't open", "Service", p.serviceRef(), "Address", p.apiAddress, "Error", err)
+		return http.StatusServiceUnavailable
+	}
+
+	scyllaClient, err := p.getScyllaClient()
 	if err != nil {
 		klog.ErrorS(err, "healthz probe: can't get scylla client", "Service", p.serviceRef())
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return http.StatusInternalServerError
 	}
 	defer scyllaClient.Close()
 
@@ -203,9 +1018,31 @@ func (p *Prober) Healthz(w http.ResponseWriter, req *http.Request) {
 	_, err = scyllaClient.Ping(ctx, localhost)
 	if err != nil {
 		klog.ErrorS(err, "healthz probe: can't connect to Scylla API", "Service", p.serviceRef())
-		w.WriteHeader(http.StatusServiceUnavailable)
+		return http.StatusServiceUnavailable
+	}
+
+	if p.options.MetricsCheck {
+		// The warning is logged, as liveness probes don't report reasons.
+		_ = p.metricsWarning(ctx, "healthz probe")
+	}
+
+	p.logSuccess("healthz probe: node is healthy", "Service", p.serviceRef())
+	return http.StatusOK
+}
+
+func (p *Prober) Healthz(w http.ResponseWriter, req *http.Request) {
+	if !p.allowProbeMethod(w, req) {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	ctx, ctxCancel := context.WithTimeout(req.Context(), p.healthzTimeout)
+	defer ctxCancel()
+
+	w.WriteHeader(p.healthz(ctx))
+}
+
+// Livez serves the liveness probe under the name used by current Kubernetes conventions.
+// It is equivalent to Healthz, which is kept for compatibility.
+func (p *Prober) Livez(w http.ResponseWriter, req *http.Request) {
+	p.Healthz(w, req)
 }
@@ -0,0 +1,1602 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	testNamespace   = "scylla"
+	testServiceName = "basic-dc-rack-0"
+	testHostID      = "host-id-0"
+)
+
+type fakeScyllaClient struct {
+	nodeStatuses     scyllaclient.NodeStatusInfoSlice
+	hostID           string
+	transportEnabled bool
+	starting         bool
+	keyspaces        []string
+	tokens           []string
+	listenAddress    string
+	version          string
+	features         []string
+	datacenter       string
+	rack             string
+	err              error
+	// keyspacesErr fails only the Keyspaces calls.
+	keyspacesErr error
+
+	// lastDeadline is the deadline of the context of the last Status or Ping call.
+	lastDeadline time.Time
+	statusCalls  int
+	drainCalls   int
+}
+
+var _ ScyllaClient = &fakeScyllaClient{}
+
+func (c *fakeScyllaClient) Status(ctx context.Context, host string) (scyllaclient.NodeStatusInfoSlice, error) {
+	c.lastDeadline, _ = ctx.Deadline()
+	c.statusCalls++
+	return c.nodeStatuses, c.err
+}
+
+func (c *fakeScyllaClient) GetLocalHostId(ctx context.Context, host string, retry bool) (string, error) {
+	return c.hostID, c.err
+}
+
+func (c *fakeScyllaClient) IsNativeTransportEnabled(ctx context.Context, host string) (bool, error) {
+	return c.transportEnabled, c.err
+}
+
+func (c *fakeScyllaClient) IsStarting(ctx context.Context, host string) (bool, error) {
+	return c.starting, c.err
+}
+
+func (c *fakeScyllaClient) GetNodeTokens(ctx context.Context, host, endpoint string) ([]string, error) {
+	return c.tokens, c.err
+}
+
+func (c *fakeScyllaClient) Ping(ctx context.Context, host string) (time.Duration, error) {
+	c.lastDeadline, _ = ctx.Deadline()
+	return time.Millisecond, c.err
+}
+
+func (c *fakeScyllaClient) Keyspaces(ctx context.Context) ([]string, error) {
+	if c.keyspacesErr != nil {
+		return nil, c.keyspacesErr
+	}
+
+	return c.keyspaces, c.err
+}
+
+func (c *fakeScyllaClient) ListenAddress(ctx context.Context, host string) (string, error) {
+	return c.listenAddress, c.err
+}
+
+func (c *fakeScyllaClient) ScyllaVersion(ctx context.Context) (string, error) {
+	return c.version, c.err
+}
+
+func (c *fakeScyllaClient) EnabledFeatures(ctx context.Context, host string) ([]string, error) {
+	return c.features, c.err
+}
+
+func (c *fakeScyllaClient) Drain(ctx context.Context, host string) error {
+	c.drainCalls++
+	if c.err != nil {
+		return c.err
+	}
+
+	// Draining stops listening for clients.
+	c.transportEnabled = false
+	return nil
+}
+
+func (c *fakeScyllaClient) GetSnitchDatacenter(ctx context.Context, host string) (string, error) {
+	return c.datacenter, c.err
+}
+
+func (c *fakeScyllaClient) GetSnitchRack(ctx context.Context, host string) (string, error) {
+	return c.rack, c.err
+}
+
+func (c *fakeScyllaClient) Close() {}
+
+func newUNScyllaClient() *fakeScyllaClient {
+	return &fakeScyllaClient{
+		nodeStatuses: scyllaclient.NodeStatusInfoSlice{
+			{
+				HostID: testHostID,
+				Addr:   "10.0.0.1",
+				Status: scyllaclient.NodeStatusUp,
+				State:  scyllaclient.NodeStateNormal,
+			},
+		},
+		hostID:           testHostID,
+		transportEnabled: true,
+	}
+}
+
+func newTestService(labels map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testServiceName,
+			Namespace: testNamespace,
+			Labels:    labels,
+		},
+	}
+}
+
+func newTestProber(t *testing.T, svc *corev1.Service, client ScyllaClient) *Prober {
+	return newTestProberWithOptions(t, svc, client, ProberOptions{})
+}
+
+func newTestProberWithOptions(t *testing.T, svc *corev1.Service, client ScyllaClient, options ProberOptions) *Prober {
+	t.Helper()
+
+	serviceCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if svc != nil {
+		err := serviceCache.Add(svc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	podCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	p := NewProber(
+		testNamespace,
+		testServiceName,
+		corev1listers.NewServiceLister(serviceCache),
+		testServiceName,
+		corev1listers.NewPodLister(podCache),
+		nil,
+		options,
+	)
+	p.newScyllaClient = func() (ScyllaClient, error) {
+		if client == nil {
+			return nil, fmt.Errorf("unexpected ScyllaDB API client creation")
+		}
+
+		return client, nil
+	}
+	p.apiAddress = newTestAPIListener(t)
+
+	return p
+}
+
+func probe(handler http.HandlerFunc, path string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w.Code
+}
+
+func TestProber_Paused(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                  string
+		service               *corev1.Service
+		client                ScyllaClient
+		expectedReadyzStatus  int
+		expectedHealthzStatus int
+	}{
+		{
+			name: "paused node is unready and healthy without contacting ScyllaDB API",
+			service: newTestService(map[string]string{
+				naming.NodePausedLabel: "",
+			}),
+			client:                nil,
+			expectedReadyzStatus:  http.StatusServiceUnavailable,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name: "paused node under maintenance is unready and healthy without contacting ScyllaDB API",
+			service: newTestService(map[string]string{
+				naming.NodePausedLabel:      "",
+				naming.NodeMaintenanceLabel: "",
+			}),
+			client:                nil,
+			expectedReadyzStatus:  http.StatusServiceUnavailable,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name:                  "node that isn't paused is ready and healthy when it is UN with native transport enabled",
+			service:               newTestService(nil),
+			client:                newUNScyllaClient(),
+			expectedReadyzStatus:  http.StatusOK,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name:                  "missing service makes node unready and unhealthy",
+			service:               nil,
+			client:                nil,
+			expectedReadyzStatus:  http.StatusServiceUnavailable,
+			expectedHealthzStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProber(t, tc.service, tc.client)
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			if healthzStatus != tc.expectedHealthzStatus {
+				t.Errorf("expected healthz status %d, got %d", tc.expectedHealthzStatus, healthzStatus)
+			}
+		})
+	}
+}
+
+func TestProber_ExtraMaintenanceLabels(t *testing.T) {
+	t.Parallel()
+
+	const customMaintenanceLabel = "mesh.example.com/maintenance"
+
+	tt := []struct {
+		name                   string
+		service                *corev1.Service
+		extraMaintenanceLabels []string
+		expectedReadyzStatus   int
+		expectedBody           string
+	}{
+		{
+			name: "built-in maintenance label is checked without extra labels",
+			service: newTestService(map[string]string{
+				naming.NodeMaintenanceLabel: "",
+			}),
+			extraMaintenanceLabels: nil,
+			expectedReadyzStatus:   http.StatusServiceUnavailable,
+			expectedBody:           "node is under maintenance\n",
+		},
+		{
+			name: "built-in maintenance label is checked with extra labels",
+			service: newTestService(map[string]string{
+				naming.NodeMaintenanceLabel: "",
+			}),
+			extraMaintenanceLabels: []string{customMaintenanceLabel},
+			expectedReadyzStatus:   http.StatusServiceUnavailable,
+			expectedBody:           "node is under maintenance\n",
+		},
+		{
+			name: "extra maintenance label marks node as under maintenance",
+			service: newTestService(map[string]string{
+				customMaintenanceLabel: "true",
+			}),
+			extraMaintenanceLabels: []string{"other.example.com/maintenance", customMaintenanceLabel},
+			expectedReadyzStatus:   http.StatusServiceUnavailable,
+			expectedBody:           "node is under maintenance\n",
+		},
+		{
+			name: "custom label isn't a maintenance label unless configured",
+			service: newTestService(map[string]string{
+				customMaintenanceLabel: "true",
+			}),
+			extraMaintenanceLabels: nil,
+			expectedReadyzStatus:   http.StatusOK,
+			expectedBody:           "ok\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, tc.service, newUNScyllaClient(), ProberOptions{
+				ExtraMaintenanceLabels: tc.extraMaintenanceLabels,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+type erroringServiceLister struct {
+	err error
+}
+
+var _ corev1listers.ServiceLister = &erroringServiceLister{}
+
+func (l *erroringServiceLister) List(selector labels.Selector) ([]*corev1.Service, error) {
+	return nil, l.err
+}
+
+func (l *erroringServiceLister) Services(namespace string) corev1listers.ServiceNamespaceLister {
+	return l
+}
+
+func (l *erroringServiceLister) Get(name string) (*corev1.Service, error) {
+	return nil, l.err
+}
+
+func TestProber_ServiceLookupErrors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                 string
+		err                  error
+		expectedReasonPrefix string
+	}{
+		{
+			name:                 "forbidden error is reported as missing RBAC",
+			err:                  apierrors.NewForbidden(corev1.Resource("services"), testServiceName, errors.New("access denied")),
+			expectedReasonPrefix: "missing RBAC to read services: ",
+		},
+		{
+			name:                 "unauthorized error is reported as missing RBAC",
+			err:                  apierrors.NewUnauthorized("unauthorized"),
+			expectedReasonPrefix: "missing RBAC to read services: ",
+		},
+		{
+			name:                 "other errors are reported as failed lookups",
+			err:                  errors.New("connection refused"),
+			expectedReasonPrefix: "can't look up service paused label: ",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProber(t, nil, nil)
+			p.serviceLister = &erroringServiceLister{err: tc.err}
+
+			readyzStatus, readyzReason := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != http.StatusServiceUnavailable {
+				t.Errorf("expected readyz status %d, got %d", http.StatusServiceUnavailable, readyzStatus)
+			}
+			if !strings.HasPrefix(readyzReason, tc.expectedReasonPrefix) {
+				t.Errorf("expected readyz reason with prefix %q, got %q", tc.expectedReasonPrefix, readyzReason)
+			}
+
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			if healthzStatus != http.StatusServiceUnavailable {
+				t.Errorf("expected healthz status %d, got %d", http.StatusServiceUnavailable, healthzStatus)
+			}
+		})
+	}
+}
+
+func listenOnLocalhost(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func closedLocalhostPort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	err = listener.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return port
+}
+
+func TestProber_NativeTransportCheck(t *testing.T) {
+	t.Parallel()
+
+	transportDisabledClient := func() *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.transportEnabled = false
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		client               ScyllaClient
+		options              func(t *testing.T) ProberOptions
+		expectedReadyzStatus int
+	}{
+		{
+			name:   "CQL mode: UN node with native transport enabled is ready",
+			client: newUNScyllaClient(),
+			options: func(t *testing.T) ProberOptions {
+				return ProberOptions{}
+			},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name:   "CQL mode: UN node with native transport disabled is unready",
+			client: transportDisabledClient(),
+			options: func(t *testing.T) ProberOptions {
+				return ProberOptions{}
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "Alternator-only mode: UN node with native transport disabled is ready",
+			client: transportDisabledClient(),
+			options: func(t *testing.T) ProberOptions {
+				return ProberOptions{
+					SkipNativeTransportCheck: true,
+				}
+			},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name:   "Alternator-only mode: UN node with open alternator port is ready",
+			client: transportDisabledClient(),
+			options: func(t *testing.T) ProberOptions {
+				return ProberOptions{
+					SkipNativeTransportCheck: true,
+					AlternatorPort:           listenOnLocalhost(t),
+				}
+			},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name:   "Alternator-only mode: UN node with closed alternator port is unready",
+			client: newUNScyllaClient(),
+			options: func(t *testing.T) ProberOptions {
+				return ProberOptions{
+					SkipNativeTransportCheck: true,
+					AlternatorPort:           closedLocalhostPort(t),
+				}
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "Alternator-only mode: node that isn't UN is unready",
+			client: func() *fakeScyllaClient {
+				c := newUNScyllaClient()
+				c.nodeStatuses[0].Status = scyllaclient.NodeStatusDown
+				return c
+			}(),
+			options: func(t *testing.T) ProberOptions {
+				return ProberOptions{
+					SkipNativeTransportCheck: true,
+				}
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, tc.options(t))
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+		})
+	}
+}
+
+func TestProber_StartupPhases(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(status scyllaclient.NodeStatus, state scyllaclient.NodeState, transportEnabled bool) *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.nodeStatuses[0].Status = status
+		c.nodeStatuses[0].State = state
+		c.transportEnabled = transportEnabled
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		client               ScyllaClient
+		options              ProberOptions
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "UN node with native transport enabled is ready",
+			client:               newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal, true),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "UN node with native transport disabled",
+			client:               newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateNormal, false),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "native transport is disabled\n",
+		},
+		{
+			name:                 "joining node with native transport enabled",
+			client:               newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining, true),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "native transport is enabled, but node isn't UN yet\n",
+		},
+		{
+			name:                 "joining node with native transport disabled",
+			client:               newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining, false),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "node is not UN\n",
+		},
+		{
+			name:                 "down node with native transport enabled",
+			client:               newClient(scyllaclient.NodeStatusDown, scyllaclient.NodeStateNormal, true),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "native transport is enabled, but node isn't UN yet\n",
+		},
+		{
+			name:   "native transport state isn't checked for nodes that aren't UN when the check is skipped",
+			client: newClient(scyllaclient.NodeStatusUp, scyllaclient.NodeStateJoining, true),
+			options: ProberOptions{
+				SkipNativeTransportCheck: true,
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "node is not UN\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, tc.options)
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestProber_AwaitPathsManifestDir(t *testing.T) {
+	t.Parallel()
+
+	writeFile := func(t *testing.T, path string, data string) {
+		t.Helper()
+
+		err := os.WriteFile(path, []byte(data), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tt := []struct {
+		name                 string
+		setup                func(t *testing.T, manifestDir, dataDir string)
+		expectedReadyzStatus int
+	}{
+		{
+			name:                 "missing manifest directory doesn't require any paths",
+			setup:                func(t *testing.T, manifestDir, dataDir string) {},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name: "empty manifest directory doesn't require any paths",
+			setup: func(t *testing.T, manifestDir, dataDir string) {
+				err := os.Mkdir(manifestDir, 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name: "node is unready until all paths listed in manifests exist",
+			setup: func(t *testing.T, manifestDir, dataDir string) {
+				err := os.Mkdir(manifestDir, 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				writeFile(t, filepath.Join(manifestDir, "bootstrap"), fmt.Sprintf("# bootstrap phase\n%s\n\n", filepath.Join(dataDir, "existing")))
+				writeFile(t, filepath.Join(manifestDir, "tuning"), filepath.Join(dataDir, "missing"))
+				writeFile(t, filepath.Join(dataDir, "existing"), "")
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "node is ready when all paths listed in manifests exist",
+			setup: func(t *testing.T, manifestDir, dataDir string) {
+				err := os.Mkdir(manifestDir, 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				writeFile(t, filepath.Join(manifestDir, "bootstrap"), filepath.Join(dataDir, "existing"))
+				writeFile(t, filepath.Join(dataDir, "existing"), "")
+			},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name: "hidden manifests are ignored",
+			setup: func(t *testing.T, manifestDir, dataDir string) {
+				err := os.Mkdir(manifestDir, 0755)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				writeFile(t, filepath.Join(manifestDir, ".hidden"), filepath.Join(dataDir, "missing"))
+			},
+			expectedReadyzStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			manifestDir := filepath.Join(tmpDir, "manifests")
+			dataDir := filepath.Join(tmpDir, "data")
+			err := os.Mkdir(dataDir, 0755)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.setup(t, manifestDir, dataDir)
+
+			p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+				AwaitPathsManifestDir: manifestDir,
+			})
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			if healthzStatus != http.StatusOK {
+				t.Errorf("expected healthz status %d, got %d", http.StatusOK, healthzStatus)
+			}
+		})
+	}
+}
+
+func TestProber_AwaitPathsManifestDirIsReReadOnEveryProbe(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	awaitedPath := filepath.Join(tmpDir, "awaited")
+
+	p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+		AwaitPathsManifestDir: tmpDir,
+	})
+
+	readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+	if readyzStatus != http.StatusOK {
+		t.Fatalf("expected readyz status %d, got %d", http.StatusOK, readyzStatus)
+	}
+
+	err := os.WriteFile(filepath.Join(tmpDir, "phase"), []byte(awaitedPath), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readyzStatus = probe(p.Readyz, naming.ReadinessProbePath)
+	if readyzStatus != http.StatusServiceUnavailable {
+		t.Fatalf("expected readyz status %d, got %d", http.StatusServiceUnavailable, readyzStatus)
+	}
+
+	err = os.WriteFile(awaitedPath, nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readyzStatus = probe(p.Readyz, naming.ReadinessProbePath)
+	if readyzStatus != http.StatusOK {
+		t.Fatalf("expected readyz status %d, got %d", http.StatusOK, readyzStatus)
+	}
+}
+
+func TestProber_AwaitPathsMinSize(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                  string
+		content               []byte
+		minSize               int64
+		expectedReadyzStatus  int
+		expectedHealthzStatus int
+	}{
+		{
+			name:                  "empty file is present without min size",
+			content:               []byte{},
+			minSize:               0,
+			expectedReadyzStatus:  http.StatusOK,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name:                  "empty file isn't present with min size",
+			content:               []byte{},
+			minSize:               1,
+			expectedReadyzStatus:  http.StatusServiceUnavailable,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name:                  "file smaller than min size isn't present",
+			content:               []byte("done"),
+			minSize:               5,
+			expectedReadyzStatus:  http.StatusServiceUnavailable,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name:                  "populated file is present with min size",
+			content:               []byte("done\n"),
+			minSize:               5,
+			expectedReadyzStatus:  http.StatusOK,
+			expectedHealthzStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			awaitedPath := filepath.Join(t.TempDir(), "marker")
+			err := os.WriteFile(awaitedPath, tc.content, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := newTestProber(t, newTestService(nil), newUNScyllaClient())
+			p.awaitPaths = []AwaitPath{
+				{
+					Path:    awaitedPath,
+					MinSize: tc.minSize,
+				},
+			}
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			if healthzStatus != tc.expectedHealthzStatus {
+				t.Errorf("expected healthz status %d, got %d", tc.expectedHealthzStatus, healthzStatus)
+			}
+		})
+	}
+}
+
+func TestProber_MaxBootstrapDuration(t *testing.T) {
+	t.Parallel()
+
+	const maxBootstrapDuration = 10 * time.Minute
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name                  string
+		maxBootstrapDuration  time.Duration
+		elapsed               time.Duration
+		awaitedPathExists     bool
+		expectedHealthzStatus int
+	}{
+		{
+			name:                  "missing await paths are healthy indefinitely without a bound",
+			maxBootstrapDuration:  0,
+			elapsed:               24 * time.Hour,
+			awaitedPathExists:     false,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name:                  "missing await paths are healthy within the bound",
+			maxBootstrapDuration:  maxBootstrapDuration,
+			elapsed:               maxBootstrapDuration,
+			awaitedPathExists:     false,
+			expectedHealthzStatus: http.StatusOK,
+		},
+		{
+			name:                  "missing await paths are unhealthy beyond the bound",
+			maxBootstrapDuration:  maxBootstrapDuration,
+			elapsed:               maxBootstrapDuration + time.Second,
+			awaitedPathExists:     false,
+			expectedHealthzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:                  "existing await paths aren't affected by the bound",
+			maxBootstrapDuration:  maxBootstrapDuration,
+			elapsed:               maxBootstrapDuration + time.Second,
+			awaitedPathExists:     true,
+			expectedHealthzStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			awaitedPath := filepath.Join(t.TempDir(), "marker")
+			if tc.awaitedPathExists {
+				err := os.WriteFile(awaitedPath, []byte{}, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+				MaxBootstrapDuration: tc.maxBootstrapDuration,
+			})
+			p.awaitPaths = []AwaitPath{
+				{
+					Path: awaitedPath,
+				},
+			}
+			p.startTime = start
+			p.now = func() time.Time {
+				return start.Add(tc.elapsed)
+			}
+
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			if healthzStatus != tc.expectedHealthzStatus {
+				t.Errorf("expected healthz status %d, got %d", tc.expectedHealthzStatus, healthzStatus)
+			}
+		})
+	}
+}
+
+func TestProber_Timeouts(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name                   string
+		options                ProberOptions
+		expectedReadyzTimeout  time.Duration
+		expectedHealthzTimeout time.Duration
+	}{
+		{
+			name:                   "unset timeouts default to DefaultProbeTimeout",
+			options:                ProberOptions{},
+			expectedReadyzTimeout:  DefaultProbeTimeout,
+			expectedHealthzTimeout: DefaultProbeTimeout,
+		},
+		{
+			name: "each handler uses its own timeout",
+			options: ProberOptions{
+				ReadyzTimeout:  2 * time.Hour,
+				HealthzTimeout: 10 * time.Second,
+			},
+			expectedReadyzTimeout:  2 * time.Hour,
+			expectedHealthzTimeout: 10 * time.Second,
+		},
+		{
+			name: "only readyz timeout is set",
+			options: ProberOptions{
+				ReadyzTimeout: 2 * time.Hour,
+			},
+			expectedReadyzTimeout:  2 * time.Hour,
+			expectedHealthzTimeout: DefaultProbeTimeout,
+		},
+	}
+
+	// Allow for the time passing between issuing the request and the client call.
+	const tolerance = 2 * time.Second
+
+	assertDeadline := func(t *testing.T, handlerName string, start time.Time, deadline time.Time, expectedTimeout time.Duration) {
+		t.Helper()
+
+		if deadline.IsZero() {
+			t.Fatalf("%s: expected ScyllaDB API to be called with a deadline", handlerName)
+		}
+
+		timeout := deadline.Sub(start)
+		if timeout < expectedTimeout || timeout > expectedTimeout+tolerance {
+			t.Errorf("%s: expected timeout %v, got %v", handlerName, expectedTimeout, timeout)
+		}
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUNScyllaClient()
+			p := newTestProberWithOptions(t, newTestService(nil), client, tc.options)
+
+			start := time.Now()
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != http.StatusOK {
+				t.Fatalf("expected readyz status %d, got %d", http.StatusOK, readyzStatus)
+			}
+			assertDeadline(t, "readyz", start, client.lastDeadline, tc.expectedReadyzTimeout)
+
+			start = time.Now()
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			if healthzStatus != http.StatusOK {
+				t.Fatalf("expected healthz status %d, got %d", http.StatusOK, healthzStatus)
+			}
+			assertDeadline(t, "healthz", start, client.lastDeadline, tc.expectedHealthzTimeout)
+		})
+	}
+}
+
+func TestProber_LivezMatchesHealthz(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name           string
+		service        *corev1.Service
+		client         ScyllaClient
+		expectedStatus int
+	}{
+		{
+			name:           "reachable ScyllaDB API",
+			service:        newTestService(nil),
+			client:         newUNScyllaClient(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "unreachable ScyllaDB API",
+			service: newTestService(nil),
+			client: &fakeScyllaClient{
+				err: fmt.Errorf("connection refused"),
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "node under maintenance",
+			service: newTestService(map[string]string{
+				naming.NodeMaintenanceLabel: "",
+			}),
+			client:         nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "paused node",
+			service: newTestService(map[string]string{
+				naming.NodePausedLabel: "",
+			}),
+			client:         nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing service",
+			service:        nil,
+			client:         nil,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProber(t, tc.service, tc.client)
+
+			healthzStatus := probe(p.Healthz, naming.LivenessProbePath)
+			livezStatus := probe(p.Livez, naming.LivezProbePath)
+
+			if healthzStatus != tc.expectedStatus {
+				t.Errorf("expected healthz status %d, got %d", tc.expectedStatus, healthzStatus)
+			}
+
+			if livezStatus != healthzStatus {
+				t.Errorf("expected livez status to match healthz status %d, got %d", healthzStatus, livezStatus)
+			}
+		})
+	}
+}
+
+func probeVerbose(handler http.HandlerFunc, path string) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, path+"?verbose", nil)
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w.Code, w.Body.String()
+}
+
+func TestProber_ReadinessChecks(t *testing.T) {
+	t.Parallel()
+
+	keyspaceExists := func(keyspace string) ReadinessCheck {
+		return func(ctx context.Context, client ScyllaClient) (bool, string, error) {
+			keyspaces, err := client.Keyspaces(ctx)
+			if err != nil {
+				return false, "", err
+			}
+
+			if !slices.Contains(keyspaces, keyspace) {
+				return false, fmt.Sprintf("keyspace %q doesn't exist", keyspace), nil
+			}
+
+			return true, "", nil
+		}
+	}
+
+	clientWithKeyspaces := func(keyspaces ...string) *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.keyspaces = keyspaces
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		client               ScyllaClient
+		checks               []ReadinessCheck
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "no custom checks",
+			client:               newUNScyllaClient(),
+			checks:               nil,
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:   "passing custom check",
+			client: clientWithKeyspaces("system", "my_keyspace"),
+			checks: []ReadinessCheck{
+				keyspaceExists("my_keyspace"),
+			},
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:   "failing custom check propagates its reason",
+			client: clientWithKeyspaces("system"),
+			checks: []ReadinessCheck{
+				keyspaceExists("system"),
+				keyspaceExists("my_keyspace"),
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "keyspace \"my_keyspace\" doesn't exist\n",
+		},
+		{
+			name:   "erroring custom check",
+			client: clientWithKeyspaces("system"),
+			checks: []ReadinessCheck{
+				func(ctx context.Context, client ScyllaClient) (bool, string, error) {
+					return false, "", fmt.Errorf("boom")
+				},
+			},
+			expectedReadyzStatus: http.StatusInternalServerError,
+			expectedBody:         "can't run readiness check 0: boom\n",
+		},
+		{
+			name: "custom checks don't run when built-in checks fail",
+			client: func() *fakeScyllaClient {
+				c := clientWithKeyspaces("system")
+				c.transportEnabled = false
+				return c
+			}(),
+			checks: []ReadinessCheck{
+				keyspaceExists("my_keyspace"),
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "native transport is disabled\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				ReadinessChecks: tc.checks,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+
+			nonVerboseStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if nonVerboseStatus != readyzStatus {
+				t.Errorf("expected non-verbose readyz status %d, got %d", readyzStatus, nonVerboseStatus)
+			}
+		})
+	}
+}
+
+func TestProber_RequiredKeyspace(t *testing.T) {
+	t.Parallel()
+
+	clientWithKeyspaces := func(keyspaces ...string) *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.keyspaces = keyspaces
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		requiredKeyspace     string
+		client               ScyllaClient
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "check is skipped when no keyspace is required",
+			requiredKeyspace:     "",
+			client:               clientWithKeyspaces("system"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node is ready when the required keyspace exists",
+			requiredKeyspace:     "my_keyspace",
+			client:               clientWithKeyspaces("system", "my_keyspace"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node isn't ready when the required keyspace doesn't exist",
+			requiredKeyspace:     "my_keyspace",
+			client:               clientWithKeyspaces("system"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "required keyspace \"my_keyspace\" doesn't exist\n",
+		},
+		{
+			name:             "node isn't ready when keyspaces can't be listed",
+			requiredKeyspace: "my_keyspace",
+			client: &erroringKeyspacesScyllaClient{
+				fakeScyllaClient: newUNScyllaClient(),
+				err:              fmt.Errorf("boom"),
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "can't list keyspaces: boom\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				RequiredKeyspace: tc.requiredKeyspace,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+// erroringKeyspacesScyllaClient fails only listing keyspaces.
+type erroringKeyspacesScyllaClient struct {
+	*fakeScyllaClient
+	err error
+}
+
+func (c *erroringKeyspacesScyllaClient) Keyspaces(ctx context.Context) ([]string, error) {
+	return nil, c.err
+}
+
+func TestProber_ExpectedVersion(t *testing.T) {
+	t.Parallel()
+
+	clientWithVersion := func(version string) *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.version = version
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		expectedVersion      string
+		client               ScyllaClient
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "check is skipped when no version is expected",
+			expectedVersion:      "",
+			client:               clientWithVersion("6.1.0-0.20240801.abcdef"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node is ready when it runs exactly the expected version",
+			expectedVersion:      "6.2.0",
+			client:               clientWithVersion("6.2.0"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node is ready when it runs a build of the expected version",
+			expectedVersion:      "6.2.0",
+			client:               clientWithVersion("6.2.0-0.20241010.abcdef"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node isn't ready when it runs a different version",
+			expectedVersion:      "6.2.0",
+			client:               clientWithVersion("6.1.0-0.20240801.abcdef"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "node runs version \"6.1.0-0.20240801.abcdef\" instead of the expected \"6.2.0\"\n",
+		},
+		{
+			name:                 "node isn't ready when it runs a version sharing the expected prefix",
+			expectedVersion:      "6.2.1",
+			client:               clientWithVersion("6.2.10"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "node runs version \"6.2.10\" instead of the expected \"6.2.1\"\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				ExpectedVersion: tc.expectedVersion,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestProber_RequiredFeatures(t *testing.T) {
+	t.Parallel()
+
+	clientWithFeatures := func(features ...string) *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.features = features
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		requiredFeatures     []string
+		client               ScyllaClient
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "check is skipped when no features are required",
+			requiredFeatures:     nil,
+			client:               clientWithFeatures(),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node is ready when all required features are enabled",
+			requiredFeatures:     []string{"TABLETS", "UDA"},
+			client:               clientWithFeatures("CDC", "TABLETS", "UDA"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "node isn't ready when some required features aren't enabled",
+			requiredFeatures:     []string{"TABLETS", "UDA", "VIEW_BUILD_STATUS_ON_GROUP0"},
+			client:               clientWithFeatures("CDC", "UDA"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "required feature(s) TABLETS, VIEW_BUILD_STATUS_ON_GROUP0 aren't enabled yet\n",
+		},
+		{
+			name:             "node isn't ready when enabled features can't be determined",
+			requiredFeatures: []string{"TABLETS"},
+			client: &erroringFeaturesScyllaClient{
+				fakeScyllaClient: newUNScyllaClient(),
+				err:              fmt.Errorf("boom"),
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "can't get enabled features: boom\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, ProberOptions{
+				RequiredFeatures: tc.requiredFeatures,
+			})
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+// erroringFeaturesScyllaClient fails only getting enabled features.
+type erroringFeaturesScyllaClient struct {
+	*fakeScyllaClient
+	err error
+}
+
+func (c *erroringFeaturesScyllaClient) EnabledFeatures(ctx context.Context, host string) ([]string, error) {
+	return nil, c.err
+}
+
+func TestProber_ListenAddressCheck(t *testing.T) {
+	t.Parallel()
+
+	clientWithListenAddress := func(listenAddress string) *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.listenAddress = listenAddress
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		options              ProberOptions
+		client               ScyllaClient
+		expectedReadyzStatus int
+		expectedBody         string
+	}{
+		{
+			name:                 "check is skipped when it's disabled",
+			options:              ProberOptions{PodIP: "10.0.0.1"},
+			client:               clientWithListenAddress("10.0.0.2"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "listen address matching Pod IP",
+			options:              ProberOptions{ListenAddressCheck: true, PodIP: "10.0.0.1"},
+			client:               clientWithListenAddress("10.0.0.1"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "differently formatted IPv6 listen address matching Pod IP",
+			options:              ProberOptions{ListenAddressCheck: true, PodIP: "fd00::1"},
+			client:               clientWithListenAddress("fd00:0:0:0:0:0:0:1"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "unspecified listen address matches any Pod IP",
+			options:              ProberOptions{ListenAddressCheck: true, PodIP: "10.0.0.1"},
+			client:               clientWithListenAddress("0.0.0.0"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedBody:         "ok\n",
+		},
+		{
+			name:                 "listen address not matching Pod IP",
+			options:              ProberOptions{ListenAddressCheck: true, PodIP: "10.0.0.1"},
+			client:               clientWithListenAddress("127.0.0.1"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "listen address \"127.0.0.1\" doesn't match Pod IP \"10.0.0.1\"\n",
+		},
+		{
+			name:                 "listen address that isn't an IP",
+			options:              ProberOptions{ListenAddressCheck: true, PodIP: "10.0.0.1"},
+			client:               clientWithListenAddress("scylla.local"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedBody:         "listen address \"scylla.local\" doesn't match Pod IP \"10.0.0.1\"\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, tc.options)
+
+			readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestProber_RequireTokens(t *testing.T) {
+	t.Parallel()
+
+	clientWithTokens := func(tokens ...string) *fakeScyllaClient {
+		c := newUNScyllaClient()
+		c.tokens = tokens
+		return c
+	}
+
+	tt := []struct {
+		name                 string
+		client               ScyllaClient
+		options              ProberOptions
+		expectedReadyzStatus int
+	}{
+		{
+			name:                 "zero-token node is ready when tokens aren't required",
+			client:               clientWithTokens(),
+			options:              ProberOptions{},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name:   "zero-token node is unready when tokens are required",
+			client: clientWithTokens(),
+			options: ProberOptions{
+				RequireTokens: true,
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "token-owning node is ready when tokens are required",
+			client: clientWithTokens("-9223372036854775808", "0"),
+			options: ProberOptions{
+				RequireTokens: true,
+			},
+			expectedReadyzStatus: http.StatusOK,
+		},
+		{
+			name: "token-owning node with native transport disabled is unready when tokens are required",
+			client: func() *fakeScyllaClient {
+				c := clientWithTokens("0")
+				c.transportEnabled = false
+				return c
+			}(),
+			options: ProberOptions{
+				RequireTokens: true,
+			},
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newTestProberWithOptions(t, newTestService(nil), tc.client, tc.options)
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+		})
+	}
+}
+
+func TestProber_CQLAuthCheck(t *testing.T) {
+	t.Parallel()
+
+	validCredentials := map[string]string{
+		corev1.BasicAuthUsernameKey: "cassandra\n",
+		corev1.BasicAuthPasswordKey: "secret\n",
+	}
+
+	tt := []struct {
+		name                 string
+		cqlAuthCheck         bool
+		credentials          map[string]string
+		loginErr             error
+		expectedReadyzStatus int
+		expectedLogin        bool
+	}{
+		{
+			name:                 "check isn't run when it's disabled",
+			cqlAuthCheck:         false,
+			credentials:          nil,
+			loginErr:             fmt.Errorf("authentication failed"),
+			expectedReadyzStatus: http.StatusOK,
+			expectedLogin:        false,
+		},
+		{
+			name:                 "node is ready when authenticated login succeeds",
+			cqlAuthCheck:         true,
+			credentials:          validCredentials,
+			loginErr:             nil,
+			expectedReadyzStatus: http.StatusOK,
+			expectedLogin:        true,
+		},
+		{
+			name:                 "node is unready when authenticated login fails",
+			cqlAuthCheck:         true,
+			credentials:          validCredentials,
+			loginErr:             fmt.Errorf("authentication failed"),
+			expectedReadyzStatus: http.StatusServiceUnavailable,
+			expectedLogin:        true,
+		},
+		{
+			name:         "unreadable credentials result in an internal error",
+			cqlAuthCheck: true,
+			credentials: map[string]string{
+				corev1.BasicAuthUsernameKey: "cassandra",
+			},
+			loginErr:             nil,
+			expectedReadyzStatus: http.StatusInternalServerError,
+			expectedLogin:        false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			credentialsPath := t.TempDir()
+			for k, v := range tc.credentials {
+				err := os.WriteFile(filepath.Join(credentialsPath, k), []byte(v), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p := newTestProberWithOptions(t, newTestService(nil), newUNScyllaClient(), ProberOptions{
+				CQLAuthCheck:       tc.cqlAuthCheck,
+				CQLCredentialsPath: credentialsPath,
+			})
+
+			loggedIn := false
+			p.cqlLogin = func(ctx context.Context, username, password string) error {
+				loggedIn = true
+				if username != "cassandra" || password != "secret" {
+					return fmt.Errorf("unexpected credentials %q/%q", username, password)
+				}
+				return tc.loginErr
+			}
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if loggedIn != tc.expectedLogin {
+				t.Errorf("expected login attempt %t, got %t", tc.expectedLogin, loggedIn)
+			}
+		})
+	}
+}
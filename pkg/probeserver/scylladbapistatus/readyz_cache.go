@@ -0,0 +1,134 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+type readyzCacheEntry struct {
+	statusCode int
+	reason     string
+	timestamp  time.Time
+}
+
+func (p *Prober) isReadyzCacheEnabled() bool {
+	return p.options.ReadyzCacheRefreshInterval > 0
+}
+
+func (p *Prober) getCachedReadyz() (*readyzCacheEntry, bool) {
+	p.readyzCacheLock.Lock()
+	defer p.readyzCacheLock.Unlock()
+
+	if p.readyzCache == nil || p.now().Sub(p.readyzCache.timestamp) > p.options.ReadyzCacheMaxStaleness {
+		return nil, false
+	}
+
+	return p.readyzCache, true
+}
+
+func (p *Prober) setCachedReadyz(statusCode int, reason string) {
+	p.readyzCacheLock.Lock()
+	defer p.readyzCacheLock.Unlock()
+
+	p.readyzCache = &readyzCacheEntry{
+		statusCode: statusCode,
+		reason:     reason,
+		timestamp:  p.now(),
+	}
+}
+
+func (p *Prober) getLastDefinitiveReadyz() (*readyzCacheEntry, bool) {
+	p.lastDefinitiveReadyzLock.Lock()
+	defer p.lastDefinitiveReadyzLock.Unlock()
+
+	if p.lastDefinitiveReadyz == nil || p.now().Sub(p.lastDefinitiveReadyz.timestamp) > p.options.ReadyzFallbackFreshness {
+		return nil, false
+	}
+
+	return p.lastDefinitiveReadyz, true
+}
+
+func (p *Prober) setLastDefinitiveReadyz(statusCode int, reason string) {
+	p.lastDefinitiveReadyzLock.Lock()
+	defer p.lastDefinitiveReadyzLock.Unlock()
+
+	p.lastDefinitiveReadyz = &readyzCacheEntry{
+		statusCode: statusCode,
+		reason:     reason,
+		timestamp:  p.now(),
+	}
+}
+
+// fallbackAPIReadyz runs the readiness checks that contact ScyllaDB API. When a call to ScyllaDB API fails,
+// e.g. during a brief API outage, it returns the last definitive outcome instead, as long as it isn't older
+// than ReadyzFallbackFreshness. Failures caused by the local configuration are always returned as they are.
+func (p *Prober) fallbackAPIReadyz(ctx context.Context) (int, string) {
+	statusCode, reason, transient := p.apiReadyz(ctx)
+	if p.options.ReadyzFallbackFreshness <= 0 {
+		return statusCode, reason
+	}
+
+	if !transient {
+		p.setLastDefinitiveReadyz(statusCode, reason)
+		return statusCode, reason
+	}
+
+	entry, ok := p.getLastDefinitiveReadyz()
+	if !ok {
+		return statusCode, reason
+	}
+
+	p.logFailure("readyz probe: can't determine readiness, using the last definitive outcome", "Service", p.serviceRef(), "Reason", reason, "Age", p.now().Sub(entry.timestamp))
+	return entry.statusCode, entry.reason
+}
+
+// cachedAPIReadyz serves the readiness checks that contact ScyllaDB API from the cache, when it's enabled and fresh.
+// Otherwise, it falls back to a live call.
+func (p *Prober) cachedAPIReadyz(ctx context.Context) (int, string) {
+	if !p.isReadyzCacheEnabled() {
+		return p.fallbackAPIReadyz(ctx)
+	}
+
+	entry, ok := p.getCachedReadyz()
+	if ok {
+		return entry.statusCode, entry.reason
+	}
+
+	klog.V(4).InfoS("readyz probe: cached result is missing or stale, falling back to a live call", "Service", p.serviceRef())
+	statusCode, reason := p.fallbackAPIReadyz(ctx)
+	p.setCachedReadyz(statusCode, reason)
+
+	return statusCode, reason
+}
+
+func (p *Prober) refreshReadyzCache(ctx context.Context) {
+	paused, err := p.isNodePaused()
+	if err != nil {
+		p.handleServiceLabelLookupError("readyz cache refresh", "paused", err)
+		return
+	}
+
+	if paused {
+		// Paused nodes shouldn't get any load on ScyllaDB API.
+		return
+	}
+
+	ctx, ctxCancel := context.WithTimeout(ctx, p.readyzTimeout)
+	defer ctxCancel()
+
+	statusCode, reason := p.fallbackAPIReadyz(ctx)
+	p.setCachedReadyz(statusCode, reason)
+}
+
+// Run refreshes the readiness cache in the background until the context is cancelled.
+// It returns immediately if the cache is disabled.
+func (p *Prober) Run(ctx context.Context) {
+	if !p.isReadyzCacheEnabled() {
+		return
+	}
+
+	wait.UntilWithContext(ctx, p.refreshReadyzCache, p.options.ReadyzCacheRefreshInterval)
+}
@@ -0,0 +1,340 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+func TestProber_ReadyzCache(t *testing.T) {
+	t.Parallel()
+
+	const (
+		refreshInterval = 10 * time.Second
+		maxStaleness    = 30 * time.Second
+	)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name                  string
+		options               ProberOptions
+		refreshCache          bool
+		elapsed               time.Duration
+		expectedReadyzStatus  int
+		expectedStatusCalls   int
+		expectedCachedStatus  int
+		expectedCacheIsFilled bool
+	}{
+		{
+			name:                  "disabled cache always makes a live call",
+			options:               ProberOptions{},
+			refreshCache:          false,
+			elapsed:               0,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedStatusCalls:   1,
+			expectedCacheIsFilled: false,
+		},
+		{
+			name: "fresh cache is used instead of a live call",
+			options: ProberOptions{
+				ReadyzCacheRefreshInterval: refreshInterval,
+				ReadyzCacheMaxStaleness:    maxStaleness,
+			},
+			refreshCache:          true,
+			elapsed:               maxStaleness,
+			expectedReadyzStatus:  http.StatusOK,
+			expectedStatusCalls:   1,
+			expectedCachedStatus:  http.StatusOK,
+			expectedCacheIsFilled: true,
+		},
+		{
+			name: "stale cache falls back to a live call and is replaced",
+			options: ProberOptions{
+				ReadyzCacheRefreshInterval: refreshInterval,
+				ReadyzCacheMaxStaleness:    maxStaleness,
+			},
+			refreshCache:          true,
+			elapsed:               maxStaleness + time.Second,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedStatusCalls:   2,
+			expectedCachedStatus:  http.StatusInternalServerError,
+			expectedCacheIsFilled: true,
+		},
+		{
+			name: "empty cache falls back to a live call and is filled",
+			options: ProberOptions{
+				ReadyzCacheRefreshInterval: refreshInterval,
+				ReadyzCacheMaxStaleness:    maxStaleness,
+			},
+			refreshCache:          false,
+			elapsed:               0,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedStatusCalls:   1,
+			expectedCachedStatus:  http.StatusInternalServerError,
+			expectedCacheIsFilled: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUNScyllaClient()
+			p := newTestProberWithOptions(t, newTestService(nil), client, tc.options)
+			now := start
+			p.now = func() time.Time {
+				return now
+			}
+
+			if tc.refreshCache {
+				p.refreshReadyzCache(context.Background())
+			}
+
+			// Make any live call fail from now on.
+			client.err = fmt.Errorf("ScyllaDB API is unavailable")
+			now = now.Add(tc.elapsed)
+
+			readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if client.statusCalls != tc.expectedStatusCalls {
+				t.Errorf("expected %d ScyllaDB API status calls, got %d", tc.expectedStatusCalls, client.statusCalls)
+			}
+
+			if (p.readyzCache != nil) != tc.expectedCacheIsFilled {
+				t.Fatalf("expected cache to be filled: %t, got %t", tc.expectedCacheIsFilled, p.readyzCache != nil)
+			}
+
+			if p.readyzCache != nil && p.readyzCache.statusCode != tc.expectedCachedStatus {
+				t.Errorf("expected cached status %d, got %d", tc.expectedCachedStatus, p.readyzCache.statusCode)
+			}
+		})
+	}
+}
+
+func TestProber_ReadyzCacheDoesNotOverrideLocalChecks(t *testing.T) {
+	t.Parallel()
+
+	client := newUNScyllaClient()
+	svc := newTestService(nil)
+	p := newTestProberWithOptions(t, svc, client, ProberOptions{
+		ReadyzCacheRefreshInterval: 10 * time.Second,
+		ReadyzCacheMaxStaleness:    time.Hour,
+	})
+
+	p.refreshReadyzCache(context.Background())
+
+	readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+	if readyzStatus != http.StatusOK {
+		t.Fatalf("expected readyz status %d, got %d", http.StatusOK, readyzStatus)
+	}
+
+	svc.Labels = map[string]string{
+		naming.NodeMaintenanceLabel: "",
+	}
+
+	readyzStatus = probe(p.Readyz, naming.ReadinessProbePath)
+	if readyzStatus != http.StatusServiceUnavailable {
+		t.Errorf("expected readyz status %d, got %d", http.StatusServiceUnavailable, readyzStatus)
+	}
+}
+
+func TestProber_RefreshReadyzCacheSkipsPausedNode(t *testing.T) {
+	t.Parallel()
+
+	p := newTestProberWithOptions(t, newTestService(map[string]string{naming.NodePausedLabel: ""}), nil, ProberOptions{
+		ReadyzCacheRefreshInterval: 10 * time.Second,
+		ReadyzCacheMaxStaleness:    time.Hour,
+	})
+
+	p.refreshReadyzCache(context.Background())
+
+	if p.readyzCache != nil {
+		t.Errorf("expected cache not to be refreshed for a paused node, got %#v", p.readyzCache)
+	}
+}
+
+func TestProber_ReadyzFallback(t *testing.T) {
+	t.Parallel()
+
+	const freshness = 30 * time.Second
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	failAPI := func(client *fakeScyllaClient) {
+		client.err = fmt.Errorf("test error")
+	}
+
+	tt := []struct {
+		name                  string
+		freshness             time.Duration
+		options               ProberOptions
+		definitiveClient      *fakeScyllaClient
+		elapsed               time.Duration
+		fail                  func(client *fakeScyllaClient)
+		expectedReadyzStatus  int
+		expectedReadyzReason  string
+		expectedInitialStatus int
+	}{
+		{
+			name:                  "disabled fallback reports the failure",
+			freshness:             0,
+			definitiveClient:      newUNScyllaClient(),
+			elapsed:               time.Second,
+			fail:                  failAPI,
+			expectedInitialStatus: http.StatusOK,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedReadyzReason:  "can't get scylla node status: test error\n",
+		},
+		{
+			name:                  "fresh ready outcome is returned on failure",
+			freshness:             freshness,
+			definitiveClient:      newUNScyllaClient(),
+			elapsed:               freshness,
+			fail:                  failAPI,
+			expectedInitialStatus: http.StatusOK,
+			expectedReadyzStatus:  http.StatusOK,
+			expectedReadyzReason:  "ok\n",
+		},
+		{
+			name:      "fresh unready outcome is returned on failure",
+			freshness: freshness,
+			definitiveClient: func() *fakeScyllaClient {
+				client := newUNScyllaClient()
+				client.transportEnabled = false
+				return client
+			}(),
+			elapsed:               freshness,
+			fail:                  failAPI,
+			expectedInitialStatus: http.StatusServiceUnavailable,
+			expectedReadyzStatus:  http.StatusServiceUnavailable,
+			expectedReadyzReason:  "native transport is disabled\n",
+		},
+		{
+			name:                  "stale outcome isn't returned on failure",
+			freshness:             freshness,
+			definitiveClient:      newUNScyllaClient(),
+			elapsed:               freshness + time.Second,
+			fail:                  failAPI,
+			expectedInitialStatus: http.StatusOK,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedReadyzReason:  "can't get scylla node status: test error\n",
+		},
+		{
+			name:      "fresh outcome is returned when a check that isn't a node status call fails to contact the API",
+			freshness: freshness,
+			options: ProberOptions{
+				RequiredKeyspace: "test",
+			},
+			definitiveClient: func() *fakeScyllaClient {
+				client := newUNScyllaClient()
+				client.keyspaces = []string{"test"}
+				return client
+			}(),
+			elapsed: freshness,
+			fail: func(client *fakeScyllaClient) {
+				client.keyspacesErr = fmt.Errorf("test error")
+			},
+			expectedInitialStatus: http.StatusOK,
+			expectedReadyzStatus:  http.StatusOK,
+			expectedReadyzReason:  "ok\n",
+		},
+		{
+			name:      "fresh outcome isn't returned when CQL credentials can't be read",
+			freshness: freshness,
+			options: ProberOptions{
+				CQLAuthCheck:       true,
+				CQLCredentialsPath: "/nonexistent",
+			},
+			definitiveClient: func() *fakeScyllaClient {
+				client := newUNScyllaClient()
+				client.transportEnabled = false
+				return client
+			}(),
+			elapsed: time.Second,
+			fail: func(client *fakeScyllaClient) {
+				client.transportEnabled = true
+			},
+			expectedInitialStatus: http.StatusServiceUnavailable,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedReadyzReason:  "can't read CQL credentials: can't read \"/nonexistent/username\": open /nonexistent/username: no such file or directory\n",
+		},
+		{
+			name:      "fresh outcome isn't returned when a custom readiness check fails to run",
+			freshness: freshness,
+			options: ProberOptions{
+				ReadinessChecks: []ReadinessCheck{
+					func(ctx context.Context, client ScyllaClient) (bool, string, error) {
+						if client.(*fakeScyllaClient).keyspacesErr != nil {
+							return false, "", fmt.Errorf("test error")
+						}
+
+						return true, "", nil
+					},
+				},
+			},
+			definitiveClient: newUNScyllaClient(),
+			elapsed:          time.Second,
+			fail: func(client *fakeScyllaClient) {
+				client.keyspacesErr = fmt.Errorf("test error")
+			},
+			expectedInitialStatus: http.StatusOK,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedReadyzReason:  "can't run readiness check 0: test error\n",
+		},
+		{
+			name:      "transient failure isn't recorded as the definitive outcome",
+			freshness: freshness,
+			options: ProberOptions{
+				RequiredKeyspace: "test",
+			},
+			definitiveClient: func() *fakeScyllaClient {
+				client := newUNScyllaClient()
+				client.keyspacesErr = fmt.Errorf("test error")
+				return client
+			}(),
+			elapsed:               time.Second,
+			fail:                  failAPI,
+			expectedInitialStatus: http.StatusServiceUnavailable,
+			expectedReadyzStatus:  http.StatusInternalServerError,
+			expectedReadyzReason:  "can't get scylla node status: test error\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := tc.definitiveClient
+			options := tc.options
+			options.ReadyzFallbackFreshness = tc.freshness
+			p := newTestProberWithOptions(t, newTestService(nil), client, options)
+			now := start
+			p.now = func() time.Time { return now }
+
+			initialStatus := probe(p.Readyz, naming.ReadinessProbePath)
+			if initialStatus != tc.expectedInitialStatus {
+				t.Errorf("expected initial readyz status %d, got %d", tc.expectedInitialStatus, initialStatus)
+			}
+
+			now = start.Add(tc.elapsed)
+			tc.fail(client)
+
+			readyzStatus, reason := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+			if readyzStatus != tc.expectedReadyzStatus {
+				t.Errorf("expected readyz status %d, got %d", tc.expectedReadyzStatus, readyzStatus)
+			}
+
+			if reason != tc.expectedReadyzReason {
+				t.Errorf("expected readyz reason %q, got %q", tc.expectedReadyzReason, reason)
+			}
+		})
+	}
+}
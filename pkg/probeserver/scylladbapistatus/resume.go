@@ -0,0 +1,102 @@
+package scylladbapistatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+)
+
+const (
+	// DefaultResumeWarmupHold is the warmup hold of resuming nodes when it isn't configured.
+	DefaultResumeWarmupHold = 30 * time.Second
+
+	// DefaultResumeGossipSettleWindow is the gossip settle window of resuming nodes when it isn't configured.
+	DefaultResumeGossipSettleWindow = 30 * time.Second
+)
+
+func (p *Prober) isNodeResuming() (bool, error) {
+	return p.serviceHasAnyLabel(naming.NodeResumingLabel)
+}
+
+// getResumeFirstUNTime returns the time when the node was first observed UN while resuming,
+// recording the current time if it hasn't been observed before. It returns false once the resume is complete.
+func (p *Prober) getResumeFirstUNTime() (time.Time, bool) {
+	p.resumeLock.Lock()
+	defer p.resumeLock.Unlock()
+
+	if p.resumeComplete {
+		return time.Time{}, false
+	}
+
+	if p.resumeFirstUNTime.IsZero() {
+		p.resumeFirstUNTime = p.now()
+	}
+
+	return p.resumeFirstUNTime, true
+}
+
+func (p *Prober) completeResume() {
+	p.resumeLock.Lock()
+	defer p.resumeLock.Unlock()
+
+	p.resumeComplete = true
+}
+
+// resetResume makes the resume checks apply again the next time the node is resuming.
+func (p *Prober) resetResume() {
+	p.resumeLock.Lock()
+	defer p.resumeLock.Unlock()
+
+	p.resumeFirstUNTime = time.Time{}
+	p.resumeComplete = false
+}
+
+// resumeReadyz holds back the readiness of a UN node of a cluster that is being resumed from pause until it warms up
+// and its view of the cluster settles. Once it passes, the checks aren't applied again until the node is paused.
+// It also returns whether the outcome is transient, as the view couldn't be obtained from ScyllaDB API.
+func (p *Prober) resumeReadyz(ctx context.Context, scyllaClient ScyllaClient) (int, string, bool) {
+	resuming, err := p.isNodeResuming()
+	if err != nil {
+		return http.StatusServiceUnavailable, p.handleServiceLabelLookupError("readyz probe", "resuming", err), false
+	}
+
+	if !resuming {
+		return http.StatusOK, "ok", false
+	}
+
+	firstUNTime, ok := p.getResumeFirstUNTime()
+	if !ok {
+		return http.StatusOK, "ok", false
+	}
+
+	warmupHold := p.options.ResumeWarmupHold
+	if warmupHold == 0 {
+		warmupHold = DefaultResumeWarmupHold
+	}
+
+	gossipSettleWindow := p.options.ResumeGossipSettleWindow
+	if gossipSettleWindow == 0 {
+		gossipSettleWindow = DefaultResumeGossipSettleWindow
+	}
+
+	// The gossip state is observed even while warming up, so both periods can elapse at the same time.
+	gossipStatusCode, gossipReason, gossipTransient := p.gossipSettleReadyz(ctx, scyllaClient, gossipSettleWindow)
+
+	remaining := warmupHold - p.now().Sub(firstUNTime)
+	if remaining > 0 {
+		p.logFailure("readyz probe: resuming node is warming up", "Service", p.serviceRef(), "Remaining", remaining)
+		return http.StatusServiceUnavailable, fmt.Sprintf("node is resuming and warming up, %v remaining", remaining), false
+	}
+
+	if gossipStatusCode != http.StatusOK {
+		return gossipStatusCode, gossipReason, gossipTransient
+	}
+
+	p.logSuccess("readyz probe: node finished resuming", "Service", p.serviceRef())
+	p.completeResume()
+
+	return http.StatusOK, "ok", false
+}
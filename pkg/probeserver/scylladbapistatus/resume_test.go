@@ -0,0 +1,122 @@
+package scylladbapistatus
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestProber_Resume(t *testing.T) {
+	t.Parallel()
+
+	const (
+		resumeWarmupHold         = time.Minute
+		resumeGossipSettleWindow = 30 * time.Second
+	)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	joiningNode := scyllaclient.NodeStatusInfo{
+		HostID: "host-id-2",
+		Addr:   "10.0.0.2",
+		Status: scyllaclient.NodeStatusUp,
+		State:  scyllaclient.NodeStateJoining,
+	}
+
+	type step struct {
+		elapsed              time.Duration
+		labels               map[string]string
+		otherNodes           []scyllaclient.NodeStatusInfo
+		expectedReadyzStatus int
+	}
+
+	resuming := map[string]string{naming.NodeResumingLabel: ""}
+	paused := map[string]string{naming.NodePausedLabel: ""}
+
+	tt := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "node that isn't resuming is ready immediately",
+			steps: []step{
+				{elapsed: 0, labels: nil, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name: "resuming node is held unready until it warms up",
+			steps: []step{
+				{elapsed: 0, labels: resuming, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: resumeWarmupHold - time.Second, labels: resuming, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Second, labels: resuming, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name: "resuming node is held unready until gossip settles after warming up",
+			steps: []step{
+				{elapsed: 0, labels: resuming, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: resumeWarmupHold - time.Second, labels: resuming, otherNodes: []scyllaclient.NodeStatusInfo{joiningNode}, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Second, labels: resuming, otherNodes: []scyllaclient.NodeStatusInfo{joiningNode}, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: resumeGossipSettleWindow - time.Second, labels: resuming, otherNodes: []scyllaclient.NodeStatusInfo{joiningNode}, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name: "resume mode clears once the node passed the checks",
+			steps: []step{
+				{elapsed: 0, labels: resuming, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: resumeWarmupHold, labels: resuming, expectedReadyzStatus: http.StatusOK},
+				{elapsed: time.Second, labels: resuming, otherNodes: []scyllaclient.NodeStatusInfo{joiningNode}, expectedReadyzStatus: http.StatusOK},
+				{elapsed: time.Second, labels: nil, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name: "resume mode applies again after the node is paused",
+			steps: []step{
+				{elapsed: 0, labels: resuming, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: resumeWarmupHold, labels: resuming, expectedReadyzStatus: http.StatusOK},
+				{elapsed: time.Second, labels: paused, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Hour, labels: resuming, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: resumeWarmupHold, labels: resuming, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUNScyllaClient()
+			localNodeStatuses := client.nodeStatuses
+			p := newTestProberWithOptions(t, nil, client, ProberOptions{
+				ResumeWarmupHold:         resumeWarmupHold,
+				ResumeGossipSettleWindow: resumeGossipSettleWindow,
+			})
+			now := start
+			p.now = func() time.Time {
+				return now
+			}
+
+			for i, s := range tc.steps {
+				now = now.Add(s.elapsed)
+				client.nodeStatuses = append(append(scyllaclient.NodeStatusInfoSlice{}, localNodeStatuses...), s.otherNodes...)
+
+				serviceCache := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+				err := serviceCache.Add(newTestService(s.labels))
+				if err != nil {
+					t.Fatal(err)
+				}
+				p.serviceLister = corev1listers.NewServiceLister(serviceCache)
+
+				readyzStatus, body := probeVerbose(p.Readyz, naming.ReadinessProbePath)
+				if readyzStatus != s.expectedReadyzStatus {
+					t.Errorf("step %d: expected readyz status %d, got %d: %q", i, s.expectedReadyzStatus, readyzStatus, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// getFirstUNTime returns the time since when the node has been observed UN with native transport enabled,
+// recording the current time if it hasn't been observed before.
+func (p *Prober) getFirstUNTime() time.Time {
+	p.firstUNTimeLock.Lock()
+	defer p.firstUNTimeLock.Unlock()
+
+	if p.firstUNTime.IsZero() {
+		p.firstUNTime = p.now()
+	}
+
+	return p.firstUNTime
+}
+
+// resetFirstUNTime makes the warmup hold apply again, as the node was observed not UN or not serving clients.
+func (p *Prober) resetFirstUNTime() {
+	p.firstUNTimeLock.Lock()
+	defer p.firstUNTimeLock.Unlock()
+
+	p.firstUNTime = time.Time{}
+}
+
+// warmupReadyz holds back the readiness of a UN node until WarmupHold passes since it became UN,
+// so the node can warm its caches before receiving traffic.
+func (p *Prober) warmupReadyz() (int, string) {
+	if p.options.WarmupHold <= 0 {
+		return http.StatusOK, "ok"
+	}
+
+	remaining := p.options.WarmupHold - p.now().Sub(p.getFirstUNTime())
+	if remaining > 0 {
+		p.logFailure("readyz probe: node is warming up", "Service", p.serviceRef(), "Remaining", remaining)
+		return http.StatusServiceUnavailable, fmt.Sprintf("node is warming up, %v remaining", remaining)
+	}
+
+	return http.StatusOK, "ok"
+}
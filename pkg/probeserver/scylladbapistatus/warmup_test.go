@@ -0,0 +1,134 @@
+package scylladbapistatus
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-operator/pkg/naming"
+	"github.com/scylladb/scylla-operator/pkg/scyllaclient"
+)
+
+func TestProber_WarmupHold(t *testing.T) {
+	t.Parallel()
+
+	const warmupHold = time.Minute
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type step struct {
+		elapsed              time.Duration
+		down                 bool
+		apiFailure           bool
+		transportEnabled     bool
+		expectedReadyzStatus int
+	}
+
+	tt := []struct {
+		name       string
+		warmupHold time.Duration
+		steps      []step
+	}{
+		{
+			name:       "disabled hold makes a UN node ready immediately",
+			warmupHold: 0,
+			steps: []step{
+				{elapsed: 0, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:       "UN node is held unready until the hold passes since it was first observed UN",
+			warmupHold: warmupHold,
+			steps: []step{
+				{elapsed: 0, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold - time.Second, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Second, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:       "hold starts only once the node is UN with native transport enabled",
+			warmupHold: warmupHold,
+			steps: []step{
+				{elapsed: 0, transportEnabled: false, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:       "hold isn't applied again while the node stays UN",
+			warmupHold: warmupHold,
+			steps: []step{
+				{elapsed: 0, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:       "hold is applied again once the node goes down and becomes UN again",
+			warmupHold: warmupHold,
+			steps: []step{
+				{elapsed: 0, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+				{elapsed: time.Second, down: true, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Second, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold - time.Second, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Second, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:       "hold isn't applied again when ScyllaDB API fails to respond",
+			warmupHold: warmupHold,
+			steps: []step{
+				{elapsed: 0, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+				{elapsed: time.Second, apiFailure: true, transportEnabled: true, expectedReadyzStatus: http.StatusInternalServerError},
+				{elapsed: time.Second, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+		{
+			name:       "hold is applied again once the node stops serving native transport",
+			warmupHold: warmupHold,
+			steps: []step{
+				{elapsed: 0, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+				{elapsed: time.Second, transportEnabled: false, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: time.Second, transportEnabled: true, expectedReadyzStatus: http.StatusServiceUnavailable},
+				{elapsed: warmupHold, transportEnabled: true, expectedReadyzStatus: http.StatusOK},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUNScyllaClient()
+			p := newTestProberWithOptions(t, newTestService(nil), client, ProberOptions{
+				WarmupHold: tc.warmupHold,
+			})
+			now := start
+			p.now = func() time.Time {
+				return now
+			}
+
+			for i, s := range tc.steps {
+				now = now.Add(s.elapsed)
+				client.transportEnabled = s.transportEnabled
+				client.nodeStatuses[0].Status = scyllaclient.NodeStatusUp
+				if s.down {
+					client.nodeStatuses[0].Status = scyllaclient.NodeStatusDown
+				}
+				client.err = nil
+				if s.apiFailure {
+					client.err = fmt.Errorf("test error")
+				}
+
+				readyzStatus := probe(p.Readyz, naming.ReadinessProbePath)
+				if readyzStatus != s.expectedReadyzStatus {
+					t.Errorf("step %d: expected readyz status %d, got %d", i, s.expectedReadyzStatus, readyzStatus)
+				}
+			}
+		})
+	}
+}
@@ -4,13 +4,33 @@ package resourceapply
 
 import (
 	"context"
+	"fmt"
 
 	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
 	scyllav1alpha1client "github.com/scylladb/scylla-operator/pkg/client/scylla/clientset/versioned/typed/scylla/v1alpha1"
 	scyllav1alpha1listers "github.com/scylladb/scylla-operator/pkg/client/scylla/listers/scylla/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/tools/record"
 )
 
+const (
+	// Mirrors the name generation of the API server.
+	maxNameLength                = 63
+	generatedNameRandomLength    = 5
+	maxGeneratedNameLength       = maxNameLength - generatedNameRandomLength
+	maxGenerateNameCreateRetries = 8
+)
+
+func generateName(base string) string {
+	if len(base) > maxGeneratedNameLength {
+		base = base[:maxGeneratedNameLength]
+	}
+
+	return fmt.Sprintf("%s%s", base, utilrand.String(generatedNameRandomLength))
+}
+
 func ApplyScyllaDBDatacenterWithControl(
 	ctx context.Context,
 	control ApplyControlInterface[*scyllav1alpha1.ScyllaDBDatacenter],
@@ -74,3 +94,37 @@ func ApplyRemoteOwner(
 		options,
 	)
 }
+
+// CreateRemoteOwnerWithGenerateName creates the RemoteOwner and returns the created object.
+// When the object has no name, a unique one is generated from its GenerateName on the client side,
+// so the behaviour is the same with clients that don't honor GenerateName, like the fake ones.
+func CreateRemoteOwnerWithGenerateName(
+	ctx context.Context,
+	client scyllav1alpha1client.RemoteOwnersGetter,
+	obj *scyllav1alpha1.RemoteOwner,
+) (*scyllav1alpha1.RemoteOwner, error) {
+	if len(obj.Name) != 0 {
+		return client.RemoteOwners(obj.Namespace).Create(ctx, obj, metav1.CreateOptions{})
+	}
+
+	if len(obj.GenerateName) == 0 {
+		return nil, fmt.Errorf("remoteowner has neither name nor generateName set")
+	}
+
+	for i := 0; i < maxGenerateNameCreateRetries; i++ {
+		ro := obj.DeepCopy()
+		ro.Name = generateName(ro.GenerateName)
+
+		created, err := client.RemoteOwners(ro.Namespace).Create(ctx, ro, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return created, nil
+	}
+
+	return nil, fmt.Errorf("can't create remoteowner with generateName %q: all %d generated names are already taken", obj.GenerateName, maxGenerateNameCreateRetries)
+}
@@ -0,0 +1,116 @@
+package resourceapply
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	scyllav1alpha1 "github.com/scylladb/scylla-operator/pkg/api/scylla/v1alpha1"
+	scyllafake "github.com/scylladb/scylla-operator/pkg/client/scylla/clientset/versioned/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestCreateRemoteOwnerWithGenerateName(t *testing.T) {
+	t.Parallel()
+
+	newRemoteOwner := func() *scyllav1alpha1.RemoteOwner {
+		return &scyllav1alpha1.RemoteOwner{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    "default",
+				GenerateName: "basic-",
+			},
+		}
+	}
+
+	t.Run("generates unique names", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := scyllafake.NewSimpleClientset()
+
+		const count = 20
+		names := sets.New[string]()
+		for i := 0; i < count; i++ {
+			ro, err := CreateRemoteOwnerWithGenerateName(ctx, client.ScyllaV1alpha1(), newRemoteOwner())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.HasPrefix(ro.Name, "basic-") || len(ro.Name) == len("basic-") {
+				t.Errorf("expected name generated from prefix %q, got %q", "basic-", ro.Name)
+			}
+
+			names.Insert(ro.Name)
+		}
+
+		if names.Len() != count {
+			t.Errorf("expected %d unique names, got %d: %v", count, names.Len(), sets.List(names))
+		}
+
+		list, err := client.ScyllaV1alpha1().RemoteOwners("default").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(list.Items) != count {
+			t.Errorf("expected %d stored RemoteOwners, got %d", count, len(list.Items))
+		}
+	})
+
+	t.Run("retries when a generated name is taken", func(t *testing.T) {
+		t.Parallel()
+
+		client := scyllafake.NewSimpleClientset()
+		conflicts := 2
+		client.PrependReactor("create", "remoteowners", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if conflicts == 0 {
+				return false, nil, nil
+			}
+			conflicts--
+
+			ro := action.(clienttesting.CreateAction).GetObject().(*scyllav1alpha1.RemoteOwner)
+			return true, nil, apierrors.NewAlreadyExists(scyllav1alpha1.Resource("remoteowners"), ro.Name)
+		})
+
+		ro, err := CreateRemoteOwnerWithGenerateName(context.Background(), client.ScyllaV1alpha1(), newRemoteOwner())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(ro.Name, "basic-") {
+			t.Errorf("expected name generated from prefix %q, got %q", "basic-", ro.Name)
+		}
+	})
+
+	t.Run("keeps an explicit name", func(t *testing.T) {
+		t.Parallel()
+
+		ro := newRemoteOwner()
+		ro.Name = "explicit"
+
+		created, err := CreateRemoteOwnerWithGenerateName(context.Background(), scyllafake.NewSimpleClientset().ScyllaV1alpha1(), ro)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if created.Name != "explicit" {
+			t.Errorf("expected name %q, got %q", "explicit", created.Name)
+		}
+	})
+
+	t.Run("fails without name and generateName", func(t *testing.T) {
+		t.Parallel()
+
+		ro := newRemoteOwner()
+		ro.GenerateName = ""
+
+		_, err := CreateRemoteOwnerWithGenerateName(context.Background(), scyllafake.NewSimpleClientset().ScyllaV1alpha1(), ro)
+		if err == nil {
+			t.Errorf("expected an error, got nil")
+		}
+	})
+}
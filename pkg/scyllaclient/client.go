@@ -3,6 +3,7 @@ package scyllaclient
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -20,6 +21,8 @@ import (
 	"github.com/scylladb/scylla-operator/pkg/util/httpx"
 	scyllaclient "github.com/scylladb/scylladb-swagger-go-client/scylladb/gen/v1/client"
 	scyllaoperations "github.com/scylladb/scylladb-swagger-go-client/scylladb/gen/v1/client/operations"
+	scyllav2client "github.com/scylladb/scylladb-swagger-go-client/scylladb/gen/v2/client"
+	scyllav2config "github.com/scylladb/scylladb-swagger-go-client/scylladb/gen/v2/client/config"
 )
 
 func init() {
@@ -35,9 +38,10 @@ func init() {
 type Client struct {
 	config *Config
 
-	scyllaClient *scyllaclient.ScylladbV1
-	transport    http.RoundTripper
-	pool         hostpool.HostPool
+	scyllaClient   *scyllaclient.ScylladbV1
+	scyllaV2Client *scyllav2client.ScylladbV2
+	transport      http.RoundTripper
+	pool           hostpool.HostPool
 }
 
 func NewClient(config *Config) (*Client, error) {
@@ -71,11 +75,19 @@ func NewClient(config *Config) (*Client, error) {
 
 	scyllaClient := scyllaclient.New(scyllaRuntime, strfmt.Default)
 
+	scyllaV2Runtime := api.NewWithClient(
+		scyllav2client.DefaultHost, scyllav2client.DefaultBasePath, []string{config.Scheme}, c,
+	)
+	scyllaV2Runtime.Debug = false
+
+	scyllaV2Client := scyllav2client.New(scyllaV2Runtime, strfmt.Default)
+
 	return &Client{
-		config:       config,
-		scyllaClient: scyllaClient,
-		transport:    transport,
-		pool:         pool,
+		config:         config,
+		scyllaClient:   scyllaClient,
+		scyllaV2Client: scyllaV2Client,
+		transport:      transport,
+		pool:           pool,
 	}, nil
 }
 
@@ -251,6 +263,18 @@ func (c *Client) GetSnitchDatacenter(ctx context.Context, host string) (string,
 	return resp.GetPayload(), nil
 }
 
+func (c *Client) GetSnitchRack(ctx context.Context, host string) (string, error) {
+	resp, err := c.scyllaClient.Operations.SnitchRackGet(&scyllaoperations.SnitchRackGetParams{
+		Context: ctx,
+		Host:    &host,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetPayload(), nil
+}
+
 const (
 	snapshotTimeout = 5 * time.Minute
 	drainTimeout    = 5 * time.Minute
@@ -265,6 +289,54 @@ func (c *Client) Keyspaces(ctx context.Context) ([]string, error) {
 	return resp.Payload, nil
 }
 
+// KeyspaceReplicas returns the replicas of each token range of the keyspace.
+func (c *Client) KeyspaceReplicas(ctx context.Context, keyspace string) ([][]string, error) {
+	resp, err := c.scyllaClient.Operations.StorageServiceDescribeRingByKeyspaceGet(&scyllaoperations.StorageServiceDescribeRingByKeyspaceGetParams{
+		Context:  ctx,
+		Keyspace: keyspace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := make([][]string, 0, len(resp.Payload))
+	for _, tr := range resp.Payload {
+		replicas = append(replicas, tr.Endpoints)
+	}
+
+	return replicas, nil
+}
+
+// KeyspaceTableCounts returns the number of tables in each keyspace.
+func (c *Client) KeyspaceTableCounts(ctx context.Context) (map[string]int32, error) {
+	keyspaces, err := c.Keyspaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get keyspaces: %w", err)
+	}
+
+	counts := make(map[string]int32, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		counts[keyspace] = 0
+	}
+
+	resp, err := c.scyllaClient.Operations.ColumnFamilyNameGet(&scyllaoperations.ColumnFamilyNameGetParams{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("can't get tables: %w", err)
+	}
+
+	// Tables are listed as "keyspace:table".
+	for _, name := range resp.Payload {
+		keyspace, _, found := strings.Cut(name, ":")
+		if !found {
+			return nil, fmt.Errorf("unexpected table name %q", name)
+		}
+
+		counts[keyspace]++
+	}
+
+	return counts, nil
+}
+
 // Snapshots lists available snapshots.
 func (c *Client) Snapshots(ctx context.Context, host string) ([]string, error) {
 	ctx = customTimeout(ctx, snapshotTimeout)
@@ -355,6 +427,48 @@ func (c *Client) Decommission(ctx context.Context, host string) error {
 	return nil
 }
 
+// supportedFeaturesApplicationState is the index of the gossip application state listing features supported by a node.
+const supportedFeaturesApplicationState = 14
+
+// EnabledFeatures returns the features supported by all nodes in the gossip view of the host,
+// which is when ScyllaDB enables them in the cluster.
+func (c *Client) EnabledFeatures(ctx context.Context, host string) ([]string, error) {
+	resp, err := c.scyllaClient.Operations.FailureDetectorEndpointsGet(&scyllaoperations.FailureDetectorEndpointsGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return nil, err
+	}
+
+	var enabled *strset.Set
+	for _, es := range resp.Payload {
+		supported := strset.New()
+		for _, as := range es.ApplicationState {
+			if as.ApplicationState != supportedFeaturesApplicationState {
+				continue
+			}
+
+			for _, f := range strings.Split(as.Value, ",") {
+				if len(f) != 0 {
+					supported.Add(f)
+				}
+			}
+		}
+
+		if enabled == nil {
+			enabled = supported
+		} else {
+			enabled = strset.Intersection(enabled, supported)
+		}
+	}
+
+	if enabled == nil {
+		return nil, nil
+	}
+
+	features := enabled.List()
+	sort.Strings(features)
+	return features, nil
+}
+
 func (c *Client) ScyllaVersion(ctx context.Context) (string, error) {
 	resp, err := c.scyllaClient.Operations.StorageServiceScyllaReleaseVersionGet(&scyllaoperations.StorageServiceScyllaReleaseVersionGetParams{Context: ctx})
 	if err != nil {
@@ -363,6 +477,67 @@ func (c *Client) ScyllaVersion(ctx context.Context) (string, error) {
 	return resp.Payload, nil
 }
 
+// NodeScyllaVersion returns the ScyllaDB release version running on the host.
+func (c *Client) NodeScyllaVersion(ctx context.Context, host string) (string, error) {
+	resp, err := c.scyllaClient.Operations.StorageServiceScyllaReleaseVersionGet(&scyllaoperations.StorageServiceScyllaReleaseVersionGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return "", err
+	}
+	return resp.Payload, nil
+}
+
+// Uptime returns the time since the ScyllaDB process on the host started.
+func (c *Client) Uptime(ctx context.Context, host string) (time.Duration, error) {
+	resp, err := c.scyllaClient.Operations.SystemUptimeMsGet(&scyllaoperations.SystemUptimeMsGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(resp.Payload) * time.Millisecond, nil
+}
+
+// ListenAddress returns the value of "listen_address" config parameter of the node.
+func (c *Client) ListenAddress(ctx context.Context, host string) (string, error) {
+	resp, err := c.scyllaV2Client.Config.FindConfigListenAddress(scyllav2config.NewFindConfigListenAddressParamsWithContext(forceHost(ctx, host)))
+	if err != nil {
+		return "", fmt.Errorf("can't get listen_address: %w", err)
+	}
+
+	return resp.Payload, nil
+}
+
+// parseInt64Number converts a number decoded from an untyped JSON response to int64.
+// Responses are decoded either as json.Number or float64, depending on the decoder settings.
+func parseInt64Number(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("can't parse number %q: %w", n, err)
+		}
+		return int64(f), nil
+	case float64:
+		return int64(n), nil
+	default:
+		return 0, fmt.Errorf("unexpected number type %T", v)
+	}
+}
+
+// Load returns the number of bytes of data stored on the node.
+func (c *Client) Load(ctx context.Context, host string) (int64, error) {
+	resp, err := c.scyllaClient.Operations.StorageServiceLoadGet(&scyllaoperations.StorageServiceLoadGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return 0, err
+	}
+
+	load, err := parseInt64Number(resp.Payload)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse load: %w", err)
+	}
+
+	return load, nil
+}
+
 func (c *Client) OperationMode(ctx context.Context, host string) (OperationalMode, error) {
 	resp, err := c.scyllaClient.Operations.StorageServiceOperationModeGet(&scyllaoperations.StorageServiceOperationModeGetParams{Context: forceHost(ctx, host)})
 	if err != nil {
@@ -371,6 +546,15 @@ func (c *Client) OperationMode(ctx context.Context, host string) (OperationalMod
 	return operationalModeFromString(resp.Payload), nil
 }
 
+// IsStarting returns true while the host is starting up, which includes replaying its commitlog.
+func (c *Client) IsStarting(ctx context.Context, host string) (bool, error) {
+	resp, err := c.scyllaClient.Operations.StorageServiceIsStartingGet(&scyllaoperations.StorageServiceIsStartingGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return false, err
+	}
+	return resp.Payload, nil
+}
+
 func (c *Client) IsNativeTransportEnabled(ctx context.Context, host string) (bool, error) {
 	resp, err := c.scyllaClient.Operations.StorageServiceNativeTransportGet(&scyllaoperations.StorageServiceNativeTransportGetParams{Context: forceHost(ctx, host)})
 	if err != nil {
@@ -379,6 +563,88 @@ func (c *Client) IsNativeTransportEnabled(ctx context.Context, host string) (boo
 	return resp.Payload, nil
 }
 
+func (c *Client) GetSchemaVersion(ctx context.Context, host string, retry bool) (string, error) {
+	if len(host) > 0 {
+		ctx = forceHost(ctx, host)
+	}
+
+	if !retry {
+		ctx = noRetry(ctx)
+	}
+
+	resp, err := c.scyllaClient.Operations.StorageServiceSchemaVersionGet(&scyllaoperations.StorageServiceSchemaVersionGetParams{Context: ctx})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetPayload(), nil
+}
+
+// SchemaVersions returns the hosts holding each schema version in the cluster, as seen by the host.
+func (c *Client) SchemaVersions(ctx context.Context, host string) (map[string][]string, error) {
+	resp, err := c.scyllaClient.Operations.StorageProxySchemaVersionsGet(&scyllaoperations.StorageProxySchemaVersionsGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string][]string, len(resp.Payload))
+	for _, kv := range resp.Payload {
+		versions[kv.Key] = append(versions[kv.Key], kv.Value...)
+	}
+
+	return versions, nil
+}
+
+// CompactedKeyspaces returns the sorted keyspaces that have entries in the compaction history of the host.
+func (c *Client) CompactedKeyspaces(ctx context.Context, host string) ([]string, error) {
+	resp, err := c.scyllaClient.Operations.CompactionManagerCompactionHistoryGet(&scyllaoperations.CompactionManagerCompactionHistoryGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return nil, err
+	}
+
+	keyspaces := strset.New()
+	for _, h := range resp.Payload {
+		if h != nil && len(h.Ks) != 0 {
+			keyspaces.Add(h.Ks)
+		}
+	}
+
+	res := keyspaces.List()
+	sort.Strings(res)
+
+	return res, nil
+}
+
+// MaxPartitionSize returns the size in bytes of the largest partition compacted on the host, across all tables.
+func (c *Client) MaxPartitionSize(ctx context.Context, host string) (int64, error) {
+	resp, err := c.scyllaClient.Operations.ColumnFamilyMetricsMaxRowSizeGet(&scyllaoperations.ColumnFamilyMetricsMaxRowSizeGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := parseInt64Number(resp.Payload)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse max partition size: %w", err)
+	}
+
+	return size, nil
+}
+
+// SnapshotSize returns the number of bytes taken by snapshots on the host, excluding the data they share with live tables.
+func (c *Client) SnapshotSize(ctx context.Context, host string) (int64, error) {
+	resp, err := c.scyllaClient.Operations.StorageServiceSnapshotsSizeTrueGet(&scyllaoperations.StorageServiceSnapshotsSizeTrueGetParams{Context: forceHost(ctx, host)})
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := parseInt64Number(resp.Payload)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse snapshot size: %w", err)
+	}
+
+	return size, nil
+}
+
 func (c *Client) HasSchemaAgreement(ctx context.Context) (bool, error) {
 	resp, err := c.scyllaClient.Operations.StorageProxySchemaVersionsGet(&scyllaoperations.StorageProxySchemaVersionsGetParams{Context: ctx})
 	if err != nil {
@@ -0,0 +1,49 @@
+package scyllaclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ClockOffset estimates the offset of the clock of the host relative to the local clock,
+// based on the Date header of a response of the host. The header has a resolution of one second,
+// which bounds the precision of the estimate. ClockOffset requests are not retried.
+func (c *Client) ClockOffset(ctx context.Context, host string) (time.Duration, error) {
+	ctx = noRetry(forceHost(ctx, host))
+
+	u := c.newURL(host, "/storage_service/host_id/local")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("can't create clock request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return 0, fmt.Errorf("can't get node time: %w", err)
+	}
+	defer resp.Body.Close()
+	end := time.Now()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("can't get node time: unexpected status code %d", resp.StatusCode)
+	}
+
+	date := resp.Header.Get("Date")
+	if len(date) == 0 {
+		return 0, fmt.Errorf("can't get node time: response doesn't have a Date header")
+	}
+
+	nodeTime, err := http.ParseTime(date)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse node time %q: %w", date, err)
+	}
+
+	// The header is truncated to seconds, so the middle of the second is the best estimate of the node time.
+	nodeTime = nodeTime.Add(500 * time.Millisecond)
+	localTime := start.Add(end.Sub(start) / 2)
+
+	return nodeTime.Sub(localTime), nil
+}
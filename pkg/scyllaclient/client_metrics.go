@@ -0,0 +1,331 @@
+package scyllaclient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// shardCountMetricName is a metric that is exported once for every shard.
+	shardCountMetricName = "database_total_writes"
+
+	// cqlConnectionsMetricName is a per-shard metric of currently open CQL client connections.
+	cqlConnectionsMetricName = "transport_current_connections"
+
+	// schedulerSharesMetricName is a per-shard metric exported for every scheduling group.
+	schedulerSharesMetricName = "scheduler_shares"
+
+	// serviceLevelSchedulingGroupPrefix is the prefix of names of scheduling groups created for service levels.
+	serviceLevelSchedulingGroupPrefix = "sl:"
+
+	// coordinatorLatencyMetricNameFormat is the format of per-shard histograms of coordinator latencies
+	// of an operation, in microseconds.
+	coordinatorLatencyMetricNameFormat = "storage_proxy_coordinator_%s_latency"
+)
+
+const (
+	// ReadOperation identifies coordinator reads.
+	ReadOperation = "read"
+
+	// WriteOperation identifies coordinator writes.
+	WriteOperation = "write"
+)
+
+var (
+	shardLabelRegexp = regexp.MustCompile(`[{,]shard="([^"]*)"`)
+	groupLabelRegexp = regexp.MustCompile(`[{,]group="([^"]*)"`)
+	leLabelRegexp    = regexp.MustCompile(`[{,]le="([^"]*)"`)
+)
+
+// getMetrics returns the agent metrics of the host with the given name in Prometheus text exposition format.
+// The caller is responsible for closing the returned body. Metrics requests are not retried.
+func (c *Client) getMetrics(ctx context.Context, host string, name string) (io.ReadCloser, error) {
+	ctx = noRetry(forceHost(ctx, host))
+
+	u := c.newURL(host, "/metrics")
+	q := u.Query()
+	q.Add("name", name)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't create metrics request: %w", err)
+	}
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("can't get metrics: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("can't get metrics: unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+// ShardCount returns the number of shards of the node, determined from the per-shard metrics exposed by the agent.
+// ShardCount requests are not retried.
+func (c *Client) ShardCount(ctx context.Context, host string) (int32, error) {
+	body, err := c.getMetrics(ctx, host, shardCountMetricName)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	return countMetricShards(body, "scylla_"+shardCountMetricName)
+}
+
+// CQLConnections returns the number of client connections currently open to the CQL port of the node,
+// summed over all shards. CQLConnections requests are not retried.
+func (c *Client) CQLConnections(ctx context.Context, host string) (int32, error) {
+	body, err := c.getMetrics(ctx, host, cqlConnectionsMetricName)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	return ParseCQLConnections(body)
+}
+
+// ParseCQLConnections returns the number of open CQL client connections, summed over all shards,
+// from metrics in Prometheus text exposition format.
+func ParseCQLConnections(r io.Reader) (int32, error) {
+	sum, err := sumMetric(r, "scylla_"+cqlConnectionsMetricName)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(sum), nil
+}
+
+// ServiceLevelSchedulingGroups returns the names of service levels that have a scheduling group on the node,
+// which is the case for service levels used by workload prioritization. ServiceLevelSchedulingGroups requests are not retried.
+func (c *Client) ServiceLevelSchedulingGroups(ctx context.Context, host string) ([]string, error) {
+	body, err := c.getMetrics(ctx, host, schedulerSharesMetricName)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	groups, err := getMetricGroups(body, "scylla_"+schedulerSharesMetricName)
+	if err != nil {
+		return nil, err
+	}
+
+	var serviceLevels []string
+	for _, g := range groups {
+		serviceLevel, ok := strings.CutPrefix(g, serviceLevelSchedulingGroupPrefix)
+		if ok {
+			serviceLevels = append(serviceLevels, serviceLevel)
+		}
+	}
+
+	return serviceLevels, nil
+}
+
+// CoordinatorLatencyQuantile returns the q-quantile of latencies of the operation coordinated by the node
+// since it started, estimated from the latency histogram exposed by the agent. The estimate is the upper bound
+// of the histogram bucket the quantile falls into. CoordinatorLatencyQuantile requests are not retried.
+func (c *Client) CoordinatorLatencyQuantile(ctx context.Context, host string, operation string, q float64) (time.Duration, error) {
+	metricName := fmt.Sprintf(coordinatorLatencyMetricNameFormat, operation)
+	body, err := c.getMetrics(ctx, host, metricName)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	v, err := histogramQuantile(body, "scylla_"+metricName, q)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(v) * time.Microsecond, nil
+}
+
+// isMetricSample returns true if the line is a labeled sample of the given metric.
+func isMetricSample(line string, metricName string) bool {
+	return len(line) > len(metricName) && line[:len(metricName)] == metricName && line[len(metricName)] == '{'
+}
+
+// sumMetric sums the values of all labeled samples of the given metric in Prometheus text exposition format.
+func sumMetric(r io.Reader, metricName string) (float64, error) {
+	found := false
+	var sum float64
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !isMetricSample(line, metricName) {
+			continue
+		}
+
+		labelsEnd := strings.LastIndexByte(line, '}')
+		if labelsEnd < 0 {
+			continue
+		}
+
+		fields := strings.Fields(line[labelsEnd+1:])
+		if len(fields) == 0 {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("can't parse value of metric %q: %w", metricName, err)
+		}
+
+		sum += v
+		found = true
+	}
+	err := scanner.Err()
+	if err != nil {
+		return 0, fmt.Errorf("can't read metrics: %w", err)
+	}
+
+	if !found {
+		return 0, fmt.Errorf("metric %q not found", metricName)
+	}
+
+	return sum, nil
+}
+
+// histogramQuantile estimates the q-quantile of the given histogram metric in Prometheus text exposition format,
+// aggregated over all its labels, as the upper bound of the bucket the quantile falls into.
+func histogramQuantile(r io.Reader, metricName string, q float64) (float64, error) {
+	bucketMetricName := metricName + "_bucket"
+	buckets := map[float64]float64{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !isMetricSample(line, bucketMetricName) {
+			continue
+		}
+
+		m := leLabelRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		le, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("can't parse bucket bound %q of metric %q: %w", m[1], metricName, err)
+		}
+
+		labelsEnd := strings.LastIndexByte(line, '}')
+		fields := strings.Fields(line[labelsEnd+1:])
+		if len(fields) == 0 {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, fmt.Errorf("can't parse value of metric %q: %w", metricName, err)
+		}
+
+		buckets[le] += v
+	}
+	err := scanner.Err()
+	if err != nil {
+		return 0, fmt.Errorf("can't read metrics: %w", err)
+	}
+
+	bounds := slices.Sorted(maps.Keys(buckets))
+	if len(bounds) == 0 {
+		return 0, fmt.Errorf("metric %q not found", bucketMetricName)
+	}
+
+	// Buckets are cumulative, so the last one counts all samples.
+	total := buckets[bounds[len(bounds)-1]]
+	if total == 0 {
+		return 0, fmt.Errorf("metric %q doesn't have any samples", metricName)
+	}
+
+	rank := q * total
+	upperBound := bounds[0]
+	for _, le := range bounds {
+		if math.IsInf(le, 1) {
+			break
+		}
+
+		upperBound = le
+		if buckets[le] >= rank {
+			break
+		}
+	}
+
+	return upperBound, nil
+}
+
+// countMetricShards counts the distinct shard labels of the given metric in Prometheus text exposition format.
+func countMetricShards(r io.Reader, metricName string) (int32, error) {
+	shards := map[string]struct{}{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !isMetricSample(line, metricName) {
+			continue
+		}
+
+		m := shardLabelRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		shards[m[1]] = struct{}{}
+	}
+	err := scanner.Err()
+	if err != nil {
+		return 0, fmt.Errorf("can't read metrics: %w", err)
+	}
+
+	if len(shards) == 0 {
+		return 0, fmt.Errorf("metric %q with shard label not found", metricName)
+	}
+
+	return int32(len(shards)), nil
+}
+
+// getMetricGroups returns the distinct group labels of the given metric in Prometheus text exposition format,
+// in the order of their first occurrence.
+func getMetricGroups(r io.Reader, metricName string) ([]string, error) {
+	var groups []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !isMetricSample(line, metricName) {
+			continue
+		}
+
+		m := groupLabelRegexp.FindStringSubmatch(line)
+		if m == nil || slices.Contains(groups, m[1]) {
+			continue
+		}
+
+		groups = append(groups, m[1])
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("can't read metrics: %w", err)
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("metric %q with group label not found", metricName)
+	}
+
+	return groups, nil
+}
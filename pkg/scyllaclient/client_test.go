@@ -0,0 +1,70 @@
+package scyllaclient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseInt64Number(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		value         interface{}
+		expected      int64
+		expectedError bool
+	}{
+		{
+			name:     "json number",
+			value:    json.Number("1073741824"),
+			expected: 1073741824,
+		},
+		{
+			name:     "json number in exponent notation",
+			value:    json.Number("1.5e3"),
+			expected: 1500,
+		},
+		{
+			name:     "float",
+			value:    float64(42),
+			expected: 42,
+		},
+		{
+			name:          "malformed json number",
+			value:         json.Number("abc"),
+			expectedError: true,
+		},
+		{
+			name:          "unexpected type",
+			value:         "42",
+			expectedError: true,
+		},
+		{
+			name:          "nil",
+			value:         nil,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseInt64Number(tc.value)
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got %d", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package scyllaclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrTopologyUpgradeStateUnsupported is returned when the host doesn't expose the state of the upgrade
+	// to raft-based topology, which is the case for ScyllaDB versions that don't support it.
+	ErrTopologyUpgradeStateUnsupported = errors.New("topology upgrade state is not supported")
+)
+
+const (
+	// TopologyUpgradeStateDone is the state of the upgrade to raft-based topology in which
+	// the cluster uses consistent topology changes.
+	TopologyUpgradeStateDone = "done"
+)
+
+// TopologyUpgradeState returns the state of the upgrade of the cluster to raft-based topology as seen by the host,
+// e.g. "not_upgraded" or "done". TopologyUpgradeState requests are not retried.
+func (c *Client) TopologyUpgradeState(ctx context.Context, host string) (string, error) {
+	ctx = noRetry(forceHost(ctx, host))
+
+	u := c.newURL(host, "/storage_service/raft_topology/upgrade")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("can't create topology upgrade state request: %w", err)
+	}
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("can't get topology upgrade state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", ErrTopologyUpgradeStateUnsupported
+	default:
+		return "", fmt.Errorf("can't get topology upgrade state: unexpected status code %d", resp.StatusCode)
+	}
+
+	var state string
+	err = json.NewDecoder(resp.Body).Decode(&state)
+	if err != nil {
+		return "", fmt.Errorf("can't decode topology upgrade state: %w", err)
+	}
+
+	return state, nil
+}